@@ -2,66 +2,194 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	adminpb "github.com/hunderaweke/gostream/gen/go/admin"
+	analyticspb "github.com/hunderaweke/gostream/gen/go/analytics"
 	authpb "github.com/hunderaweke/gostream/gen/go/auth"
+	commentpb "github.com/hunderaweke/gostream/gen/go/comment"
+	historypb "github.com/hunderaweke/gostream/gen/go/history"
+	playlistpb "github.com/hunderaweke/gostream/gen/go/playlist"
+	reactionpb "github.com/hunderaweke/gostream/gen/go/reaction"
+	recommendationpb "github.com/hunderaweke/gostream/gen/go/recommendation"
+	subscriptionpb "github.com/hunderaweke/gostream/gen/go/subscription"
+	userpb "github.com/hunderaweke/gostream/gen/go/user"
 	videopb "github.com/hunderaweke/gostream/gen/go/video"
+	"github.com/hunderaweke/gostream/internal/apierror"
+	"github.com/hunderaweke/gostream/internal/auth"
 	"github.com/hunderaweke/gostream/internal/database"
+	"github.com/hunderaweke/gostream/internal/domain"
 	grpcserver "github.com/hunderaweke/gostream/internal/grpc_server"
+	"github.com/hunderaweke/gostream/internal/logging"
+	"github.com/hunderaweke/gostream/internal/mailer"
+	"github.com/hunderaweke/gostream/internal/metrics"
 	"github.com/hunderaweke/gostream/internal/queue"
 	"github.com/hunderaweke/gostream/internal/repository"
 	"github.com/hunderaweke/gostream/internal/server/handlers"
+	"github.com/hunderaweke/gostream/internal/tracing"
 	"github.com/hunderaweke/gostream/internal/usecase"
 	"github.com/hunderaweke/gostream/pkg/interceptors"
+	"github.com/hunderaweke/gostream/pkg/utils"
 	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func main() {
 	var wg sync.WaitGroup
 	if err := godotenv.Load(); err != nil {
-		log.Fatalf("error loading .env file: %v", err)
+		fatal("error loading .env file", err)
 	}
+	if err := logging.Setup(); err != nil {
+		fatal("error setting up logging", err)
+	}
+	keySet, err := utils.LoadKeySetFromEnv()
+	if err != nil {
+		fatal("error loading token signing keys", err)
+	}
+	utils.SetKeySet(keySet)
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		fatal("error setting up tracing", err)
+	}
+	defer func() {
+		shutCtx, shutCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutCancel()
+		if err := shutdownTracing(shutCtx); err != nil {
+			slog.Error("tracing shutdown error", "error", err)
+		}
+	}()
 	minioClient, err := database.NewMinioClient("gostream")
 	if err != nil {
-		log.Fatalf("error creating minio client: %v", err)
+		fatal("error creating minio client", err)
 	}
 	db, err := database.GetPostgresDB()
 	if err != nil {
-		log.Fatalf("error creating postgres connection: %v", err)
+		fatal("error creating postgres connection", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("error getting postgres connection pool", err)
+	}
+	if err := metrics.RegisterDBStats(sqlDB, "postgres"); err != nil {
+		fatal("error registering database metrics", err)
+	}
+	rdb, err := database.GetRedis()
+	if err != nil {
+		fatal("error creating redis client", err)
+	}
+	defer rdb.Close()
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		fatal("error connecting to redis", err)
+	}
+	userRepo := repository.NewUserRepository(db)
+	authUsecase := usecase.NewUserUsecase(userRepo, repository.NewVideoStorage(minioClient), repository.NewLoginAttemptRepository(rdb))
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(repository.NewAPIKeyRepository(db), userRepo)
+	sessionUsecase := usecase.NewSessionUsecase(repository.NewSessionRepository(db))
+	authenticator := auth.NewAuthenticator(apiKeyUsecase, sessionUsecase, userRepo)
+	mfaUsecase := usecase.NewMFAUsecase(repository.NewMFARepository(db), userRepo, repository.NewMFAChallengeRepository(rdb), repository.NewLoginAttemptRepository(rdb))
+	oidcProviders, err := auth.LoadOIDCProvidersFromEnv()
+	if err != nil {
+		fatal("error loading oidc providers", err)
 	}
-	authUsecase := usecase.NewUserUsecase(repository.NewUserRepository(db))
+	mail, err := mailer.FromEnv()
+	if err != nil {
+		fatal("error creating mailer", err)
+	}
+	verificationUsecase := usecase.NewEmailVerificationUsecase(repository.NewEmailVerificationRepository(db), userRepo, mail)
+	oidcUsecase := usecase.NewOIDCUsecase(oidcProviders, repository.NewOIDCStateRepository(rdb), repository.NewExternalIdentityRepository(db), authUsecase)
 	rmq, err := queue.NewRabbitMQ()
 	if err != nil {
-		log.Fatal(err)
+		fatal("error connecting to rabbitmq", err)
 	}
 	defer rmq.Close()
-	videoUsecase := usecase.NewVideoUsecase(repository.NewVideoRepository(db), minioClient, rmq)
-	authService := grpcserver.NewAuthService(authUsecase)
-	videoService := grpcserver.NewVideoService(minioClient, videoUsecase, rmq)
+	videoRepo := repository.NewVideoRepository(db)
+	videoUsecase := usecase.NewVideoUsecase(videoRepo, minioClient, rmq)
+	playlistUsecase := usecase.NewPlaylistUsecase(repository.NewPlaylistRepository(db), videoRepo)
+	commentRepo := repository.NewCommentRepository(db)
+	commentUsecase := usecase.NewCommentUsecase(commentRepo, videoRepo, userRepo)
+	reactionUsecase := usecase.NewReactionUsecase(repository.NewReactionRepository(db), videoRepo, commentRepo)
+	subscriptionUsecase := usecase.NewSubscriptionUsecase(repository.NewSubscriptionRepository(db), userRepo, videoRepo)
+	viewUsecase := usecase.NewViewUsecase(repository.NewViewRepository(rdb), videoRepo)
+	watchHistoryRepo := repository.NewWatchHistoryRepository(db)
+	historyUsecase := usecase.NewWatchHistoryUsecase(watchHistoryRepo, repository.NewWatchProgressBuffer(rdb), videoRepo)
+	analyticsUsecase := usecase.NewAnalyticsUsecase(repository.NewAnalyticsStore(rdb), repository.NewAnalyticsRepository(db), videoRepo)
+	rankingStore := repository.NewRankingStore(rdb)
+	rankingUsecase := usecase.NewRankingUsecase(repository.NewRankingRepository(db), rankingStore, videoRepo)
+	// Co-watching is the strongest signal; trending only breaks ties and
+	// fills in for new users.
+	recommendationUsecase := usecase.NewRecommendationUsecase(videoRepo, watchHistoryRepo,
+		domain.WeightedSource{Source: repository.NewCoWatchCandidateSource(db), Weight: 1},
+		domain.WeightedSource{Source: repository.NewTagCandidateSource(db), Weight: 0.6},
+		domain.WeightedSource{Source: repository.NewTextCandidateSource(db), Weight: 0.6},
+		domain.WeightedSource{Source: usecase.NewRankingCandidateSource(rankingStore, domain.RankingTrending), Weight: 0.2},
+	)
+	if username := os.Getenv("ADMIN_USERNAME"); username != "" {
+		if err := promoteAdmin(context.Background(), authUsecase, username); err != nil {
+			slog.Warn("error promoting bootstrap admin", "username", username, "error", err)
+		}
+	}
+	authService := grpcserver.NewAuthService(authUsecase, mfaUsecase, oidcUsecase, verificationUsecase, sessionUsecase)
+	videoService := grpcserver.NewVideoService(minioClient, videoUsecase, viewUsecase, historyUsecase, rankingUsecase, rmq)
+	adminService := grpcserver.NewAdminService(authUsecase, videoUsecase)
+	apiKeyService := grpcserver.NewAPIKeyService(apiKeyUsecase)
+	userService := grpcserver.NewUserService(authUsecase, mfaUsecase, verificationUsecase, sessionUsecase)
+	playlistService := grpcserver.NewPlaylistService(playlistUsecase)
+	commentService := grpcserver.NewCommentService(commentUsecase)
+	reactionService := grpcserver.NewReactionService(reactionUsecase)
+	subscriptionService := grpcserver.NewSubscriptionService(subscriptionUsecase)
+	historyService := grpcserver.NewHistoryService(historyUsecase)
+	analyticsService := grpcserver.NewAnalyticsService(analyticsUsecase)
+	recommendationService := grpcserver.NewRecommendationService(recommendationUsecase)
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
-		log.Fatalf("error creating tcp server: %v", err)
+		fatal("error creating tcp server", err)
 	}
+	loggingInterceptor := interceptors.NewLoggingInterceptor()
+	metricsInterceptor := interceptors.NewMetricsInterceptor()
+	errorInterceptor := interceptors.NewErrorInterceptor()
+	authInterceptor := interceptors.NewAuthInterceptor(authenticator)
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptors.NewAuthInterceptor().Unary()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			loggingInterceptor.Unary(),
+			metricsInterceptor.Unary(),
+			errorInterceptor.Unary(),
+			authInterceptor.Unary(),
+		),
+		grpc.ChainStreamInterceptor(
+			loggingInterceptor.Stream(),
+			metricsInterceptor.Stream(),
+			errorInterceptor.Stream(),
+			authInterceptor.Stream(),
+		),
 	)
 	authpb.RegisterAuthServiceServer(grpcServer, authService)
 	videopb.RegisterVideoServiceServer(grpcServer, videoService)
-	errChan := make(chan error, 2)
+	adminpb.RegisterAdminServiceServer(grpcServer, adminService)
+	authpb.RegisterAPIKeyServiceServer(grpcServer, apiKeyService)
+	userpb.RegisterUserServiceServer(grpcServer, userService)
+	playlistpb.RegisterPlaylistServiceServer(grpcServer, playlistService)
+	commentpb.RegisterCommentServiceServer(grpcServer, commentService)
+	reactionpb.RegisterReactionServiceServer(grpcServer, reactionService)
+	subscriptionpb.RegisterSubscriptionServiceServer(grpcServer, subscriptionService)
+	historypb.RegisterHistoryServiceServer(grpcServer, historyService)
+	analyticspb.RegisterAnalyticsServiceServer(grpcServer, analyticsService)
+	recommendationpb.RegisterRecommendationServiceServer(grpcServer, recommendationService)
+	errChan := make(chan error, 3)
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -69,13 +197,16 @@ func main() {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		log.Println("Auth service has started running on port :50051....")
+		slog.Info("gRPC server listening", "addr", ":50051")
 		if err := grpcServer.Serve(lis); err != nil {
 			errChan <- fmt.Errorf("error serving gRPC: %w", err)
 		}
 	}()
 
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 			MarshalOptions: protojson.MarshalOptions{
@@ -86,48 +217,128 @@ func main() {
 				DiscardUnknown: true,
 			},
 		}),
-		runtime.WithErrorHandler(customErrorHandler),
+		runtime.WithErrorHandler(apierror.GatewayErrorHandler),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 	)
 	rootMux := http.NewServeMux()
 	rootMux.Handle("/", mux)
-	rootMux.HandleFunc("GET /v1/stream/", handlers.SecureStreamHandler(minioClient, videoUsecase))
-	rootMux.HandleFunc("POST /v1/upload/{video_id}", handlers.SecureUploadHandler(minioClient, videoUsecase))
+	rootMux.HandleFunc("GET /v1/stream/", metrics.InstrumentHandler("/v1/stream/", handlers.SecureStreamHandler(minioClient, videoUsecase, viewUsecase, analyticsUsecase, authenticator)))
+	rootMux.HandleFunc("POST /v1/upload/{video_id}", metrics.InstrumentHandler("/v1/upload/", handlers.SecureUploadHandler(minioClient, videoUsecase, authenticator)))
+	rootMux.HandleFunc("GET /.well-known/jwks.json", handlers.JWKSHandler())
 	if err = authpb.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
-		log.Fatalf("error registering auth handlers: %v", err)
+		fatal("error registering auth handlers", err)
+	}
+	if err = authpb.RegisterAPIKeyServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering api key handlers", err)
+	}
+	if err = userpb.RegisterUserServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering user handlers", err)
 	}
 	if err = videopb.RegisterVideoServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
-		log.Fatalf("error registering video handlers: %v", err)
+		fatal("error registering video handlers", err)
+	}
+	if err = adminpb.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering admin handlers", err)
+	}
+	if err = playlistpb.RegisterPlaylistServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering playlist handlers", err)
+	}
+	if err = commentpb.RegisterCommentServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering comment handlers", err)
+	}
+	if err = reactionpb.RegisterReactionServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering reaction handlers", err)
+	}
+	if err = subscriptionpb.RegisterSubscriptionServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering subscription handlers", err)
+	}
+	if err = historypb.RegisterHistoryServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering history handlers", err)
+	}
+	if err = analyticspb.RegisterAnalyticsServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering analytics handlers", err)
+	}
+	if err = recommendationpb.RegisterRecommendationServiceHandlerFromEndpoint(ctx, mux, ":50051", opts); err != nil {
+		fatal("error registering recommendation handlers", err)
 	}
 	httpServer := http.Server{
 		Addr:    ":8080",
-		Handler: allowCORS(rootMux),
+		Handler: otelhttp.NewHandler(logging.Middleware(allowCORS(rootMux)), "gateway"),
 	}
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		log.Println("HTTP Gateway listening to the port :8080")
+		slog.Info("HTTP gateway listening", "addr", ":8080")
 		if err := httpServer.ListenAndServe(); err != nil {
 			errChan <- fmt.Errorf("failed to listen to HTTP Server: %w", err)
 		}
 	}()
 
+	// Metrics are served on their own listener, kept off the public gateway.
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler())
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = ":9090"
+	}
+	metricsServer := http.Server{
+		Addr:    metricsAddr,
+		Handler: metricsMux,
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		slog.Info("metrics listening", "addr", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("failed to listen to metrics server: %w", err)
+		}
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		if err := rmq.ConsumeVideoQueue(ctx, minioClient, videoUsecase); err != nil {
+		if err := rmq.ConsumeVideoQueue(ctx, minioClient, videoUsecase, subscriptionUsecase); err != nil {
 			if ctx.Err() != nil {
 				errChan <- err
 			}
 			return
 		}
 	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		viewUsecase.RunFlusher(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		historyUsecase.RunFlusher(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		analyticsUsecase.RunRollups(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rankingUsecase.RunRefresher(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := rmq.WatchQueueDepth(ctx, 15*time.Second); err != nil {
+			slog.Error("queue depth watcher stopped", "error", err)
+		}
+	}()
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	select {
 	case err := <-errChan:
-		log.Printf("shutdown triggered by server error: %v", err)
+		slog.Error("shutdown triggered by server error", "error", err)
 	case sig := <-sigCh:
-		log.Printf("shutdown triggered by signal: %v", sig)
+		slog.Info("shutdown triggered by signal", "signal", sig.String())
 	}
 	cancel()
 	go func() {
@@ -137,17 +348,67 @@ func main() {
 	shutCtx, shutCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutCancel()
 	if err := httpServer.Shutdown(shutCtx); err != nil {
-		log.Printf("http server shutdown error: %v", err)
+		slog.Error("http server shutdown error", "error", err)
+	}
+	if err := metricsServer.Shutdown(shutCtx); err != nil {
+		slog.Error("metrics server shutdown error", "error", err)
 	}
 	wg.Wait()
-	log.Printf("servers stopped, exiting")
+	slog.Info("servers stopped, exiting")
+}
+
+// promoteAdmin grants the admin role to an existing user so a fresh
+// deployment has someone who can manage roles through the AdminService.
+func promoteAdmin(ctx context.Context, users domain.UserService, username string) error {
+	user, err := users.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("user %q not found", username)
+	}
+	if user.Role == domain.RoleAdmin {
+		return nil
+	}
+	_, err = users.SetRole(ctx, user.ID, domain.RoleAdmin)
+	return err
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// incomingHeaderMatcher forwards the request ID and API key headers to the
+// gRPC server in addition to the gateway's default set of headers.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, logging.RequestIDHeader) {
+		return "x-request-id", true
+	}
+	if strings.EqualFold(key, auth.APIKeyHeader) {
+		return "x-api-key", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher drops the echoed request ID, which the logging
+// middleware already sets on the response, and passes cookies through.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case "x-request-id":
+		return "", false
+	case "set-cookie":
+		return "Set-Cookie", true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
 }
 
 func allowCORS(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Api-Key, X-Request-Id")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-Id, Retry-After")
 
 		if r.Method == "OPTIONS" {
 			return
@@ -156,13 +417,3 @@ func allowCORS(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
-func customErrorHandler(ctx context.Context, sm *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
-	s := status.Convert(err)
-	httpStatusCode := runtime.HTTPStatusFromCode(s.Code())
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(httpStatusCode)
-	response := map[string]string{
-		"error": s.Message(),
-	}
-	json.NewEncoder(w).Encode(response)
-}
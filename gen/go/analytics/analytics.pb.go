@@ -0,0 +1,624 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: analytics.proto
+
+package analyticspb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlaybackEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// Chosen by the player, the same for every event of one playback.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// START, REBUFFER, BITRATE_SWITCH, ERROR or WATCH_TIME.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// WATCH_TIME: seconds watched since the previous WATCH_TIME event.
+	WatchSeconds float64 `protobuf:"fixed64,4,opt,name=watch_seconds,json=watchSeconds,proto3" json:"watch_seconds,omitempty"`
+	// REBUFFER: how long playback stalled.
+	RebufferMs int64 `protobuf:"varint,5,opt,name=rebuffer_ms,json=rebufferMs,proto3" json:"rebuffer_ms,omitempty"`
+	// BITRATE_SWITCH: the new bitrate.
+	BitrateKbps int64 `protobuf:"varint,6,opt,name=bitrate_kbps,json=bitrateKbps,proto3" json:"bitrate_kbps,omitempty"`
+	// The HLS segment playing when the event happened.
+	SegmentIndex *int32 `protobuf:"varint,7,opt,name=segment_index,json=segmentIndex,proto3,oneof" json:"segment_index,omitempty"`
+	// ERROR: the player's error code.
+	ErrorCode     string `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaybackEvent) Reset() {
+	*x = PlaybackEvent{}
+	mi := &file_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaybackEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackEvent) ProtoMessage() {}
+
+func (x *PlaybackEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackEvent.ProtoReflect.Descriptor instead.
+func (*PlaybackEvent) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlaybackEvent) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *PlaybackEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PlaybackEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PlaybackEvent) GetWatchSeconds() float64 {
+	if x != nil {
+		return x.WatchSeconds
+	}
+	return 0
+}
+
+func (x *PlaybackEvent) GetRebufferMs() int64 {
+	if x != nil {
+		return x.RebufferMs
+	}
+	return 0
+}
+
+func (x *PlaybackEvent) GetBitrateKbps() int64 {
+	if x != nil {
+		return x.BitrateKbps
+	}
+	return 0
+}
+
+func (x *PlaybackEvent) GetSegmentIndex() int32 {
+	if x != nil && x.SegmentIndex != nil {
+		return *x.SegmentIndex
+	}
+	return 0
+}
+
+func (x *PlaybackEvent) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type IngestPlaybackEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*PlaybackEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestPlaybackEventsRequest) Reset() {
+	*x = IngestPlaybackEventsRequest{}
+	mi := &file_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestPlaybackEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestPlaybackEventsRequest) ProtoMessage() {}
+
+func (x *IngestPlaybackEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestPlaybackEventsRequest.ProtoReflect.Descriptor instead.
+func (*IngestPlaybackEventsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IngestPlaybackEventsRequest) GetEvents() []*PlaybackEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type IngestPlaybackEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestPlaybackEventsResponse) Reset() {
+	*x = IngestPlaybackEventsResponse{}
+	mi := &file_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestPlaybackEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestPlaybackEventsResponse) ProtoMessage() {}
+
+func (x *IngestPlaybackEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestPlaybackEventsResponse.ProtoReflect.Descriptor instead.
+func (*IngestPlaybackEventsResponse) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IngestPlaybackEventsResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+type GetVideoAnalyticsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// RFC 3339; defaults to seven days before end_time.
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// RFC 3339; defaults to now.
+	EndTime string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// HOUR or DAY; defaults to HOUR for ranges up to three days.
+	Granularity   string `protobuf:"bytes,4,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoAnalyticsRequest) Reset() {
+	*x = GetVideoAnalyticsRequest{}
+	mi := &file_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetVideoAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetVideoAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetVideoAnalyticsRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoAnalyticsRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *GetVideoAnalyticsRequest) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *GetVideoAnalyticsRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+type AnalyticsBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Start string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Playback starts.
+	Views           int64   `protobuf:"varint,2,opt,name=views,proto3" json:"views,omitempty"`
+	UniqueViewers   int64   `protobuf:"varint,3,opt,name=unique_viewers,json=uniqueViewers,proto3" json:"unique_viewers,omitempty"`
+	WatchSeconds    float64 `protobuf:"fixed64,4,opt,name=watch_seconds,json=watchSeconds,proto3" json:"watch_seconds,omitempty"`
+	AvgWatchSeconds float64 `protobuf:"fixed64,5,opt,name=avg_watch_seconds,json=avgWatchSeconds,proto3" json:"avg_watch_seconds,omitempty"`
+	Rebuffers       int64   `protobuf:"varint,6,opt,name=rebuffers,proto3" json:"rebuffers,omitempty"`
+	RebufferMs      int64   `protobuf:"varint,7,opt,name=rebuffer_ms,json=rebufferMs,proto3" json:"rebuffer_ms,omitempty"`
+	BitrateSwitches int64   `protobuf:"varint,8,opt,name=bitrate_switches,json=bitrateSwitches,proto3" json:"bitrate_switches,omitempty"`
+	Errors          int64   `protobuf:"varint,9,opt,name=errors,proto3" json:"errors,omitempty"`
+	// Bytes of playlists and segments streamed.
+	BytesServed   int64 `protobuf:"varint,10,opt,name=bytes_served,json=bytesServed,proto3" json:"bytes_served,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyticsBucket) Reset() {
+	*x = AnalyticsBucket{}
+	mi := &file_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsBucket) ProtoMessage() {}
+
+func (x *AnalyticsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsBucket.ProtoReflect.Descriptor instead.
+func (*AnalyticsBucket) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AnalyticsBucket) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *AnalyticsBucket) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetUniqueViewers() int64 {
+	if x != nil {
+		return x.UniqueViewers
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetWatchSeconds() float64 {
+	if x != nil {
+		return x.WatchSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetAvgWatchSeconds() float64 {
+	if x != nil {
+		return x.AvgWatchSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetRebuffers() int64 {
+	if x != nil {
+		return x.Rebuffers
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetRebufferMs() int64 {
+	if x != nil {
+		return x.RebufferMs
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetBitrateSwitches() int64 {
+	if x != nil {
+		return x.BitrateSwitches
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetBytesServed() int64 {
+	if x != nil {
+		return x.BytesServed
+	}
+	return 0
+}
+
+type VideoAnalytics struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	VideoId     string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	StartTime   string                 `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     string                 `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Granularity string                 `protobuf:"bytes,4,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	Totals      *AnalyticsBucket       `protobuf:"bytes,5,opt,name=totals,proto3" json:"totals,omitempty"`
+	Buckets     []*AnalyticsBucket     `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// Share of playbacks that reached each segment.
+	Retention      []float64 `protobuf:"fixed64,7,rep,packed,name=retention,proto3" json:"retention,omitempty"`
+	SegmentSeconds int32     `protobuf:"varint,8,opt,name=segment_seconds,json=segmentSeconds,proto3" json:"segment_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VideoAnalytics) Reset() {
+	*x = VideoAnalytics{}
+	mi := &file_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoAnalytics) ProtoMessage() {}
+
+func (x *VideoAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoAnalytics.ProtoReflect.Descriptor instead.
+func (*VideoAnalytics) Descriptor() ([]byte, []int) {
+	return file_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VideoAnalytics) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *VideoAnalytics) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *VideoAnalytics) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *VideoAnalytics) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *VideoAnalytics) GetTotals() *AnalyticsBucket {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *VideoAnalytics) GetBuckets() []*AnalyticsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *VideoAnalytics) GetRetention() []float64 {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *VideoAnalytics) GetSegmentSeconds() int32 {
+	if x != nil {
+		return x.SegmentSeconds
+	}
+	return 0
+}
+
+var File_analytics_proto protoreflect.FileDescriptor
+
+const file_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x0fanalytics.proto\x12\x15gostream.analytics.v1\x1a\x1cgoogle/api/annotations.proto\"\xa1\x02\n" +
+	"\rPlaybackEvent\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12#\n" +
+	"\rwatch_seconds\x18\x04 \x01(\x01R\fwatchSeconds\x12\x1f\n" +
+	"\vrebuffer_ms\x18\x05 \x01(\x03R\n" +
+	"rebufferMs\x12!\n" +
+	"\fbitrate_kbps\x18\x06 \x01(\x03R\vbitrateKbps\x12(\n" +
+	"\rsegment_index\x18\a \x01(\x05H\x00R\fsegmentIndex\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\b \x01(\tR\terrorCodeB\x10\n" +
+	"\x0e_segment_index\"[\n" +
+	"\x1bIngestPlaybackEventsRequest\x12<\n" +
+	"\x06events\x18\x01 \x03(\v2$.gostream.analytics.v1.PlaybackEventR\x06events\":\n" +
+	"\x1cIngestPlaybackEventsResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\"\x91\x01\n" +
+	"\x18GetVideoAnalyticsRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\tR\aendTime\x12 \n" +
+	"\vgranularity\x18\x04 \x01(\tR\vgranularity\"\xda\x02\n" +
+	"\x0fAnalyticsBucket\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x14\n" +
+	"\x05views\x18\x02 \x01(\x03R\x05views\x12%\n" +
+	"\x0eunique_viewers\x18\x03 \x01(\x03R\runiqueViewers\x12#\n" +
+	"\rwatch_seconds\x18\x04 \x01(\x01R\fwatchSeconds\x12*\n" +
+	"\x11avg_watch_seconds\x18\x05 \x01(\x01R\x0favgWatchSeconds\x12\x1c\n" +
+	"\trebuffers\x18\x06 \x01(\x03R\trebuffers\x12\x1f\n" +
+	"\vrebuffer_ms\x18\a \x01(\x03R\n" +
+	"rebufferMs\x12)\n" +
+	"\x10bitrate_switches\x18\b \x01(\x03R\x0fbitrateSwitches\x12\x16\n" +
+	"\x06errors\x18\t \x01(\x03R\x06errors\x12!\n" +
+	"\fbytes_served\x18\n" +
+	" \x01(\x03R\vbytesServed\"\xd0\x02\n" +
+	"\x0eVideoAnalytics\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\tR\aendTime\x12 \n" +
+	"\vgranularity\x18\x04 \x01(\tR\vgranularity\x12>\n" +
+	"\x06totals\x18\x05 \x01(\v2&.gostream.analytics.v1.AnalyticsBucketR\x06totals\x12@\n" +
+	"\abuckets\x18\x06 \x03(\v2&.gostream.analytics.v1.AnalyticsBucketR\abuckets\x12\x1c\n" +
+	"\tretention\x18\a \x03(\x01R\tretention\x12'\n" +
+	"\x0fsegment_seconds\x18\b \x01(\x05R\x0esegmentSeconds2\xcc\x02\n" +
+	"\x10AnalyticsService\x12\xa0\x01\n" +
+	"\x14IngestPlaybackEvents\x122.gostream.analytics.v1.IngestPlaybackEventsRequest\x1a3.gostream.analytics.v1.IngestPlaybackEventsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/analytics/events\x12\x94\x01\n" +
+	"\x11GetVideoAnalytics\x12/.gostream.analytics.v1.GetVideoAnalyticsRequest\x1a%.gostream.analytics.v1.VideoAnalytics\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/videos/{video_id}/analyticsB>Z<github.com/hunderaweke/gostream/gen/go/analytics;analyticspbb\x06proto3"
+
+var (
+	file_analytics_proto_rawDescOnce sync.Once
+	file_analytics_proto_rawDescData []byte
+)
+
+func file_analytics_proto_rawDescGZIP() []byte {
+	file_analytics_proto_rawDescOnce.Do(func() {
+		file_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analytics_proto_rawDesc), len(file_analytics_proto_rawDesc)))
+	})
+	return file_analytics_proto_rawDescData
+}
+
+var file_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_analytics_proto_goTypes = []any{
+	(*PlaybackEvent)(nil),                // 0: gostream.analytics.v1.PlaybackEvent
+	(*IngestPlaybackEventsRequest)(nil),  // 1: gostream.analytics.v1.IngestPlaybackEventsRequest
+	(*IngestPlaybackEventsResponse)(nil), // 2: gostream.analytics.v1.IngestPlaybackEventsResponse
+	(*GetVideoAnalyticsRequest)(nil),     // 3: gostream.analytics.v1.GetVideoAnalyticsRequest
+	(*AnalyticsBucket)(nil),              // 4: gostream.analytics.v1.AnalyticsBucket
+	(*VideoAnalytics)(nil),               // 5: gostream.analytics.v1.VideoAnalytics
+}
+var file_analytics_proto_depIdxs = []int32{
+	0, // 0: gostream.analytics.v1.IngestPlaybackEventsRequest.events:type_name -> gostream.analytics.v1.PlaybackEvent
+	4, // 1: gostream.analytics.v1.VideoAnalytics.totals:type_name -> gostream.analytics.v1.AnalyticsBucket
+	4, // 2: gostream.analytics.v1.VideoAnalytics.buckets:type_name -> gostream.analytics.v1.AnalyticsBucket
+	1, // 3: gostream.analytics.v1.AnalyticsService.IngestPlaybackEvents:input_type -> gostream.analytics.v1.IngestPlaybackEventsRequest
+	3, // 4: gostream.analytics.v1.AnalyticsService.GetVideoAnalytics:input_type -> gostream.analytics.v1.GetVideoAnalyticsRequest
+	2, // 5: gostream.analytics.v1.AnalyticsService.IngestPlaybackEvents:output_type -> gostream.analytics.v1.IngestPlaybackEventsResponse
+	5, // 6: gostream.analytics.v1.AnalyticsService.GetVideoAnalytics:output_type -> gostream.analytics.v1.VideoAnalytics
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_analytics_proto_init() }
+func file_analytics_proto_init() {
+	if File_analytics_proto != nil {
+		return
+	}
+	file_analytics_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analytics_proto_rawDesc), len(file_analytics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analytics_proto_goTypes,
+		DependencyIndexes: file_analytics_proto_depIdxs,
+		MessageInfos:      file_analytics_proto_msgTypes,
+	}.Build()
+	File_analytics_proto = out.File
+	file_analytics_proto_goTypes = nil
+	file_analytics_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: analytics.proto
+
+package analyticspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_IngestPlaybackEvents_FullMethodName = "/gostream.analytics.v1.AnalyticsService/IngestPlaybackEvents"
+	AnalyticsService_GetVideoAnalytics_FullMethodName    = "/gostream.analytics.v1.AnalyticsService/GetVideoAnalytics"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnalyticsService collects quality-of-experience events from players and
+// serves hourly rollups of them to the owners of the videos.
+type AnalyticsServiceClient interface {
+	// IngestPlaybackEvents records a batch of player events. Players should
+	// send batches every few seconds rather than one request per event.
+	IngestPlaybackEvents(ctx context.Context, in *IngestPlaybackEventsRequest, opts ...grpc.CallOption) (*IngestPlaybackEventsResponse, error)
+	// GetVideoAnalytics returns the rollups of one of the caller's videos.
+	// Events show up after the next rollup, within about a minute.
+	GetVideoAnalytics(ctx context.Context, in *GetVideoAnalyticsRequest, opts ...grpc.CallOption) (*VideoAnalytics, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) IngestPlaybackEvents(ctx context.Context, in *IngestPlaybackEventsRequest, opts ...grpc.CallOption) (*IngestPlaybackEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestPlaybackEventsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_IngestPlaybackEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetVideoAnalytics(ctx context.Context, in *GetVideoAnalyticsRequest, opts ...grpc.CallOption) (*VideoAnalytics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VideoAnalytics)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetVideoAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// AnalyticsService collects quality-of-experience events from players and
+// serves hourly rollups of them to the owners of the videos.
+type AnalyticsServiceServer interface {
+	// IngestPlaybackEvents records a batch of player events. Players should
+	// send batches every few seconds rather than one request per event.
+	IngestPlaybackEvents(context.Context, *IngestPlaybackEventsRequest) (*IngestPlaybackEventsResponse, error)
+	// GetVideoAnalytics returns the rollups of one of the caller's videos.
+	// Events show up after the next rollup, within about a minute.
+	GetVideoAnalytics(context.Context, *GetVideoAnalyticsRequest) (*VideoAnalytics, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) IngestPlaybackEvents(context.Context, *IngestPlaybackEventsRequest) (*IngestPlaybackEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IngestPlaybackEvents not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetVideoAnalytics(context.Context, *GetVideoAnalyticsRequest) (*VideoAnalytics, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVideoAnalytics not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call panics, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_IngestPlaybackEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestPlaybackEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).IngestPlaybackEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_IngestPlaybackEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).IngestPlaybackEvents(ctx, req.(*IngestPlaybackEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetVideoAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetVideoAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetVideoAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetVideoAnalytics(ctx, req.(*GetVideoAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gostream.analytics.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IngestPlaybackEvents",
+			Handler:    _AnalyticsService_IngestPlaybackEvents_Handler,
+		},
+		{
+			MethodName: "GetVideoAnalytics",
+			Handler:    _AnalyticsService_GetVideoAnalytics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "analytics.proto",
+}
@@ -0,0 +1,555 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: history.proto
+
+package historypb
+
+import (
+	video "github.com/hunderaweke/gostream/gen/go/video"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReportPlaybackProgressRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	VideoId         string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	PositionSeconds float64                `protobuf:"fixed64,2,opt,name=position_seconds,json=positionSeconds,proto3" json:"position_seconds,omitempty"`
+	DurationSeconds float64                `protobuf:"fixed64,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReportPlaybackProgressRequest) Reset() {
+	*x = ReportPlaybackProgressRequest{}
+	mi := &file_history_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPlaybackProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPlaybackProgressRequest) ProtoMessage() {}
+
+func (x *ReportPlaybackProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPlaybackProgressRequest.ProtoReflect.Descriptor instead.
+func (*ReportPlaybackProgressRequest) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportPlaybackProgressRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackProgressRequest) GetPositionSeconds() float64 {
+	if x != nil {
+		return x.PositionSeconds
+	}
+	return 0
+}
+
+func (x *ReportPlaybackProgressRequest) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type GetResumePositionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResumePositionRequest) Reset() {
+	*x = GetResumePositionRequest{}
+	mi := &file_history_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResumePositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResumePositionRequest) ProtoMessage() {}
+
+func (x *GetResumePositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResumePositionRequest.ProtoReflect.Descriptor instead.
+func (*GetResumePositionRequest) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetResumePositionRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type PlaybackProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	VideoId         string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	PositionSeconds float64                `protobuf:"fixed64,2,opt,name=position_seconds,json=positionSeconds,proto3" json:"position_seconds,omitempty"`
+	DurationSeconds float64                `protobuf:"fixed64,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Completed       bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Where to resume playback.
+	ResumePositionSeconds float64 `protobuf:"fixed64,5,opt,name=resume_position_seconds,json=resumePositionSeconds,proto3" json:"resume_position_seconds,omitempty"`
+	// Empty when the video was never started.
+	WatchedAt     string `protobuf:"bytes,6,opt,name=watched_at,json=watchedAt,proto3" json:"watched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaybackProgress) Reset() {
+	*x = PlaybackProgress{}
+	mi := &file_history_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaybackProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackProgress) ProtoMessage() {}
+
+func (x *PlaybackProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackProgress.ProtoReflect.Descriptor instead.
+func (*PlaybackProgress) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlaybackProgress) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *PlaybackProgress) GetPositionSeconds() float64 {
+	if x != nil {
+		return x.PositionSeconds
+	}
+	return 0
+}
+
+func (x *PlaybackProgress) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *PlaybackProgress) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *PlaybackProgress) GetResumePositionSeconds() float64 {
+	if x != nil {
+		return x.ResumePositionSeconds
+	}
+	return 0
+}
+
+func (x *PlaybackProgress) GetWatchedAt() string {
+	if x != nil {
+		return x.WatchedAt
+	}
+	return ""
+}
+
+type GetWatchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWatchHistoryRequest) Reset() {
+	*x = GetWatchHistoryRequest{}
+	mi := &file_history_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWatchHistoryRequest) ProtoMessage() {}
+
+func (x *GetWatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetWatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWatchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetWatchHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type HistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Video         *video.Video           `protobuf:"bytes,1,opt,name=video,proto3" json:"video,omitempty"`
+	Progress      *PlaybackProgress      `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	mi := &file_history_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HistoryEntry) GetVideo() *video.Video {
+	if x != nil {
+		return x.Video
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetProgress() *PlaybackProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+type GetWatchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*HistoryEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWatchHistoryResponse) Reset() {
+	*x = GetWatchHistoryResponse{}
+	mi := &file_history_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWatchHistoryResponse) ProtoMessage() {}
+
+func (x *GetWatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetWatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWatchHistoryResponse) GetEntries() []*HistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetWatchHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ClearWatchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearWatchHistoryRequest) Reset() {
+	*x = ClearWatchHistoryRequest{}
+	mi := &file_history_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearWatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearWatchHistoryRequest) ProtoMessage() {}
+
+func (x *ClearWatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearWatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ClearWatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ClearWatchHistoryRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type ClearWatchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearWatchHistoryResponse) Reset() {
+	*x = ClearWatchHistoryResponse{}
+	mi := &file_history_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearWatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearWatchHistoryResponse) ProtoMessage() {}
+
+func (x *ClearWatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearWatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ClearWatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_history_proto_rawDescGZIP(), []int{7}
+}
+
+var File_history_proto protoreflect.FileDescriptor
+
+const file_history_proto_rawDesc = "" +
+	"\n" +
+	"\rhistory.proto\x12\x13gostream.history.v1\x1a\x1cgoogle/api/annotations.proto\x1a\vvideo.proto\"\x90\x01\n" +
+	"\x1dReportPlaybackProgressRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12)\n" +
+	"\x10position_seconds\x18\x02 \x01(\x01R\x0fpositionSeconds\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x01R\x0fdurationSeconds\"5\n" +
+	"\x18GetResumePositionRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"\xf8\x01\n" +
+	"\x10PlaybackProgress\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12)\n" +
+	"\x10position_seconds\x18\x02 \x01(\x01R\x0fpositionSeconds\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x01R\x0fdurationSeconds\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\bR\tcompleted\x126\n" +
+	"\x17resume_position_seconds\x18\x05 \x01(\x01R\x15resumePositionSeconds\x12\x1d\n" +
+	"\n" +
+	"watched_at\x18\x06 \x01(\tR\twatchedAt\"M\n" +
+	"\x16GetWatchHistoryRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x81\x01\n" +
+	"\fHistoryEntry\x12.\n" +
+	"\x05video\x18\x01 \x01(\v2\x18.gostream.video.v1.VideoR\x05video\x12A\n" +
+	"\bprogress\x18\x02 \x01(\v2%.gostream.history.v1.PlaybackProgressR\bprogress\"~\n" +
+	"\x17GetWatchHistoryResponse\x12;\n" +
+	"\aentries\x18\x01 \x03(\v2!.gostream.history.v1.HistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"5\n" +
+	"\x18ClearWatchHistoryRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"\x1b\n" +
+	"\x19ClearWatchHistoryResponse2\xe5\x04\n" +
+	"\x0eHistoryService\x12\x9e\x01\n" +
+	"\x16ReportPlaybackProgress\x122.gostream.history.v1.ReportPlaybackProgressRequest\x1a%.gostream.history.v1.PlaybackProgress\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/videos/{video_id}/progress\x12\x91\x01\n" +
+	"\x11GetResumePosition\x12-.gostream.history.v1.GetResumePositionRequest\x1a%.gostream.history.v1.PlaybackProgress\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v1/videos/{video_id}/progress\x12\x8a\x01\n" +
+	"\x0fGetWatchHistory\x12+.gostream.history.v1.GetWatchHistoryRequest\x1a,.gostream.history.v1.GetWatchHistoryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/users/me/history\x12\x90\x01\n" +
+	"\x11ClearWatchHistory\x12-.gostream.history.v1.ClearWatchHistoryRequest\x1a..gostream.history.v1.ClearWatchHistoryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/users/me/historyB:Z8github.com/hunderaweke/gostream/gen/go/history;historypbb\x06proto3"
+
+var (
+	file_history_proto_rawDescOnce sync.Once
+	file_history_proto_rawDescData []byte
+)
+
+func file_history_proto_rawDescGZIP() []byte {
+	file_history_proto_rawDescOnce.Do(func() {
+		file_history_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_history_proto_rawDesc), len(file_history_proto_rawDesc)))
+	})
+	return file_history_proto_rawDescData
+}
+
+var file_history_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_history_proto_goTypes = []any{
+	(*ReportPlaybackProgressRequest)(nil), // 0: gostream.history.v1.ReportPlaybackProgressRequest
+	(*GetResumePositionRequest)(nil),      // 1: gostream.history.v1.GetResumePositionRequest
+	(*PlaybackProgress)(nil),              // 2: gostream.history.v1.PlaybackProgress
+	(*GetWatchHistoryRequest)(nil),        // 3: gostream.history.v1.GetWatchHistoryRequest
+	(*HistoryEntry)(nil),                  // 4: gostream.history.v1.HistoryEntry
+	(*GetWatchHistoryResponse)(nil),       // 5: gostream.history.v1.GetWatchHistoryResponse
+	(*ClearWatchHistoryRequest)(nil),      // 6: gostream.history.v1.ClearWatchHistoryRequest
+	(*ClearWatchHistoryResponse)(nil),     // 7: gostream.history.v1.ClearWatchHistoryResponse
+	(*video.Video)(nil),                   // 8: gostream.video.v1.Video
+}
+var file_history_proto_depIdxs = []int32{
+	8, // 0: gostream.history.v1.HistoryEntry.video:type_name -> gostream.video.v1.Video
+	2, // 1: gostream.history.v1.HistoryEntry.progress:type_name -> gostream.history.v1.PlaybackProgress
+	4, // 2: gostream.history.v1.GetWatchHistoryResponse.entries:type_name -> gostream.history.v1.HistoryEntry
+	0, // 3: gostream.history.v1.HistoryService.ReportPlaybackProgress:input_type -> gostream.history.v1.ReportPlaybackProgressRequest
+	1, // 4: gostream.history.v1.HistoryService.GetResumePosition:input_type -> gostream.history.v1.GetResumePositionRequest
+	3, // 5: gostream.history.v1.HistoryService.GetWatchHistory:input_type -> gostream.history.v1.GetWatchHistoryRequest
+	6, // 6: gostream.history.v1.HistoryService.ClearWatchHistory:input_type -> gostream.history.v1.ClearWatchHistoryRequest
+	2, // 7: gostream.history.v1.HistoryService.ReportPlaybackProgress:output_type -> gostream.history.v1.PlaybackProgress
+	2, // 8: gostream.history.v1.HistoryService.GetResumePosition:output_type -> gostream.history.v1.PlaybackProgress
+	5, // 9: gostream.history.v1.HistoryService.GetWatchHistory:output_type -> gostream.history.v1.GetWatchHistoryResponse
+	7, // 10: gostream.history.v1.HistoryService.ClearWatchHistory:output_type -> gostream.history.v1.ClearWatchHistoryResponse
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_history_proto_init() }
+func file_history_proto_init() {
+	if File_history_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_history_proto_rawDesc), len(file_history_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_history_proto_goTypes,
+		DependencyIndexes: file_history_proto_depIdxs,
+		MessageInfos:      file_history_proto_msgTypes,
+	}.Build()
+	File_history_proto = out.File
+	file_history_proto_goTypes = nil
+	file_history_proto_depIdxs = nil
+}
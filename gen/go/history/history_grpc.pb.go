@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: history.proto
+
+package historypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HistoryService_ReportPlaybackProgress_FullMethodName = "/gostream.history.v1.HistoryService/ReportPlaybackProgress"
+	HistoryService_GetResumePosition_FullMethodName      = "/gostream.history.v1.HistoryService/GetResumePosition"
+	HistoryService_GetWatchHistory_FullMethodName        = "/gostream.history.v1.HistoryService/GetWatchHistory"
+	HistoryService_ClearWatchHistory_FullMethodName      = "/gostream.history.v1.HistoryService/ClearWatchHistory"
+)
+
+// HistoryServiceClient is the client API for HistoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HistoryService keeps the caller's watch history and where to resume each
+// video.
+type HistoryServiceClient interface {
+	// ReportPlaybackProgress is sent by the player every few seconds while
+	// playing. Reports are buffered and saved in batches.
+	ReportPlaybackProgress(ctx context.Context, in *ReportPlaybackProgressRequest, opts ...grpc.CallOption) (*PlaybackProgress, error)
+	// GetResumePosition returns where to resume a video: 0 when it was never
+	// started or was watched to the end.
+	GetResumePosition(ctx context.Context, in *GetResumePositionRequest, opts ...grpc.CallOption) (*PlaybackProgress, error)
+	// GetWatchHistory lists the videos the caller watched, most recent first.
+	GetWatchHistory(ctx context.Context, in *GetWatchHistoryRequest, opts ...grpc.CallOption) (*GetWatchHistoryResponse, error)
+	// ClearWatchHistory removes one video from the history, or all of it
+	// when video_id is empty.
+	ClearWatchHistory(ctx context.Context, in *ClearWatchHistoryRequest, opts ...grpc.CallOption) (*ClearWatchHistoryResponse, error)
+}
+
+type historyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHistoryServiceClient(cc grpc.ClientConnInterface) HistoryServiceClient {
+	return &historyServiceClient{cc}
+}
+
+func (c *historyServiceClient) ReportPlaybackProgress(ctx context.Context, in *ReportPlaybackProgressRequest, opts ...grpc.CallOption) (*PlaybackProgress, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaybackProgress)
+	err := c.cc.Invoke(ctx, HistoryService_ReportPlaybackProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) GetResumePosition(ctx context.Context, in *GetResumePositionRequest, opts ...grpc.CallOption) (*PlaybackProgress, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaybackProgress)
+	err := c.cc.Invoke(ctx, HistoryService_GetResumePosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) GetWatchHistory(ctx context.Context, in *GetWatchHistoryRequest, opts ...grpc.CallOption) (*GetWatchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWatchHistoryResponse)
+	err := c.cc.Invoke(ctx, HistoryService_GetWatchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *historyServiceClient) ClearWatchHistory(ctx context.Context, in *ClearWatchHistoryRequest, opts ...grpc.CallOption) (*ClearWatchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearWatchHistoryResponse)
+	err := c.cc.Invoke(ctx, HistoryService_ClearWatchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HistoryServiceServer is the server API for HistoryService service.
+// All implementations must embed UnimplementedHistoryServiceServer
+// for forward compatibility.
+//
+// HistoryService keeps the caller's watch history and where to resume each
+// video.
+type HistoryServiceServer interface {
+	// ReportPlaybackProgress is sent by the player every few seconds while
+	// playing. Reports are buffered and saved in batches.
+	ReportPlaybackProgress(context.Context, *ReportPlaybackProgressRequest) (*PlaybackProgress, error)
+	// GetResumePosition returns where to resume a video: 0 when it was never
+	// started or was watched to the end.
+	GetResumePosition(context.Context, *GetResumePositionRequest) (*PlaybackProgress, error)
+	// GetWatchHistory lists the videos the caller watched, most recent first.
+	GetWatchHistory(context.Context, *GetWatchHistoryRequest) (*GetWatchHistoryResponse, error)
+	// ClearWatchHistory removes one video from the history, or all of it
+	// when video_id is empty.
+	ClearWatchHistory(context.Context, *ClearWatchHistoryRequest) (*ClearWatchHistoryResponse, error)
+	mustEmbedUnimplementedHistoryServiceServer()
+}
+
+// UnimplementedHistoryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHistoryServiceServer struct{}
+
+func (UnimplementedHistoryServiceServer) ReportPlaybackProgress(context.Context, *ReportPlaybackProgressRequest) (*PlaybackProgress, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportPlaybackProgress not implemented")
+}
+func (UnimplementedHistoryServiceServer) GetResumePosition(context.Context, *GetResumePositionRequest) (*PlaybackProgress, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetResumePosition not implemented")
+}
+func (UnimplementedHistoryServiceServer) GetWatchHistory(context.Context, *GetWatchHistoryRequest) (*GetWatchHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWatchHistory not implemented")
+}
+func (UnimplementedHistoryServiceServer) ClearWatchHistory(context.Context, *ClearWatchHistoryRequest) (*ClearWatchHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearWatchHistory not implemented")
+}
+func (UnimplementedHistoryServiceServer) mustEmbedUnimplementedHistoryServiceServer() {}
+func (UnimplementedHistoryServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeHistoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HistoryServiceServer will
+// result in compilation errors.
+type UnsafeHistoryServiceServer interface {
+	mustEmbedUnimplementedHistoryServiceServer()
+}
+
+func RegisterHistoryServiceServer(s grpc.ServiceRegistrar, srv HistoryServiceServer) {
+	// If the following call panics, it indicates UnimplementedHistoryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HistoryService_ServiceDesc, srv)
+}
+
+func _HistoryService_ReportPlaybackProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPlaybackProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).ReportPlaybackProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_ReportPlaybackProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).ReportPlaybackProgress(ctx, req.(*ReportPlaybackProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_GetResumePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResumePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetResumePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetResumePosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetResumePosition(ctx, req.(*GetResumePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_GetWatchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWatchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).GetWatchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_GetWatchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).GetWatchHistory(ctx, req.(*GetWatchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HistoryService_ClearWatchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearWatchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HistoryServiceServer).ClearWatchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HistoryService_ClearWatchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HistoryServiceServer).ClearWatchHistory(ctx, req.(*ClearWatchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HistoryService_ServiceDesc is the grpc.ServiceDesc for HistoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HistoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gostream.history.v1.HistoryService",
+	HandlerType: (*HistoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportPlaybackProgress",
+			Handler:    _HistoryService_ReportPlaybackProgress_Handler,
+		},
+		{
+			MethodName: "GetResumePosition",
+			Handler:    _HistoryService_GetResumePosition_Handler,
+		},
+		{
+			MethodName: "GetWatchHistory",
+			Handler:    _HistoryService_GetWatchHistory_Handler,
+		},
+		{
+			MethodName: "ClearWatchHistory",
+			Handler:    _HistoryService_ClearWatchHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "history.proto",
+}
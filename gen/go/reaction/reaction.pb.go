@@ -0,0 +1,544 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: reaction.proto
+
+package reactionpb
+
+import (
+	video "github.com/hunderaweke/gostream/gen/go/video"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetVideoReactionRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// LIKE or DISLIKE.
+	Reaction      string `protobuf:"bytes,2,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVideoReactionRequest) Reset() {
+	*x = SetVideoReactionRequest{}
+	mi := &file_reaction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVideoReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVideoReactionRequest) ProtoMessage() {}
+
+func (x *SetVideoReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVideoReactionRequest.ProtoReflect.Descriptor instead.
+func (*SetVideoReactionRequest) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetVideoReactionRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *SetVideoReactionRequest) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type ClearVideoReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearVideoReactionRequest) Reset() {
+	*x = ClearVideoReactionRequest{}
+	mi := &file_reaction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearVideoReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearVideoReactionRequest) ProtoMessage() {}
+
+func (x *ClearVideoReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearVideoReactionRequest.ProtoReflect.Descriptor instead.
+func (*ClearVideoReactionRequest) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ClearVideoReactionRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type SetCommentReactionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommentId string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	// LIKE or DISLIKE.
+	Reaction      string `protobuf:"bytes,2,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCommentReactionRequest) Reset() {
+	*x = SetCommentReactionRequest{}
+	mi := &file_reaction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCommentReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCommentReactionRequest) ProtoMessage() {}
+
+func (x *SetCommentReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCommentReactionRequest.ProtoReflect.Descriptor instead.
+func (*SetCommentReactionRequest) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetCommentReactionRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *SetCommentReactionRequest) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type ClearCommentReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCommentReactionRequest) Reset() {
+	*x = ClearCommentReactionRequest{}
+	mi := &file_reaction_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCommentReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCommentReactionRequest) ProtoMessage() {}
+
+func (x *ClearCommentReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCommentReactionRequest.ProtoReflect.Descriptor instead.
+func (*ClearCommentReactionRequest) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClearCommentReactionRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+// ReactionCounts are the counters of the target after the change.
+type ReactionCounts struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	LikeCount    int64                  `protobuf:"varint,1,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	DislikeCount int64                  `protobuf:"varint,2,opt,name=dislike_count,json=dislikeCount,proto3" json:"dislike_count,omitempty"`
+	// The caller's reaction, empty when none.
+	Reaction      string `protobuf:"bytes,3,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionCounts) Reset() {
+	*x = ReactionCounts{}
+	mi := &file_reaction_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCounts) ProtoMessage() {}
+
+func (x *ReactionCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCounts.ProtoReflect.Descriptor instead.
+func (*ReactionCounts) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReactionCounts) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *ReactionCounts) GetDislikeCount() int64 {
+	if x != nil {
+		return x.DislikeCount
+	}
+	return 0
+}
+
+func (x *ReactionCounts) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type ListMyLikedVideosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyLikedVideosRequest) Reset() {
+	*x = ListMyLikedVideosRequest{}
+	mi := &file_reaction_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyLikedVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyLikedVideosRequest) ProtoMessage() {}
+
+func (x *ListMyLikedVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyLikedVideosRequest.ProtoReflect.Descriptor instead.
+func (*ListMyLikedVideosRequest) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMyLikedVideosRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMyLikedVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type LikedVideo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Video         *video.Video           `protobuf:"bytes,1,opt,name=video,proto3" json:"video,omitempty"`
+	LikedAt       string                 `protobuf:"bytes,2,opt,name=liked_at,json=likedAt,proto3" json:"liked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikedVideo) Reset() {
+	*x = LikedVideo{}
+	mi := &file_reaction_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikedVideo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikedVideo) ProtoMessage() {}
+
+func (x *LikedVideo) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikedVideo.ProtoReflect.Descriptor instead.
+func (*LikedVideo) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LikedVideo) GetVideo() *video.Video {
+	if x != nil {
+		return x.Video
+	}
+	return nil
+}
+
+func (x *LikedVideo) GetLikedAt() string {
+	if x != nil {
+		return x.LikedAt
+	}
+	return ""
+}
+
+type ListMyLikedVideosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*LikedVideo          `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyLikedVideosResponse) Reset() {
+	*x = ListMyLikedVideosResponse{}
+	mi := &file_reaction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyLikedVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyLikedVideosResponse) ProtoMessage() {}
+
+func (x *ListMyLikedVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reaction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyLikedVideosResponse.ProtoReflect.Descriptor instead.
+func (*ListMyLikedVideosResponse) Descriptor() ([]byte, []int) {
+	return file_reaction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListMyLikedVideosResponse) GetVideos() []*LikedVideo {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListMyLikedVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_reaction_proto protoreflect.FileDescriptor
+
+const file_reaction_proto_rawDesc = "" +
+	"\n" +
+	"\x0ereaction.proto\x12\x14gostream.reaction.v1\x1a\x1cgoogle/api/annotations.proto\x1a\vvideo.proto\"P\n" +
+	"\x17SetVideoReactionRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1a\n" +
+	"\breaction\x18\x02 \x01(\tR\breaction\"6\n" +
+	"\x19ClearVideoReactionRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"V\n" +
+	"\x19SetCommentReactionRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12\x1a\n" +
+	"\breaction\x18\x02 \x01(\tR\breaction\"<\n" +
+	"\x1bClearCommentReactionRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\"p\n" +
+	"\x0eReactionCounts\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x01 \x01(\x03R\tlikeCount\x12#\n" +
+	"\rdislike_count\x18\x02 \x01(\x03R\fdislikeCount\x12\x1a\n" +
+	"\breaction\x18\x03 \x01(\tR\breaction\"O\n" +
+	"\x18ListMyLikedVideosRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"W\n" +
+	"\n" +
+	"LikedVideo\x12.\n" +
+	"\x05video\x18\x01 \x01(\v2\x18.gostream.video.v1.VideoR\x05video\x12\x19\n" +
+	"\bliked_at\x18\x02 \x01(\tR\alikedAt\"}\n" +
+	"\x19ListMyLikedVideosResponse\x128\n" +
+	"\x06videos\x18\x01 \x03(\v2 .gostream.reaction.v1.LikedVideoR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\x91\x06\n" +
+	"\x0fReactionService\x12\x92\x01\n" +
+	"\x10SetVideoReaction\x12-.gostream.reaction.v1.SetVideoReactionRequest\x1a$.gostream.reaction.v1.ReactionCounts\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/videos/{video_id}/reaction\x12\x93\x01\n" +
+	"\x12ClearVideoReaction\x12/.gostream.reaction.v1.ClearVideoReactionRequest\x1a$.gostream.reaction.v1.ReactionCounts\"&\x82\xd3\xe4\x93\x02 *\x1e/v1/videos/{video_id}/reaction\x12\x9a\x01\n" +
+	"\x12SetCommentReaction\x12/.gostream.reaction.v1.SetCommentReactionRequest\x1a$.gostream.reaction.v1.ReactionCounts\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/comments/{comment_id}/reaction\x12\x9b\x01\n" +
+	"\x14ClearCommentReaction\x121.gostream.reaction.v1.ClearCommentReactionRequest\x1a$.gostream.reaction.v1.ReactionCounts\"*\x82\xd3\xe4\x93\x02$*\"/v1/comments/{comment_id}/reaction\x12\x97\x01\n" +
+	"\x11ListMyLikedVideos\x12..gostream.reaction.v1.ListMyLikedVideosRequest\x1a/.gostream.reaction.v1.ListMyLikedVideosResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/users/me/liked-videosB<Z:github.com/hunderaweke/gostream/gen/go/reaction;reactionpbb\x06proto3"
+
+var (
+	file_reaction_proto_rawDescOnce sync.Once
+	file_reaction_proto_rawDescData []byte
+)
+
+func file_reaction_proto_rawDescGZIP() []byte {
+	file_reaction_proto_rawDescOnce.Do(func() {
+		file_reaction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reaction_proto_rawDesc), len(file_reaction_proto_rawDesc)))
+	})
+	return file_reaction_proto_rawDescData
+}
+
+var file_reaction_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_reaction_proto_goTypes = []any{
+	(*SetVideoReactionRequest)(nil),     // 0: gostream.reaction.v1.SetVideoReactionRequest
+	(*ClearVideoReactionRequest)(nil),   // 1: gostream.reaction.v1.ClearVideoReactionRequest
+	(*SetCommentReactionRequest)(nil),   // 2: gostream.reaction.v1.SetCommentReactionRequest
+	(*ClearCommentReactionRequest)(nil), // 3: gostream.reaction.v1.ClearCommentReactionRequest
+	(*ReactionCounts)(nil),              // 4: gostream.reaction.v1.ReactionCounts
+	(*ListMyLikedVideosRequest)(nil),    // 5: gostream.reaction.v1.ListMyLikedVideosRequest
+	(*LikedVideo)(nil),                  // 6: gostream.reaction.v1.LikedVideo
+	(*ListMyLikedVideosResponse)(nil),   // 7: gostream.reaction.v1.ListMyLikedVideosResponse
+	(*video.Video)(nil),                 // 8: gostream.video.v1.Video
+}
+var file_reaction_proto_depIdxs = []int32{
+	8, // 0: gostream.reaction.v1.LikedVideo.video:type_name -> gostream.video.v1.Video
+	6, // 1: gostream.reaction.v1.ListMyLikedVideosResponse.videos:type_name -> gostream.reaction.v1.LikedVideo
+	0, // 2: gostream.reaction.v1.ReactionService.SetVideoReaction:input_type -> gostream.reaction.v1.SetVideoReactionRequest
+	1, // 3: gostream.reaction.v1.ReactionService.ClearVideoReaction:input_type -> gostream.reaction.v1.ClearVideoReactionRequest
+	2, // 4: gostream.reaction.v1.ReactionService.SetCommentReaction:input_type -> gostream.reaction.v1.SetCommentReactionRequest
+	3, // 5: gostream.reaction.v1.ReactionService.ClearCommentReaction:input_type -> gostream.reaction.v1.ClearCommentReactionRequest
+	5, // 6: gostream.reaction.v1.ReactionService.ListMyLikedVideos:input_type -> gostream.reaction.v1.ListMyLikedVideosRequest
+	4, // 7: gostream.reaction.v1.ReactionService.SetVideoReaction:output_type -> gostream.reaction.v1.ReactionCounts
+	4, // 8: gostream.reaction.v1.ReactionService.ClearVideoReaction:output_type -> gostream.reaction.v1.ReactionCounts
+	4, // 9: gostream.reaction.v1.ReactionService.SetCommentReaction:output_type -> gostream.reaction.v1.ReactionCounts
+	4, // 10: gostream.reaction.v1.ReactionService.ClearCommentReaction:output_type -> gostream.reaction.v1.ReactionCounts
+	7, // 11: gostream.reaction.v1.ReactionService.ListMyLikedVideos:output_type -> gostream.reaction.v1.ListMyLikedVideosResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_reaction_proto_init() }
+func file_reaction_proto_init() {
+	if File_reaction_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reaction_proto_rawDesc), len(file_reaction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reaction_proto_goTypes,
+		DependencyIndexes: file_reaction_proto_depIdxs,
+		MessageInfos:      file_reaction_proto_msgTypes,
+	}.Build()
+	File_reaction_proto = out.File
+	file_reaction_proto_goTypes = nil
+	file_reaction_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: reaction.proto
+
+package reactionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReactionService_SetVideoReaction_FullMethodName     = "/gostream.reaction.v1.ReactionService/SetVideoReaction"
+	ReactionService_ClearVideoReaction_FullMethodName   = "/gostream.reaction.v1.ReactionService/ClearVideoReaction"
+	ReactionService_SetCommentReaction_FullMethodName   = "/gostream.reaction.v1.ReactionService/SetCommentReaction"
+	ReactionService_ClearCommentReaction_FullMethodName = "/gostream.reaction.v1.ReactionService/ClearCommentReaction"
+	ReactionService_ListMyLikedVideos_FullMethodName    = "/gostream.reaction.v1.ReactionService/ListMyLikedVideos"
+)
+
+// ReactionServiceClient is the client API for ReactionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReactionService lets users like or dislike videos and comments. Setting
+// the same reaction twice, or clearing a missing one, changes nothing.
+type ReactionServiceClient interface {
+	SetVideoReaction(ctx context.Context, in *SetVideoReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error)
+	ClearVideoReaction(ctx context.Context, in *ClearVideoReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error)
+	SetCommentReaction(ctx context.Context, in *SetCommentReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error)
+	ClearCommentReaction(ctx context.Context, in *ClearCommentReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error)
+	// ListMyLikedVideos lists the videos the caller likes, most recently
+	// liked first.
+	ListMyLikedVideos(ctx context.Context, in *ListMyLikedVideosRequest, opts ...grpc.CallOption) (*ListMyLikedVideosResponse, error)
+}
+
+type reactionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReactionServiceClient(cc grpc.ClientConnInterface) ReactionServiceClient {
+	return &reactionServiceClient{cc}
+}
+
+func (c *reactionServiceClient) SetVideoReaction(ctx context.Context, in *SetVideoReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionCounts)
+	err := c.cc.Invoke(ctx, ReactionService_SetVideoReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionServiceClient) ClearVideoReaction(ctx context.Context, in *ClearVideoReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionCounts)
+	err := c.cc.Invoke(ctx, ReactionService_ClearVideoReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionServiceClient) SetCommentReaction(ctx context.Context, in *SetCommentReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionCounts)
+	err := c.cc.Invoke(ctx, ReactionService_SetCommentReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionServiceClient) ClearCommentReaction(ctx context.Context, in *ClearCommentReactionRequest, opts ...grpc.CallOption) (*ReactionCounts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionCounts)
+	err := c.cc.Invoke(ctx, ReactionService_ClearCommentReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionServiceClient) ListMyLikedVideos(ctx context.Context, in *ListMyLikedVideosRequest, opts ...grpc.CallOption) (*ListMyLikedVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyLikedVideosResponse)
+	err := c.cc.Invoke(ctx, ReactionService_ListMyLikedVideos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReactionServiceServer is the server API for ReactionService service.
+// All implementations must embed UnimplementedReactionServiceServer
+// for forward compatibility.
+//
+// ReactionService lets users like or dislike videos and comments. Setting
+// the same reaction twice, or clearing a missing one, changes nothing.
+type ReactionServiceServer interface {
+	SetVideoReaction(context.Context, *SetVideoReactionRequest) (*ReactionCounts, error)
+	ClearVideoReaction(context.Context, *ClearVideoReactionRequest) (*ReactionCounts, error)
+	SetCommentReaction(context.Context, *SetCommentReactionRequest) (*ReactionCounts, error)
+	ClearCommentReaction(context.Context, *ClearCommentReactionRequest) (*ReactionCounts, error)
+	// ListMyLikedVideos lists the videos the caller likes, most recently
+	// liked first.
+	ListMyLikedVideos(context.Context, *ListMyLikedVideosRequest) (*ListMyLikedVideosResponse, error)
+	mustEmbedUnimplementedReactionServiceServer()
+}
+
+// UnimplementedReactionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReactionServiceServer struct{}
+
+func (UnimplementedReactionServiceServer) SetVideoReaction(context.Context, *SetVideoReactionRequest) (*ReactionCounts, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetVideoReaction not implemented")
+}
+func (UnimplementedReactionServiceServer) ClearVideoReaction(context.Context, *ClearVideoReactionRequest) (*ReactionCounts, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearVideoReaction not implemented")
+}
+func (UnimplementedReactionServiceServer) SetCommentReaction(context.Context, *SetCommentReactionRequest) (*ReactionCounts, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCommentReaction not implemented")
+}
+func (UnimplementedReactionServiceServer) ClearCommentReaction(context.Context, *ClearCommentReactionRequest) (*ReactionCounts, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearCommentReaction not implemented")
+}
+func (UnimplementedReactionServiceServer) ListMyLikedVideos(context.Context, *ListMyLikedVideosRequest) (*ListMyLikedVideosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMyLikedVideos not implemented")
+}
+func (UnimplementedReactionServiceServer) mustEmbedUnimplementedReactionServiceServer() {}
+func (UnimplementedReactionServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeReactionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReactionServiceServer will
+// result in compilation errors.
+type UnsafeReactionServiceServer interface {
+	mustEmbedUnimplementedReactionServiceServer()
+}
+
+func RegisterReactionServiceServer(s grpc.ServiceRegistrar, srv ReactionServiceServer) {
+	// If the following call panics, it indicates UnimplementedReactionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReactionService_ServiceDesc, srv)
+}
+
+func _ReactionService_SetVideoReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVideoReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).SetVideoReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_SetVideoReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).SetVideoReaction(ctx, req.(*SetVideoReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReactionService_ClearVideoReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearVideoReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).ClearVideoReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_ClearVideoReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).ClearVideoReaction(ctx, req.(*ClearVideoReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReactionService_SetCommentReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCommentReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).SetCommentReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_SetCommentReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).SetCommentReaction(ctx, req.(*SetCommentReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReactionService_ClearCommentReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearCommentReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).ClearCommentReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_ClearCommentReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).ClearCommentReaction(ctx, req.(*ClearCommentReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReactionService_ListMyLikedVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyLikedVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).ListMyLikedVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_ListMyLikedVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).ListMyLikedVideos(ctx, req.(*ListMyLikedVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReactionService_ServiceDesc is the grpc.ServiceDesc for ReactionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReactionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gostream.reaction.v1.ReactionService",
+	HandlerType: (*ReactionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetVideoReaction",
+			Handler:    _ReactionService_SetVideoReaction_Handler,
+		},
+		{
+			MethodName: "ClearVideoReaction",
+			Handler:    _ReactionService_ClearVideoReaction_Handler,
+		},
+		{
+			MethodName: "SetCommentReaction",
+			Handler:    _ReactionService_SetCommentReaction_Handler,
+		},
+		{
+			MethodName: "ClearCommentReaction",
+			Handler:    _ReactionService_ClearCommentReaction_Handler,
+		},
+		{
+			MethodName: "ListMyLikedVideos",
+			Handler:    _ReactionService_ListMyLikedVideos_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reaction.proto",
+}
@@ -0,0 +1,306 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: recommendation.proto
+
+package recommendationpb
+
+import (
+	video "github.com/hunderaweke/gostream/gen/go/video"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetRelatedVideosRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// Defaults to 20, at most 50.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedVideosRequest) Reset() {
+	*x = GetRelatedVideosRequest{}
+	mi := &file_recommendation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedVideosRequest) ProtoMessage() {}
+
+func (x *GetRelatedVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedVideosRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedVideosRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRelatedVideosRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetRelatedVideosRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRecommendedForMeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 20, at most 50.
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendedForMeRequest) Reset() {
+	*x = GetRecommendedForMeRequest{}
+	mi := &file_recommendation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendedForMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendedForMeRequest) ProtoMessage() {}
+
+func (x *GetRecommendedForMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendedForMeRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendedForMeRequest) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRecommendedForMeRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecommendedVideo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Video *video.Video           `protobuf:"bytes,1,opt,name=video,proto3" json:"video,omitempty"`
+	Score float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// The candidate sources that suggested the video: tags, text, co_watch
+	// or trending.
+	Sources       []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendedVideo) Reset() {
+	*x = RecommendedVideo{}
+	mi := &file_recommendation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendedVideo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendedVideo) ProtoMessage() {}
+
+func (x *RecommendedVideo) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendedVideo.ProtoReflect.Descriptor instead.
+func (*RecommendedVideo) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecommendedVideo) GetVideo() *video.Video {
+	if x != nil {
+		return x.Video
+	}
+	return nil
+}
+
+func (x *RecommendedVideo) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RecommendedVideo) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type RecommendationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*RecommendedVideo    `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendationsResponse) Reset() {
+	*x = RecommendationsResponse{}
+	mi := &file_recommendation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendationsResponse) ProtoMessage() {}
+
+func (x *RecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recommendation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*RecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_recommendation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecommendationsResponse) GetVideos() []*RecommendedVideo {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+var File_recommendation_proto protoreflect.FileDescriptor
+
+const file_recommendation_proto_rawDesc = "" +
+	"\n" +
+	"\x14recommendation.proto\x12\x1agostream.recommendation.v1\x1a\x1cgoogle/api/annotations.proto\x1a\vvideo.proto\"J\n" +
+	"\x17GetRelatedVideosRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"2\n" +
+	"\x1aGetRecommendedForMeRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"r\n" +
+	"\x10RecommendedVideo\x12.\n" +
+	"\x05video\x18\x01 \x01(\v2\x18.gostream.video.v1.VideoR\x05video\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\asources\x18\x03 \x03(\tR\asources\"_\n" +
+	"\x17RecommendationsResponse\x12D\n" +
+	"\x06videos\x18\x01 \x03(\v2,.gostream.recommendation.v1.RecommendedVideoR\x06videos2\xe0\x02\n" +
+	"\x15RecommendationService\x12\xa3\x01\n" +
+	"\x10GetRelatedVideos\x123.gostream.recommendation.v1.GetRelatedVideosRequest\x1a3.gostream.recommendation.v1.RecommendationsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/videos/{video_id}/related\x12\xa0\x01\n" +
+	"\x13GetRecommendedForMe\x126.gostream.recommendation.v1.GetRecommendedForMeRequest\x1a3.gostream.recommendation.v1.RecommendationsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/feed/recommendedBHZFgithub.com/hunderaweke/gostream/gen/go/recommendation;recommendationpbb\x06proto3"
+
+var (
+	file_recommendation_proto_rawDescOnce sync.Once
+	file_recommendation_proto_rawDescData []byte
+)
+
+func file_recommendation_proto_rawDescGZIP() []byte {
+	file_recommendation_proto_rawDescOnce.Do(func() {
+		file_recommendation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_recommendation_proto_rawDesc), len(file_recommendation_proto_rawDesc)))
+	})
+	return file_recommendation_proto_rawDescData
+}
+
+var file_recommendation_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_recommendation_proto_goTypes = []any{
+	(*GetRelatedVideosRequest)(nil),    // 0: gostream.recommendation.v1.GetRelatedVideosRequest
+	(*GetRecommendedForMeRequest)(nil), // 1: gostream.recommendation.v1.GetRecommendedForMeRequest
+	(*RecommendedVideo)(nil),           // 2: gostream.recommendation.v1.RecommendedVideo
+	(*RecommendationsResponse)(nil),    // 3: gostream.recommendation.v1.RecommendationsResponse
+	(*video.Video)(nil),                // 4: gostream.video.v1.Video
+}
+var file_recommendation_proto_depIdxs = []int32{
+	4, // 0: gostream.recommendation.v1.RecommendedVideo.video:type_name -> gostream.video.v1.Video
+	2, // 1: gostream.recommendation.v1.RecommendationsResponse.videos:type_name -> gostream.recommendation.v1.RecommendedVideo
+	0, // 2: gostream.recommendation.v1.RecommendationService.GetRelatedVideos:input_type -> gostream.recommendation.v1.GetRelatedVideosRequest
+	1, // 3: gostream.recommendation.v1.RecommendationService.GetRecommendedForMe:input_type -> gostream.recommendation.v1.GetRecommendedForMeRequest
+	3, // 4: gostream.recommendation.v1.RecommendationService.GetRelatedVideos:output_type -> gostream.recommendation.v1.RecommendationsResponse
+	3, // 5: gostream.recommendation.v1.RecommendationService.GetRecommendedForMe:output_type -> gostream.recommendation.v1.RecommendationsResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_recommendation_proto_init() }
+func file_recommendation_proto_init() {
+	if File_recommendation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recommendation_proto_rawDesc), len(file_recommendation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_recommendation_proto_goTypes,
+		DependencyIndexes: file_recommendation_proto_depIdxs,
+		MessageInfos:      file_recommendation_proto_msgTypes,
+	}.Build()
+	File_recommendation_proto = out.File
+	file_recommendation_proto_goTypes = nil
+	file_recommendation_proto_depIdxs = nil
+}
@@ -23,31 +23,576 @@ const (
 )
 
 type GetVideosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Full-text search; accepts web search syntax such as "quoted phrases",
+	// OR and -excluded words. Results are ordered by relevance.
+	Query  string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// List the caller's own videos, including ones still processing.
+	// Requires authentication.
+	Mine bool `protobuf:"varint,6,opt,name=mine,proto3" json:"mine,omitempty"`
+	// One of created_at, views, title or relevance, optionally followed by
+	// asc or desc. Defaults to relevance for searches and created_at desc
+	// otherwise.
+	OrderBy string `protobuf:"bytes,7,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// next_page_token of the previous page; page is ignored when set.
+	PageToken string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Skip counting all matches; total is then left out of the response.
+	SkipTotal bool `protobuf:"varint,9,opt,name=skip_total,json=skipTotal,proto3" json:"skip_total,omitempty"`
+	// Only videos carrying every one of these tags.
+	Tags     []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category string   `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	// Add category and tag counts of the matches to the response.
+	IncludeFacets bool `protobuf:"varint,12,opt,name=include_facets,json=includeFacets,proto3" json:"include_facets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideosRequest) Reset() {
+	*x = GetVideosRequest{}
+	mi := &file_video_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideosRequest) ProtoMessage() {}
+
+func (x *GetVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideosRequest.ProtoReflect.Descriptor instead.
+func (*GetVideosRequest) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetVideosRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetVideosRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetVideosRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetVideosRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetVideosRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetVideosRequest) GetMine() bool {
+	if x != nil {
+		return x.Mine
+	}
+	return false
+}
+
+func (x *GetVideosRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *GetVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetVideosRequest) GetSkipTotal() bool {
+	if x != nil {
+		return x.SkipTotal
+	}
+	return false
+}
+
+func (x *GetVideosRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetVideosRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *GetVideosRequest) GetIncludeFacets() bool {
+	if x != nil {
+		return x.IncludeFacets
+	}
+	return false
+}
+
+type GetVideosResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Videos []*Video               `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	Total  *int64                 `protobuf:"varint,2,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	Page   int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit  int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Set when include_facets is. Category counts ignore the category
+	// filter; tag counts are limited to the 20 most used tags.
+	CategoryFacets []*FacetCount `protobuf:"bytes,6,rep,name=category_facets,json=categoryFacets,proto3" json:"category_facets,omitempty"`
+	TagFacets      []*FacetCount `protobuf:"bytes,7,rep,name=tag_facets,json=tagFacets,proto3" json:"tag_facets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetVideosResponse) Reset() {
+	*x = GetVideosResponse{}
+	mi := &file_video_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideosResponse) ProtoMessage() {}
+
+func (x *GetVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideosResponse.ProtoReflect.Descriptor instead.
+func (*GetVideosResponse) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetVideosResponse) GetVideos() []*Video {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *GetVideosResponse) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *GetVideosResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetVideosResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetVideosResponse) GetCategoryFacets() []*FacetCount {
+	if x != nil {
+		return x.CategoryFacets
+	}
+	return nil
+}
+
+func (x *GetVideosResponse) GetTagFacets() []*FacetCount {
+	if x != nil {
+		return x.TagFacets
+	}
+	return nil
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_video_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type UpdateVideoRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	VideoId     string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Title       *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Category    *string                `protobuf:"bytes,4,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	// Replaces the video's tags when non-empty; see clear_tags.
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Remove all tags.
+	ClearTags     bool `protobuf:"varint,6,opt,name=clear_tags,json=clearTags,proto3" json:"clear_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVideoRequest) Reset() {
+	*x = UpdateVideoRequest{}
+	mi := &file_video_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVideoRequest) ProtoMessage() {}
+
+func (x *UpdateVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVideoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVideoRequest) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateVideoRequest) GetClearTags() bool {
+	if x != nil {
+		return x.ClearTags
+	}
+	return false
+}
+
+type ListTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only tags whose slug starts with this, for autocompletion.
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Defaults to 10, at most 50.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_video_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTagsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Tags          []*TagCount            `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_video_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RecordViewRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VideoId        string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	WatchedSeconds float64                `protobuf:"fixed64,2,opt,name=watched_seconds,json=watchedSeconds,proto3" json:"watched_seconds,omitempty"`
+	// Ignored: the server does not know a video's length, so a view always
+	// needs the full watch threshold.
+	//
+	// Deprecated: Marked as deprecated in video.proto.
+	Completed     bool `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewRequest) Reset() {
+	*x = RecordViewRequest{}
+	mi := &file_video_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewRequest) ProtoMessage() {}
+
+func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewRequest.ProtoReflect.Descriptor instead.
+func (*RecordViewRequest) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecordViewRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetWatchedSeconds() float64 {
+	if x != nil {
+		return x.WatchedSeconds
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in video.proto.
+func (x *RecordViewRequest) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+type RecordViewResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Counted bool                   `protobuf:"varint,1,opt,name=counted,proto3" json:"counted,omitempty"`
+	// counted, duplicate, too_short, no_playback, bot or rate_limited.
+	Result        string `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVideosRequest) Reset() {
-	*x = GetVideosRequest{}
-	mi := &file_video_proto_msgTypes[0]
+func (x *RecordViewResponse) Reset() {
+	*x = RecordViewResponse{}
+	mi := &file_video_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVideosRequest) String() string {
+func (x *RecordViewResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVideosRequest) ProtoMessage() {}
+func (*RecordViewResponse) ProtoMessage() {}
 
-func (x *GetVideosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[0]
+func (x *RecordViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,71 +603,120 @@ func (x *GetVideosRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVideosRequest.ProtoReflect.Descriptor instead.
-func (*GetVideosRequest) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RecordViewResponse.ProtoReflect.Descriptor instead.
+func (*RecordViewResponse) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetVideosRequest) GetPage() int32 {
+func (x *RecordViewResponse) GetCounted() bool {
 	if x != nil {
-		return x.Page
+		return x.Counted
 	}
-	return 0
+	return false
 }
 
-func (x *GetVideosRequest) GetLimit() int32 {
+func (x *RecordViewResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type GetRankedVideosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token of the previous page.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only videos of this category.
+	Category string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	// Skip counting the ranked videos; total is then left out of the response.
+	SkipTotal     bool `protobuf:"varint,4,opt,name=skip_total,json=skipTotal,proto3" json:"skip_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankedVideosRequest) Reset() {
+	*x = GetRankedVideosRequest{}
+	mi := &file_video_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankedVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankedVideosRequest) ProtoMessage() {}
+
+func (x *GetRankedVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankedVideosRequest.ProtoReflect.Descriptor instead.
+func (*GetRankedVideosRequest) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRankedVideosRequest) GetLimit() int32 {
 	if x != nil {
 		return x.Limit
 	}
 	return 0
 }
 
-func (x *GetVideosRequest) GetQuery() string {
+func (x *GetRankedVideosRequest) GetPageToken() string {
 	if x != nil {
-		return x.Query
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *GetVideosRequest) GetStatus() string {
+func (x *GetRankedVideosRequest) GetCategory() string {
 	if x != nil {
-		return x.Status
+		return x.Category
 	}
 	return ""
 }
 
-func (x *GetVideosRequest) GetUserId() string {
+func (x *GetRankedVideosRequest) GetSkipTotal() bool {
 	if x != nil {
-		return x.UserId
+		return x.SkipTotal
 	}
-	return ""
+	return false
 }
 
-type GetVideosResponse struct {
+type TagCount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Videos        []*Video               `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Videos        int64                  `protobuf:"varint,3,opt,name=videos,proto3" json:"videos,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVideosResponse) Reset() {
-	*x = GetVideosResponse{}
-	mi := &file_video_proto_msgTypes[1]
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_video_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVideosResponse) String() string {
+func (x *TagCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVideosResponse) ProtoMessage() {}
+func (*TagCount) ProtoMessage() {}
 
-func (x *GetVideosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[1]
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -133,35 +727,28 @@ func (x *GetVideosResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVideosResponse.ProtoReflect.Descriptor instead.
-func (*GetVideosResponse) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *GetVideosResponse) GetVideos() []*Video {
-	if x != nil {
-		return x.Videos
-	}
-	return nil
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetVideosResponse) GetTotal() int64 {
+func (x *TagCount) GetSlug() string {
 	if x != nil {
-		return x.Total
+		return x.Slug
 	}
-	return 0
+	return ""
 }
 
-func (x *GetVideosResponse) GetPage() int32 {
+func (x *TagCount) GetName() string {
 	if x != nil {
-		return x.Page
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *GetVideosResponse) GetLimit() int32 {
+func (x *TagCount) GetVideos() int64 {
 	if x != nil {
-		return x.Limit
+		return x.Videos
 	}
 	return 0
 }
@@ -171,13 +758,16 @@ type CreateVideoRequest struct {
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	FileExtension string                 `protobuf:"bytes,3,opt,name=file_extension,json=fileExtension,proto3" json:"file_extension,omitempty"`
+	// At most 10 tags of up to 50 characters each.
+	Tags          []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Category      string   `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateVideoRequest) Reset() {
 	*x = CreateVideoRequest{}
-	mi := &file_video_proto_msgTypes[2]
+	mi := &file_video_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -189,7 +779,7 @@ func (x *CreateVideoRequest) String() string {
 func (*CreateVideoRequest) ProtoMessage() {}
 
 func (x *CreateVideoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[2]
+	mi := &file_video_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -202,7 +792,7 @@ func (x *CreateVideoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateVideoRequest.ProtoReflect.Descriptor instead.
 func (*CreateVideoRequest) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{2}
+	return file_video_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateVideoRequest) GetTitle() string {
@@ -226,6 +816,20 @@ func (x *CreateVideoRequest) GetFileExtension() string {
 	return ""
 }
 
+func (x *CreateVideoRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateVideoRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
 type CreateVideoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
@@ -236,7 +840,7 @@ type CreateVideoResponse struct {
 
 func (x *CreateVideoResponse) Reset() {
 	*x = CreateVideoResponse{}
-	mi := &file_video_proto_msgTypes[3]
+	mi := &file_video_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -248,7 +852,7 @@ func (x *CreateVideoResponse) String() string {
 func (*CreateVideoResponse) ProtoMessage() {}
 
 func (x *CreateVideoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[3]
+	mi := &file_video_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,7 +865,7 @@ func (x *CreateVideoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateVideoResponse.ProtoReflect.Descriptor instead.
 func (*CreateVideoResponse) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{3}
+	return file_video_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateVideoResponse) GetVideoId() string {
@@ -287,7 +891,7 @@ type CompleteUploadRequest struct {
 
 func (x *CompleteUploadRequest) Reset() {
 	*x = CompleteUploadRequest{}
-	mi := &file_video_proto_msgTypes[4]
+	mi := &file_video_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -299,7 +903,7 @@ func (x *CompleteUploadRequest) String() string {
 func (*CompleteUploadRequest) ProtoMessage() {}
 
 func (x *CompleteUploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[4]
+	mi := &file_video_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +916,7 @@ func (x *CompleteUploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteUploadRequest.ProtoReflect.Descriptor instead.
 func (*CompleteUploadRequest) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{4}
+	return file_video_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CompleteUploadRequest) GetVideoId() string {
@@ -332,7 +936,7 @@ type CompleteUploadResponse struct {
 
 func (x *CompleteUploadResponse) Reset() {
 	*x = CompleteUploadResponse{}
-	mi := &file_video_proto_msgTypes[5]
+	mi := &file_video_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -344,7 +948,7 @@ func (x *CompleteUploadResponse) String() string {
 func (*CompleteUploadResponse) ProtoMessage() {}
 
 func (x *CompleteUploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[5]
+	mi := &file_video_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,7 +961,7 @@ func (x *CompleteUploadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteUploadResponse.ProtoReflect.Descriptor instead.
 func (*CompleteUploadResponse) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{5}
+	return file_video_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CompleteUploadResponse) GetVideoId() string {
@@ -383,7 +987,7 @@ type GetVideoRequest struct {
 
 func (x *GetVideoRequest) Reset() {
 	*x = GetVideoRequest{}
-	mi := &file_video_proto_msgTypes[6]
+	mi := &file_video_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -395,7 +999,7 @@ func (x *GetVideoRequest) String() string {
 func (*GetVideoRequest) ProtoMessage() {}
 
 func (x *GetVideoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[6]
+	mi := &file_video_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -408,7 +1012,7 @@ func (x *GetVideoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVideoRequest.ProtoReflect.Descriptor instead.
 func (*GetVideoRequest) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{6}
+	return file_video_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetVideoRequest) GetVideoId() string {
@@ -427,7 +1031,7 @@ type GetVideoResponse struct {
 
 func (x *GetVideoResponse) Reset() {
 	*x = GetVideoResponse{}
-	mi := &file_video_proto_msgTypes[7]
+	mi := &file_video_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -439,7 +1043,7 @@ func (x *GetVideoResponse) String() string {
 func (*GetVideoResponse) ProtoMessage() {}
 
 func (x *GetVideoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[7]
+	mi := &file_video_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -452,7 +1056,7 @@ func (x *GetVideoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVideoResponse.ProtoReflect.Descriptor instead.
 func (*GetVideoResponse) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{7}
+	return file_video_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetVideoResponse) GetVideo() *Video {
@@ -463,23 +1067,33 @@ func (x *GetVideoResponse) GetVideo() *Video {
 }
 
 type Video struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	HlsUrl        string                 `protobuf:"bytes,4,opt,name=hls_url,json=hlsUrl,proto3" json:"hls_url,omitempty"`
-	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
-	Views         int64                  `protobuf:"varint,6,opt,name=views,proto3" json:"views,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	AuthorId      string                 `protobuf:"bytes,8,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title        string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description  string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	HlsUrl       string                 `protobuf:"bytes,4,opt,name=hls_url,json=hlsUrl,proto3" json:"hls_url,omitempty"`
+	ThumbnailUrl string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	Views        int64                  `protobuf:"varint,6,opt,name=views,proto3" json:"views,omitempty"`
+	Status       string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	AuthorId     string                 `protobuf:"bytes,8,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAt    string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set on search results only.
+	Search           *SearchHit `protobuf:"bytes,10,opt,name=search,proto3" json:"search,omitempty"`
+	Category         string     `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	Tags             []*Tag     `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	CommentCount     int64      `protobuf:"varint,13,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
+	CommentsDisabled bool       `protobuf:"varint,14,opt,name=comments_disabled,json=commentsDisabled,proto3" json:"comments_disabled,omitempty"`
+	LikeCount        int64      `protobuf:"varint,15,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	DislikeCount     int64      `protobuf:"varint,16,opt,name=dislike_count,json=dislikeCount,proto3" json:"dislike_count,omitempty"`
+	// Where the caller left off, for signed-in callers who started the video.
+	ResumePositionSeconds *float64 `protobuf:"fixed64,17,opt,name=resume_position_seconds,json=resumePositionSeconds,proto3,oneof" json:"resume_position_seconds,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *Video) Reset() {
 	*x = Video{}
-	mi := &file_video_proto_msgTypes[8]
+	mi := &file_video_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -491,7 +1105,7 @@ func (x *Video) String() string {
 func (*Video) ProtoMessage() {}
 
 func (x *Video) ProtoReflect() protoreflect.Message {
-	mi := &file_video_proto_msgTypes[8]
+	mi := &file_video_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,7 +1118,7 @@ func (x *Video) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Video.ProtoReflect.Descriptor instead.
 func (*Video) Descriptor() ([]byte, []int) {
-	return file_video_proto_rawDescGZIP(), []int{8}
+	return file_video_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Video) GetId() string {
@@ -570,26 +1184,252 @@ func (x *Video) GetCreatedAt() string {
 	return ""
 }
 
+func (x *Video) GetSearch() *SearchHit {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *Video) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Video) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Video) GetCommentCount() int64 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
+func (x *Video) GetCommentsDisabled() bool {
+	if x != nil {
+		return x.CommentsDisabled
+	}
+	return false
+}
+
+func (x *Video) GetLikeCount() int64 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *Video) GetDislikeCount() int64 {
+	if x != nil {
+		return x.DislikeCount
+	}
+	return 0
+}
+
+func (x *Video) GetResumePositionSeconds() float64 {
+	if x != nil && x.ResumePositionSeconds != nil {
+		return *x.ResumePositionSeconds
+	}
+	return 0
+}
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_video_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Tag) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// SearchHit describes why a video matched a GetVideos query. Matched words
+// in the highlights are wrapped in <mark></mark>.
+type SearchHit struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Score                float64                `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	TitleHighlight       string                 `protobuf:"bytes,2,opt,name=title_highlight,json=titleHighlight,proto3" json:"title_highlight,omitempty"`
+	DescriptionHighlight string                 `protobuf:"bytes,3,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_video_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_video_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_video_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetTitleHighlight() string {
+	if x != nil {
+		return x.TitleHighlight
+	}
+	return ""
+}
+
+func (x *SearchHit) GetDescriptionHighlight() string {
+	if x != nil {
+		return x.DescriptionHighlight
+	}
+	return ""
+}
+
 var File_video_proto protoreflect.FileDescriptor
 
 const file_video_proto_rawDesc = "" +
 	"\n" +
-	"\vvideo.proto\x12\x11gostream.video.v1\x1a\x1cgoogle/api/annotations.proto\"\x83\x01\n" +
+	"\vvideo.proto\x12\x11gostream.video.v1\x1a\x1cgoogle/api/annotations.proto\"\xc7\x02\n" +
 	"\x10GetVideosRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
 	"\x05query\x18\x03 \x01(\tR\x05query\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
-	"\auser_id\x18\x05 \x01(\tR\x06userId\"\x85\x01\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04mine\x18\x06 \x01(\bR\x04mine\x12\x19\n" +
+	"\border_by\x18\a \x01(\tR\aorderBy\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"skip_total\x18\t \x01(\bR\tskipTotal\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\v \x01(\tR\bcategory\x12%\n" +
+	"\x0einclude_facets\x18\f \x01(\bR\rincludeFacets\"\xc2\x02\n" +
 	"\x11GetVideosResponse\x120\n" +
-	"\x06videos\x18\x01 \x03(\v2\x18.gostream.video.v1.VideoR\x06videos\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x06videos\x18\x01 \x03(\v2\x18.gostream.video.v1.VideoR\x06videos\x12\x19\n" +
+	"\x05total\x18\x02 \x01(\x03H\x00R\x05total\x88\x01\x01\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"s\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\x12F\n" +
+	"\x0fcategory_facets\x18\x06 \x03(\v2\x1d.gostream.video.v1.FacetCountR\x0ecategoryFacets\x12<\n" +
+	"\n" +
+	"tag_facets\x18\a \x03(\v2\x1d.gostream.video.v1.FacetCountR\ttagFacetsB\b\n" +
+	"\x06_total\"N\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\"\xec\x01\n" +
+	"\x12UpdateVideoRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x04 \x01(\tH\x02R\bcategory\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"clear_tags\x18\x06 \x01(\bR\tclearTagsB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_category\"?\n" +
+	"\x0fListTagsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"C\n" +
+	"\x10ListTagsResponse\x12/\n" +
+	"\x04tags\x18\x01 \x03(\v2\x1b.gostream.video.v1.TagCountR\x04tags\"y\n" +
+	"\x11RecordViewRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12'\n" +
+	"\x0fwatched_seconds\x18\x02 \x01(\x01R\x0ewatchedSeconds\x12 \n" +
+	"\tcompleted\x18\x03 \x01(\bB\x02\x18\x01R\tcompleted\"F\n" +
+	"\x12RecordViewResponse\x12\x18\n" +
+	"\acounted\x18\x01 \x01(\bR\acounted\x12\x16\n" +
+	"\x06result\x18\x02 \x01(\tR\x06result\"\x88\x01\n" +
+	"\x16GetRankedVideosRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"skip_total\x18\x04 \x01(\bR\tskipTotal\"J\n" +
+	"\bTagCount\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06videos\x18\x03 \x01(\x03R\x06videos\"\xa3\x01\n" +
 	"\x12CreateVideoRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12%\n" +
-	"\x0efile_extension\x18\x03 \x01(\tR\rfileExtension\"O\n" +
+	"\x0efile_extension\x18\x03 \x01(\tR\rfileExtension\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\"O\n" +
 	"\x13CreateVideoResponse\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
 	"\n" +
@@ -602,7 +1442,7 @@ const file_video_proto_rawDesc = "" +
 	"\x0fGetVideoRequest\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"B\n" +
 	"\x10GetVideoResponse\x12.\n" +
-	"\x05video\x18\x01 \x01(\v2\x18.gostream.video.v1.VideoR\x05video\"\xf7\x01\n" +
+	"\x05video\x18\x01 \x01(\v2\x18.gostream.video.v1.VideoR\x05video\"\xe4\x04\n" +
 	"\x05Video\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -613,14 +1453,40 @@ const file_video_proto_rawDesc = "" +
 	"\x06status\x18\a \x01(\tR\x06status\x12\x1b\n" +
 	"\tauthor_id\x18\b \x01(\tR\bauthorId\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\t \x01(\tR\tcreatedAt2\xeb\x03\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x124\n" +
+	"\x06search\x18\n" +
+	" \x01(\v2\x1c.gostream.video.v1.SearchHitR\x06search\x12\x1a\n" +
+	"\bcategory\x18\v \x01(\tR\bcategory\x12*\n" +
+	"\x04tags\x18\f \x03(\v2\x16.gostream.video.v1.TagR\x04tags\x12#\n" +
+	"\rcomment_count\x18\r \x01(\x03R\fcommentCount\x12+\n" +
+	"\x11comments_disabled\x18\x0e \x01(\bR\x10commentsDisabled\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x0f \x01(\x03R\tlikeCount\x12#\n" +
+	"\rdislike_count\x18\x10 \x01(\x03R\fdislikeCount\x12;\n" +
+	"\x17resume_position_seconds\x18\x11 \x01(\x01H\x00R\x15resumePositionSeconds\x88\x01\x01B\x1a\n" +
+	"\x18_resume_position_seconds\"-\n" +
+	"\x03Tag\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x7f\n" +
+	"\tSearchHit\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x01R\x05score\x12'\n" +
+	"\x0ftitle_highlight\x18\x02 \x01(\tR\x0etitleHighlight\x123\n" +
+	"\x15description_highlight\x18\x03 \x01(\tR\x14descriptionHighlight2\xbc\b\n" +
 	"\fVideoService\x12s\n" +
 	"\vCreateVideo\x12%.gostream.video.v1.CreateVideoRequest\x1a&.gostream.video.v1.CreateVideoResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
 	"/v1/videos\x12\x90\x01\n" +
 	"\x0eCompleteUpload\x12(.gostream.video.v1.CompleteUploadRequest\x1a).gostream.video.v1.CompleteUploadResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/videos/{video_id}/complete\x12g\n" +
 	"\bGetVideo\x12\".gostream.video.v1.GetVideoRequest\x1a\x18.gostream.video.v1.Video\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/videos/{video_id}\x12j\n" +
 	"\tGetVideos\x12#.gostream.video.v1.GetVideosRequest\x1a$.gostream.video.v1.GetVideosResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/v1/videosB6Z4github.com/hunderaweke/gostream/gen/go/video;videopbb\x06proto3"
+	"/v1/videos\x12p\n" +
+	"\vUpdateVideo\x12%.gostream.video.v1.UpdateVideoRequest\x1a\x18.gostream.video.v1.Video\" \x82\xd3\xe4\x93\x02\x1a:\x01*2\x15/v1/videos/{video_id}\x12e\n" +
+	"\bListTags\x12\".gostream.video.v1.ListTagsRequest\x1a#.gostream.video.v1.ListTagsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/tags\x12\x81\x01\n" +
+	"\n" +
+	"RecordView\x12$.gostream.video.v1.RecordViewRequest\x1a%.gostream.video.v1.RecordViewResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/videos/{video_id}/views\x12y\n" +
+	"\vGetTrending\x12).gostream.video.v1.GetRankedVideosRequest\x1a$.gostream.video.v1.GetVideosResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/feed/trending\x12w\n" +
+	"\n" +
+	"GetPopular\x12).gostream.video.v1.GetRankedVideosRequest\x1a$.gostream.video.v1.GetVideosResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/feed/popularB6Z4github.com/hunderaweke/gostream/gen/go/video;videopbb\x06proto3"
 
 var (
 	file_video_proto_rawDescOnce sync.Once
@@ -634,34 +1500,59 @@ func file_video_proto_rawDescGZIP() []byte {
 	return file_video_proto_rawDescData
 }
 
-var file_video_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_video_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_video_proto_goTypes = []any{
 	(*GetVideosRequest)(nil),       // 0: gostream.video.v1.GetVideosRequest
 	(*GetVideosResponse)(nil),      // 1: gostream.video.v1.GetVideosResponse
-	(*CreateVideoRequest)(nil),     // 2: gostream.video.v1.CreateVideoRequest
-	(*CreateVideoResponse)(nil),    // 3: gostream.video.v1.CreateVideoResponse
-	(*CompleteUploadRequest)(nil),  // 4: gostream.video.v1.CompleteUploadRequest
-	(*CompleteUploadResponse)(nil), // 5: gostream.video.v1.CompleteUploadResponse
-	(*GetVideoRequest)(nil),        // 6: gostream.video.v1.GetVideoRequest
-	(*GetVideoResponse)(nil),       // 7: gostream.video.v1.GetVideoResponse
-	(*Video)(nil),                  // 8: gostream.video.v1.Video
+	(*FacetCount)(nil),             // 2: gostream.video.v1.FacetCount
+	(*UpdateVideoRequest)(nil),     // 3: gostream.video.v1.UpdateVideoRequest
+	(*ListTagsRequest)(nil),        // 4: gostream.video.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),       // 5: gostream.video.v1.ListTagsResponse
+	(*RecordViewRequest)(nil),      // 6: gostream.video.v1.RecordViewRequest
+	(*RecordViewResponse)(nil),     // 7: gostream.video.v1.RecordViewResponse
+	(*GetRankedVideosRequest)(nil), // 8: gostream.video.v1.GetRankedVideosRequest
+	(*TagCount)(nil),               // 9: gostream.video.v1.TagCount
+	(*CreateVideoRequest)(nil),     // 10: gostream.video.v1.CreateVideoRequest
+	(*CreateVideoResponse)(nil),    // 11: gostream.video.v1.CreateVideoResponse
+	(*CompleteUploadRequest)(nil),  // 12: gostream.video.v1.CompleteUploadRequest
+	(*CompleteUploadResponse)(nil), // 13: gostream.video.v1.CompleteUploadResponse
+	(*GetVideoRequest)(nil),        // 14: gostream.video.v1.GetVideoRequest
+	(*GetVideoResponse)(nil),       // 15: gostream.video.v1.GetVideoResponse
+	(*Video)(nil),                  // 16: gostream.video.v1.Video
+	(*Tag)(nil),                    // 17: gostream.video.v1.Tag
+	(*SearchHit)(nil),              // 18: gostream.video.v1.SearchHit
 }
 var file_video_proto_depIdxs = []int32{
-	8, // 0: gostream.video.v1.GetVideosResponse.videos:type_name -> gostream.video.v1.Video
-	8, // 1: gostream.video.v1.GetVideoResponse.video:type_name -> gostream.video.v1.Video
-	2, // 2: gostream.video.v1.VideoService.CreateVideo:input_type -> gostream.video.v1.CreateVideoRequest
-	4, // 3: gostream.video.v1.VideoService.CompleteUpload:input_type -> gostream.video.v1.CompleteUploadRequest
-	6, // 4: gostream.video.v1.VideoService.GetVideo:input_type -> gostream.video.v1.GetVideoRequest
-	0, // 5: gostream.video.v1.VideoService.GetVideos:input_type -> gostream.video.v1.GetVideosRequest
-	3, // 6: gostream.video.v1.VideoService.CreateVideo:output_type -> gostream.video.v1.CreateVideoResponse
-	5, // 7: gostream.video.v1.VideoService.CompleteUpload:output_type -> gostream.video.v1.CompleteUploadResponse
-	8, // 8: gostream.video.v1.VideoService.GetVideo:output_type -> gostream.video.v1.Video
-	1, // 9: gostream.video.v1.VideoService.GetVideos:output_type -> gostream.video.v1.GetVideosResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	16, // 0: gostream.video.v1.GetVideosResponse.videos:type_name -> gostream.video.v1.Video
+	2,  // 1: gostream.video.v1.GetVideosResponse.category_facets:type_name -> gostream.video.v1.FacetCount
+	2,  // 2: gostream.video.v1.GetVideosResponse.tag_facets:type_name -> gostream.video.v1.FacetCount
+	9,  // 3: gostream.video.v1.ListTagsResponse.tags:type_name -> gostream.video.v1.TagCount
+	16, // 4: gostream.video.v1.GetVideoResponse.video:type_name -> gostream.video.v1.Video
+	18, // 5: gostream.video.v1.Video.search:type_name -> gostream.video.v1.SearchHit
+	17, // 6: gostream.video.v1.Video.tags:type_name -> gostream.video.v1.Tag
+	10, // 7: gostream.video.v1.VideoService.CreateVideo:input_type -> gostream.video.v1.CreateVideoRequest
+	12, // 8: gostream.video.v1.VideoService.CompleteUpload:input_type -> gostream.video.v1.CompleteUploadRequest
+	14, // 9: gostream.video.v1.VideoService.GetVideo:input_type -> gostream.video.v1.GetVideoRequest
+	0,  // 10: gostream.video.v1.VideoService.GetVideos:input_type -> gostream.video.v1.GetVideosRequest
+	3,  // 11: gostream.video.v1.VideoService.UpdateVideo:input_type -> gostream.video.v1.UpdateVideoRequest
+	4,  // 12: gostream.video.v1.VideoService.ListTags:input_type -> gostream.video.v1.ListTagsRequest
+	6,  // 13: gostream.video.v1.VideoService.RecordView:input_type -> gostream.video.v1.RecordViewRequest
+	8,  // 14: gostream.video.v1.VideoService.GetTrending:input_type -> gostream.video.v1.GetRankedVideosRequest
+	8,  // 15: gostream.video.v1.VideoService.GetPopular:input_type -> gostream.video.v1.GetRankedVideosRequest
+	11, // 16: gostream.video.v1.VideoService.CreateVideo:output_type -> gostream.video.v1.CreateVideoResponse
+	13, // 17: gostream.video.v1.VideoService.CompleteUpload:output_type -> gostream.video.v1.CompleteUploadResponse
+	16, // 18: gostream.video.v1.VideoService.GetVideo:output_type -> gostream.video.v1.Video
+	1,  // 19: gostream.video.v1.VideoService.GetVideos:output_type -> gostream.video.v1.GetVideosResponse
+	16, // 20: gostream.video.v1.VideoService.UpdateVideo:output_type -> gostream.video.v1.Video
+	5,  // 21: gostream.video.v1.VideoService.ListTags:output_type -> gostream.video.v1.ListTagsResponse
+	7,  // 22: gostream.video.v1.VideoService.RecordView:output_type -> gostream.video.v1.RecordViewResponse
+	1,  // 23: gostream.video.v1.VideoService.GetTrending:output_type -> gostream.video.v1.GetVideosResponse
+	1,  // 24: gostream.video.v1.VideoService.GetPopular:output_type -> gostream.video.v1.GetVideosResponse
+	16, // [16:25] is the sub-list for method output_type
+	7,  // [7:16] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_video_proto_init() }
@@ -669,13 +1560,16 @@ func file_video_proto_init() {
 	if File_video_proto != nil {
 		return
 	}
+	file_video_proto_msgTypes[1].OneofWrappers = []any{}
+	file_video_proto_msgTypes[3].OneofWrappers = []any{}
+	file_video_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_video_proto_rawDesc), len(file_video_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
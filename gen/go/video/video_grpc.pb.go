@@ -23,6 +23,11 @@ const (
 	VideoService_CompleteUpload_FullMethodName = "/gostream.video.v1.VideoService/CompleteUpload"
 	VideoService_GetVideo_FullMethodName       = "/gostream.video.v1.VideoService/GetVideo"
 	VideoService_GetVideos_FullMethodName      = "/gostream.video.v1.VideoService/GetVideos"
+	VideoService_UpdateVideo_FullMethodName    = "/gostream.video.v1.VideoService/UpdateVideo"
+	VideoService_ListTags_FullMethodName       = "/gostream.video.v1.VideoService/ListTags"
+	VideoService_RecordView_FullMethodName     = "/gostream.video.v1.VideoService/RecordView"
+	VideoService_GetTrending_FullMethodName    = "/gostream.video.v1.VideoService/GetTrending"
+	VideoService_GetPopular_FullMethodName     = "/gostream.video.v1.VideoService/GetPopular"
 )
 
 // VideoServiceClient is the client API for VideoService service.
@@ -33,6 +38,22 @@ type VideoServiceClient interface {
 	CompleteUpload(ctx context.Context, in *CompleteUploadRequest, opts ...grpc.CallOption) (*CompleteUploadResponse, error)
 	GetVideo(ctx context.Context, in *GetVideoRequest, opts ...grpc.CallOption) (*Video, error)
 	GetVideos(ctx context.Context, in *GetVideosRequest, opts ...grpc.CallOption) (*GetVideosResponse, error)
+	// UpdateVideo changes the metadata of one of the caller's videos. Unset
+	// fields are left alone.
+	UpdateVideo(ctx context.Context, in *UpdateVideoRequest, opts ...grpc.CallOption) (*Video, error)
+	// ListTags returns the tags used by published videos, most used first.
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	// RecordView is reported by the player once the viewer has watched for
+	// the view threshold, or to the end of a shorter video. The view counts
+	// once per viewer per window, and only if the watch time fits the time
+	// since the player fetched the video's playlist.
+	RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*RecordViewResponse, error)
+	// GetTrending lists the videos gaining views and likes right now. The
+	// ranking is recomputed every few minutes.
+	GetTrending(ctx context.Context, in *GetRankedVideosRequest, opts ...grpc.CallOption) (*GetVideosResponse, error)
+	// GetPopular lists the videos with the most views and likes, favouring
+	// recent uploads over old ones.
+	GetPopular(ctx context.Context, in *GetRankedVideosRequest, opts ...grpc.CallOption) (*GetVideosResponse, error)
 }
 
 type videoServiceClient struct {
@@ -83,6 +104,56 @@ func (c *videoServiceClient) GetVideos(ctx context.Context, in *GetVideosRequest
 	return out, nil
 }
 
+func (c *videoServiceClient) UpdateVideo(ctx context.Context, in *UpdateVideoRequest, opts ...grpc.CallOption) (*Video, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Video)
+	err := c.cc.Invoke(ctx, VideoService_UpdateVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*RecordViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordViewResponse)
+	err := c.cc.Invoke(ctx, VideoService_RecordView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetTrending(ctx context.Context, in *GetRankedVideosRequest, opts ...grpc.CallOption) (*GetVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVideosResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetTrending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetPopular(ctx context.Context, in *GetRankedVideosRequest, opts ...grpc.CallOption) (*GetVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVideosResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetPopular_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VideoServiceServer is the server API for VideoService service.
 // All implementations must embed UnimplementedVideoServiceServer
 // for forward compatibility.
@@ -91,6 +162,22 @@ type VideoServiceServer interface {
 	CompleteUpload(context.Context, *CompleteUploadRequest) (*CompleteUploadResponse, error)
 	GetVideo(context.Context, *GetVideoRequest) (*Video, error)
 	GetVideos(context.Context, *GetVideosRequest) (*GetVideosResponse, error)
+	// UpdateVideo changes the metadata of one of the caller's videos. Unset
+	// fields are left alone.
+	UpdateVideo(context.Context, *UpdateVideoRequest) (*Video, error)
+	// ListTags returns the tags used by published videos, most used first.
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	// RecordView is reported by the player once the viewer has watched for
+	// the view threshold, or to the end of a shorter video. The view counts
+	// once per viewer per window, and only if the watch time fits the time
+	// since the player fetched the video's playlist.
+	RecordView(context.Context, *RecordViewRequest) (*RecordViewResponse, error)
+	// GetTrending lists the videos gaining views and likes right now. The
+	// ranking is recomputed every few minutes.
+	GetTrending(context.Context, *GetRankedVideosRequest) (*GetVideosResponse, error)
+	// GetPopular lists the videos with the most views and likes, favouring
+	// recent uploads over old ones.
+	GetPopular(context.Context, *GetRankedVideosRequest) (*GetVideosResponse, error)
 	mustEmbedUnimplementedVideoServiceServer()
 }
 
@@ -113,6 +200,21 @@ func (UnimplementedVideoServiceServer) GetVideo(context.Context, *GetVideoReques
 func (UnimplementedVideoServiceServer) GetVideos(context.Context, *GetVideosRequest) (*GetVideosResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetVideos not implemented")
 }
+func (UnimplementedVideoServiceServer) UpdateVideo(context.Context, *UpdateVideoRequest) (*Video, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateVideo not implemented")
+}
+func (UnimplementedVideoServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedVideoServiceServer) RecordView(context.Context, *RecordViewRequest) (*RecordViewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordView not implemented")
+}
+func (UnimplementedVideoServiceServer) GetTrending(context.Context, *GetRankedVideosRequest) (*GetVideosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrending not implemented")
+}
+func (UnimplementedVideoServiceServer) GetPopular(context.Context, *GetRankedVideosRequest) (*GetVideosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPopular not implemented")
+}
 func (UnimplementedVideoServiceServer) mustEmbedUnimplementedVideoServiceServer() {}
 func (UnimplementedVideoServiceServer) testEmbeddedByValue()                      {}
 
@@ -206,6 +308,96 @@ func _VideoService_GetVideos_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VideoService_UpdateVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).UpdateVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_UpdateVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).UpdateVideo(ctx, req.(*UpdateVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_RecordView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).RecordView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_RecordView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).RecordView(ctx, req.(*RecordViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetTrending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRankedVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetTrending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetTrending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetTrending(ctx, req.(*GetRankedVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetPopular_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRankedVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetPopular(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetPopular_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetPopular(ctx, req.(*GetRankedVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VideoService_ServiceDesc is the grpc.ServiceDesc for VideoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +421,26 @@ var VideoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVideos",
 			Handler:    _VideoService_GetVideos_Handler,
 		},
+		{
+			MethodName: "UpdateVideo",
+			Handler:    _VideoService_UpdateVideo_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _VideoService_ListTags_Handler,
+		},
+		{
+			MethodName: "RecordView",
+			Handler:    _VideoService_RecordView_Handler,
+		},
+		{
+			MethodName: "GetTrending",
+			Handler:    _VideoService_GetTrending_Handler,
+		},
+		{
+			MethodName: "GetPopular",
+			Handler:    _VideoService_GetPopular_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "video.proto",
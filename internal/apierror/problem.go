@@ -0,0 +1,116 @@
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/hunderaweke/gostream/internal/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Code, Reason, RequestID,
+// Errors, Resource and RetryAfter are extension members carrying the gRPC
+// code and the errdetails attached to the status.
+type Problem struct {
+	Type       string          `json:"type"`
+	Title      string          `json:"title"`
+	Status     int             `json:"status"`
+	Detail     string          `json:"detail,omitempty"`
+	Instance   string          `json:"instance,omitempty"`
+	Code       string          `json:"code"`
+	Reason     string          `json:"reason,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Errors     []FieldProblem  `json:"errors,omitempty"`
+	Resource   *ResourceDetail `json:"resource,omitempty"`
+	RetryAfter int64           `json:"retry_after,omitempty"`
+}
+
+type FieldProblem struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+type ResourceDetail struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+}
+
+// NewProblem builds the problem for s as seen by request r.
+func NewProblem(r *http.Request, s *status.Status) Problem {
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+	p := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(httpStatus),
+		Status:    httpStatus,
+		Detail:    s.Message(),
+		Instance:  r.URL.Path,
+		Code:      s.Code().String(),
+		RequestID: r.Header.Get(logging.RequestIDHeader),
+	}
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			p.Reason = d.GetReason()
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				p.Errors = append(p.Errors, FieldProblem{Field: v.GetField(), Detail: v.GetDescription()})
+			}
+		case *errdetails.ResourceInfo:
+			p.Resource = &ResourceDetail{Type: d.GetResourceType(), ID: d.GetResourceName()}
+		case *errdetails.RetryInfo:
+			p.RetryAfter = int64((d.GetRetryDelay().AsDuration() + time.Second - 1) / time.Second)
+		case *errdetails.RequestInfo:
+			if d.GetRequestId() != "" {
+				p.RequestID = d.GetRequestId()
+			}
+		}
+	}
+	return p
+}
+
+// WriteProblem writes p as the response, setting Retry-After when the
+// problem carries a retry delay.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	if p.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(p.RetryAfter, 10))
+	}
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// WriteError maps err like the gRPC server does and writes it as a problem.
+// It is used by the plain HTTP handlers that do not go through the gateway.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	WriteProblem(w, NewProblem(r, Status(r.Context(), r.Method+" "+r.URL.Path, err)))
+}
+
+// Write writes a problem with the given gRPC code and message.
+func Write(w http.ResponseWriter, r *http.Request, code codes.Code, message string) {
+	WriteProblem(w, NewProblem(r, status.New(code, message)))
+}
+
+// GatewayErrorHandler is a runtime.ErrorHandlerFunc rendering gateway errors
+// as problem details.
+func GatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	// Routing errors such as 405 carry their own HTTP status.
+	var httpErr *runtime.HTTPStatusError
+	if errors.As(err, &httpErr) {
+		p := NewProblem(r, status.Convert(httpErr.Err))
+		p.Status = httpErr.HTTPStatus
+		p.Title = http.StatusText(httpErr.HTTPStatus)
+		WriteProblem(w, p)
+		return
+	}
+	WriteProblem(w, NewProblem(r, status.Convert(err)))
+}
@@ -0,0 +1,103 @@
+// Package apierror maps application errors to gRPC statuses and renders
+// those statuses as RFC 7807 problem details over HTTP.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is reported in ErrorInfo details.
+const errorDomain = "gostream"
+
+// Status converts err into the status reported to clients. Domain errors
+// keep their message and gain errdetails describing them; errors that already
+// are statuses pass through; anything else is logged under op and replaced by
+// a bare Internal status so that database or storage errors never reach
+// clients.
+func Status(ctx context.Context, op string, err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+	if de, ok := domain.AsError(err); ok {
+		return domainStatus(de)
+	}
+	var locked *domain.AccountLockedError
+	if errors.As(err, &locked) {
+		return withDetails(status.New(codes.ResourceExhausted, err.Error()),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Until(locked.Until).Round(time.Second))},
+			&errdetails.ErrorInfo{Reason: "ACCOUNT_LOCKED", Domain: errorDomain},
+		)
+	}
+	var limited *domain.TooManyAttemptsError
+	if errors.As(err, &limited) {
+		return withDetails(status.New(codes.ResourceExhausted, err.Error()),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(limited.RetryAfter.Round(time.Second))},
+			&errdetails.ErrorInfo{Reason: "RATE_LIMITED", Domain: errorDomain},
+		)
+	}
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrInvalidToken):
+		return status.New(codes.Unauthenticated, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, "request was cancelled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, "request deadline exceeded")
+	}
+	slog.ErrorContext(ctx, "internal error", "op", op, "error", err)
+	return withDetails(status.New(codes.Internal, "internal error"),
+		&errdetails.RequestInfo{RequestId: utils.GetRequestID(ctx)},
+	)
+}
+
+func domainStatus(de *domain.Error) *status.Status {
+	reason := de.Reason
+	if reason == "" {
+		reason = de.Kind.String()
+	}
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+	switch de.Kind {
+	case domain.KindNotFound:
+		return withDetails(status.New(codes.NotFound, de.Message), info,
+			&errdetails.ResourceInfo{ResourceType: de.Resource, ResourceName: de.ID})
+	case domain.KindPermissionDenied:
+		return withDetails(status.New(codes.PermissionDenied, de.Message), info)
+	case domain.KindInvalidArgument:
+		br := &errdetails.BadRequest{}
+		for _, v := range de.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		return withDetails(status.New(codes.InvalidArgument, de.Message), info, br)
+	case domain.KindConflict:
+		return withDetails(status.New(codes.AlreadyExists, de.Message), info)
+	case domain.KindFailedPrecondition:
+		return withDetails(status.New(codes.FailedPrecondition, de.Message), info,
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: reason, Description: de.Message},
+			}})
+	default:
+		return status.New(codes.Unknown, de.Message)
+	}
+}
+
+// withDetails attaches details to s, falling back to s alone if they cannot
+// be marshalled.
+func withDetails(s *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	if ds, err := s.WithDetails(details...); err == nil {
+		return ds
+	}
+	return s
+}
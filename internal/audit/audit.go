@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Security-relevant events. Names are stable so they can be alerted on.
+const (
+	EventLoginLockedOut   = "login.locked_out"
+	EventLoginRateLimited = "login.rate_limited"
+)
+
+// Record writes an audit event to the structured log. Audit records carry
+// audit=true so they can be routed apart from application logs.
+func Record(ctx context.Context, event string, attrs ...any) {
+	slog.InfoContext(ctx, "audit event", append([]any{"audit", true, "event", event}, attrs...)...)
+}
@@ -3,13 +3,15 @@ package database
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 type MinioClient struct {
@@ -26,7 +28,7 @@ func NewMinioClient(bucket string) (*MinioClient, error) {
 	defer cancel()
 	exists, errBucketExists := minioClient.BucketExists(ctx, bucket)
 	if errBucketExists == nil && !exists {
-		log.Printf("bucket do not exist creating it ... %v", bucket)
+		slog.Info("bucket does not exist, creating it", "bucket", bucket)
 		if err := minioClient.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
 			return nil, fmt.Errorf("failed to create bucket: %w", err)
 		}
@@ -44,12 +46,16 @@ func getMinio() (*minio.Client, error) {
 	clnt, err := minio.New(os.Getenv("MINIO_ENDPOINT"), &minio.Options{
 		Creds:  credentials.NewStaticV4(os.Getenv("MINIO_ACCESS_ID"), os.Getenv("MINIO_SECRET_ACCESS_KEY"), ""),
 		Secure: false,
+		Transport: otelhttp.NewTransport(http.DefaultTransport,
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return "minio " + r.Method
+			}),
+		),
 	})
 	return clnt, err
 }
 
-func (m *MinioClient) GeneratePresignedURL(objectName string, expiryTime time.Duration) (string, error) {
-	ctx := context.Background()
+func (m *MinioClient) GeneratePresignedURL(ctx context.Context, objectName string, expiryTime time.Duration) (string, error) {
 	presignedURL, err := m.Client.PresignedPutObject(ctx, m.Bucket, objectName, expiryTime)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate presignedUrl: %v", err)
@@ -58,8 +64,7 @@ func (m *MinioClient) GeneratePresignedURL(objectName string, expiryTime time.Du
 
 }
 
-func (m *MinioClient) GenerateAccessURL(objectName string, expiryTime time.Duration) (string, error) {
-	ctx := context.Background()
+func (m *MinioClient) GenerateAccessURL(ctx context.Context, objectName string, expiryTime time.Duration) (string, error) {
 	presignedURL, err := m.Client.PresignedGetObject(ctx, m.Bucket, objectName, expiryTime, url.Values{})
 	if err != nil {
 		return "", fmt.Errorf("error creating get link for the object: %v %v", err, objectName)
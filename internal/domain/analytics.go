@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// HLSSegmentSeconds is the target length of the segments of the HLS
+	// renditions, which retention curves are counted in.
+	HLSSegmentSeconds = 10
+	// MaxPlaybackEvents bounds the events of one ingestion request.
+	MaxPlaybackEvents = 100
+	// MaxAnalyticsRange bounds the time range of an analytics query.
+	MaxAnalyticsRange = 90 * 24 * time.Hour
+	// MaxRetentionSegments bounds the retention curve: about five and a
+	// half hours of video.
+	MaxRetentionSegments = 2000
+)
+
+// PlaybackEventType is the kind of a player quality-of-experience event.
+type PlaybackEventType string
+
+const (
+	PlaybackStart         PlaybackEventType = "START"
+	PlaybackRebuffer      PlaybackEventType = "REBUFFER"
+	PlaybackBitrateSwitch PlaybackEventType = "BITRATE_SWITCH"
+	PlaybackError         PlaybackEventType = "ERROR"
+	// PlaybackWatchTime reports the seconds watched since the previous
+	// report of the session.
+	PlaybackWatchTime PlaybackEventType = "WATCH_TIME"
+)
+
+func (t PlaybackEventType) Valid() bool {
+	switch t {
+	case PlaybackStart, PlaybackRebuffer, PlaybackBitrateSwitch, PlaybackError, PlaybackWatchTime:
+		return true
+	}
+	return false
+}
+
+// PlaybackEvent is one event reported by a player. SessionID ties together
+// the events of one playback.
+type PlaybackEvent struct {
+	VideoID      uuid.UUID
+	SessionID    string
+	Type         PlaybackEventType
+	WatchSeconds float64
+	RebufferMs   int64
+	BitrateKbps  int64
+	// SegmentIndex is the HLS segment playing when the event happened; -1
+	// when unknown.
+	SegmentIndex int
+	ErrorCode    string
+}
+
+// AnalyticsCounters are the additive measures of a video over some time.
+type AnalyticsCounters struct {
+	// Views counts playback starts.
+	Views           int64   `gorm:"not null;default:0" json:"views"`
+	WatchSeconds    float64 `gorm:"not null;default:0" json:"watch_seconds"`
+	Rebuffers       int64   `gorm:"not null;default:0" json:"rebuffers"`
+	RebufferMs      int64   `gorm:"not null;default:0" json:"rebuffer_ms"`
+	BitrateSwitches int64   `gorm:"not null;default:0" json:"bitrate_switches"`
+	Errors          int64   `gorm:"not null;default:0" json:"errors"`
+	// BytesServed counts the bytes of playlists and segments streamed.
+	BytesServed int64 `gorm:"not null;default:0" json:"bytes_served"`
+}
+
+func (c *AnalyticsCounters) Add(o AnalyticsCounters) {
+	c.Views += o.Views
+	c.WatchSeconds += o.WatchSeconds
+	c.Rebuffers += o.Rebuffers
+	c.RebufferMs += o.RebufferMs
+	c.BitrateSwitches += o.BitrateSwitches
+	c.Errors += o.Errors
+	c.BytesServed += o.BytesServed
+}
+
+// VideoAnalyticsHour is the rollup of a video's playback events in one hour.
+// Rollups are rewritten with the running totals until the hour is over.
+type VideoAnalyticsHour struct {
+	VideoID uuid.UUID `gorm:"type:uuid;primaryKey" json:"video_id"`
+	Hour    time.Time `gorm:"primaryKey" json:"hour"`
+	AnalyticsCounters
+	UniqueViewers int64 `gorm:"not null;default:0" json:"unique_viewers"`
+	// CountedViews are the views the view counter accepted in the hour.
+	// Unlike Views they are deduplicated per viewer, so trending reads them.
+	// They are written when counted views are flushed, not with the rollup.
+	CountedViews int64 `gorm:"not null;default:0" json:"counted_views"`
+	// ViewersHLL is the Redis HyperLogLog of the hour's viewers, kept so
+	// that unique viewers can be counted over any range of hours.
+	ViewersHLL []byte `gorm:"type:bytea" json:"-"`
+	// Retention counts the sessions that reached each segment.
+	Retention []int64   `gorm:"type:jsonb;serializer:json" json:"retention"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	Video     *Video    `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// AnalyticsGranularity is the size of the buckets of an analytics query.
+type AnalyticsGranularity string
+
+const (
+	AnalyticsHourly AnalyticsGranularity = "HOUR"
+	AnalyticsDaily  AnalyticsGranularity = "DAY"
+)
+
+type AnalyticsBucket struct {
+	Start time.Time `json:"start"`
+	AnalyticsCounters
+	UniqueViewers int64 `json:"unique_viewers"`
+	// AvgWatchSeconds is the watch time per view.
+	AvgWatchSeconds float64 `json:"avg_watch_seconds"`
+}
+
+type VideoAnalytics struct {
+	VideoID     uuid.UUID            `json:"video_id"`
+	From        time.Time            `json:"from"`
+	To          time.Time            `json:"to"`
+	Granularity AnalyticsGranularity `json:"granularity"`
+	Totals      AnalyticsBucket      `json:"totals"`
+	Buckets     []AnalyticsBucket    `json:"buckets"`
+	// Retention is the share of sessions that reached each segment,
+	// relative to those that played the first one.
+	Retention []float64 `json:"retention"`
+}
+
+// AnalyticsStore aggregates the current hours in Redis as events arrive.
+type AnalyticsStore interface {
+	// Record adds events, received at the given time, to the rollups of
+	// their hour. viewer identifies who started playing.
+	Record(ctx context.Context, viewer string, events []PlaybackEvent, at time.Time) error
+	// AddBytesServed adds bytes streamed at the given time to the rollup of
+	// their hour.
+	AddBytesServed(ctx context.Context, videoID uuid.UUID, bytes int64, at time.Time) error
+	// TakeDirty removes and returns the rollups changed since the last call,
+	// with their running totals.
+	TakeDirty(ctx context.Context) ([]VideoAnalyticsHour, error)
+	// RestoreDirty marks rollups that could not be saved as changed again.
+	RestoreDirty(ctx context.Context, hours []VideoAnalyticsHour) error
+	// CountUnique merges HyperLogLogs and returns their cardinality.
+	CountUnique(ctx context.Context, hlls [][]byte) (int64, error)
+}
+
+type AnalyticsRepository interface {
+	// Save upserts rollups, replacing the stored totals.
+	Save(ctx context.Context, hours []VideoAnalyticsHour) error
+	// Find returns a video's rollups of the hours in [from, to), in order.
+	Find(ctx context.Context, videoID uuid.UUID, from, to time.Time) ([]VideoAnalyticsHour, error)
+}
+
+type AnalyticsService interface {
+	// Ingest records player events and returns how many were accepted.
+	// Events of crawlers are dropped.
+	Ingest(ctx context.Context, viewer Viewer, events []PlaybackEvent) (int, error)
+	// RecordBytesServed counts bytes streamed for a video. Failures are
+	// logged rather than returned, so they never interrupt playback.
+	RecordBytesServed(ctx context.Context, videoID string, bytes int64)
+	// VideoAnalytics lets the owner of a video read its rollups between
+	// from and to.
+	VideoAnalytics(ctx context.Context, userID uuid.UUID, videoID string, from, to time.Time, granularity AnalyticsGranularity) (*VideoAnalytics, error)
+	// Rollup saves the rollups changed since the last call.
+	Rollup(ctx context.Context) (int, error)
+	// RunRollups rolls up periodically until ctx is done, then once more.
+	RunRollups(ctx context.Context)
+}
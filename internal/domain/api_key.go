@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix marks a credential as a personal API key rather than a JWT.
+const APIKeyPrefix = "gsk_"
+
+// APIKeyScopes are the permissions that may be delegated to an API key.
+var APIKeyScopes = []Permission{PermVideosRead, PermVideosWrite, PermStream}
+
+// APIKey is a long-lived credential for automation. Only a SHA-256 hash of
+// the key is stored; the plaintext is shown once, when the key is created.
+type APIKey struct {
+	Model
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id" validate:"required"`
+	Name       string     `gorm:"not null" json:"name" validate:"required,min=1,max=100"`
+	Prefix     string     `gorm:"size:16;not null" json:"prefix"`
+	KeyHash    string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Scopes     string     `gorm:"not null" json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+}
+
+// ScopeList returns the key's scopes as permissions.
+func (k *APIKey) ScopeList() []Permission {
+	if k.Scopes == "" {
+		return nil
+	}
+	parts := strings.Split(k.Scopes, ",")
+	scopes := make([]Permission, len(parts))
+	for i, p := range parts {
+		scopes[i] = Permission(p)
+	}
+	return scopes
+}
+
+// Active reports whether the key can still be used at the given time.
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) (*APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*APIKey, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]APIKey, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, userID uuid.UUID, name string, scopes []Permission, expiresAt *time.Time) (*APIKey, string, error)
+	List(ctx context.Context, userID uuid.UUID) ([]APIKey, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+	Authenticate(ctx context.Context, rawKey string) (*APIKey, *User, error)
+}
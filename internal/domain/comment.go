@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxCommentLength = 5000
+	// MaxCommentMentions bounds how many @mentions of a comment are
+	// resolved.
+	MaxCommentMentions = 10
+)
+
+// CommentSortFields lists the orders of comment listings. Top orders by
+// engagement: likes plus replies.
+var CommentSortFields = []string{SortCreatedAt, SortTop}
+
+// Comment is a comment on a video, or a reply when ParentID is set. Replies
+// are one level deep: replying to a reply answers its top-level comment.
+//
+// Deleting a comment clears its body and sets DeletedAt; it is still listed
+// while it has replies, so the thread stays readable.
+type Comment struct {
+	Model
+	VideoID  uuid.UUID  `gorm:"type:uuid;not null;index:idx_comments_video_parent,priority:1" json:"video_id"`
+	UserID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ParentID *uuid.UUID `gorm:"type:uuid;index:idx_comments_video_parent,priority:2" json:"parent_id,omitempty"`
+	Body     string     `gorm:"not null" json:"body"`
+	// Edited is set once the body has been changed after posting.
+	Edited   bool       `gorm:"not null;default:false" json:"edited"`
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	Pinned   bool       `gorm:"not null;default:false" json:"pinned"`
+	// Hidden comments were hidden by the video's owner and are only shown
+	// to the owner and to their author.
+	Hidden bool `gorm:"not null;default:false" json:"hidden"`
+	// ReplyCount counts the visible replies.
+	ReplyCount int64 `gorm:"not null;default:0" json:"reply_count"`
+	// LikeCount and DislikeCount are kept up to date by the reaction
+	// repository.
+	LikeCount    int64            `gorm:"not null;default:0" json:"like_count"`
+	DislikeCount int64            `gorm:"not null;default:0" json:"dislike_count"`
+	Mentions     []CommentMention `gorm:"constraint:OnDelete:CASCADE;" json:"mentions,omitempty"`
+	Video        *Video           `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// Deleted reports whether the comment has been deleted.
+func (c *Comment) Deleted() bool {
+	return c.DeletedAt != nil
+}
+
+// CommentMention is an @username in a comment that resolved to a user.
+type CommentMention struct {
+	CommentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"comment_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Username  string    `gorm:"not null" json:"username"`
+}
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9_][A-Za-z0-9_.-]{2,49})`)
+
+// ParseMentions returns the distinct usernames mentioned in body, in order
+// of appearance. Trailing dots and dashes are taken as punctuation.
+func ParseMentions(body string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		name := strings.TrimRight(m[1], ".-")
+		if len(name) < 3 || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+type CommentFetchOptions struct {
+	BaseFetchOptions
+	VideoID uuid.UUID
+	// ParentID lists the replies of a comment; nil lists top-level comments.
+	ParentID *uuid.UUID
+	// Viewer sees their own hidden comments.
+	Viewer uuid.UUID
+	// ShowHidden includes every hidden comment, for the video's owner.
+	ShowHidden bool
+	// SkipPinned leaves the pinned comment out, as it is returned apart.
+	SkipPinned bool
+}
+
+type MultipleCommentResponse struct {
+	Comments []Comment `json:"comments"`
+	// Pinned is the video's pinned comment, set on the first page of
+	// top-level comments.
+	Pinned           *Comment `json:"pinned,omitempty"`
+	Total            int64    `json:"total"`
+	CommentsDisabled bool     `json:"comments_disabled"`
+	NextPageToken    string   `json:"next_page_token,omitempty"`
+}
+
+type CommentRepository interface {
+	// Create stores a comment with its mentions and counts it on its video
+	// and parent.
+	Create(ctx context.Context, comment *Comment) error
+	// FindByID returns a comment, deleted or not.
+	FindByID(ctx context.Context, id uuid.UUID) (*Comment, error)
+	UpdateBody(ctx context.Context, comment *Comment) error
+	Delete(ctx context.Context, comment *Comment, at time.Time) error
+	SetHidden(ctx context.Context, comment *Comment, hidden bool) error
+	// SetPinned pins a top-level comment, unpinning any other comment of
+	// the video, or unpins it.
+	SetPinned(ctx context.Context, comment *Comment, pinned bool) error
+	Pinned(ctx context.Context, videoID uuid.UUID) (*Comment, error)
+	Find(ctx context.Context, opts CommentFetchOptions) ([]Comment, error)
+	SetCommentsDisabled(ctx context.Context, videoID uuid.UUID, disabled bool) error
+}
+
+type CommentService interface {
+	// Create posts a comment, or a reply when parentID is not empty.
+	Create(ctx context.Context, userID uuid.UUID, videoID, parentID, body string) (*Comment, error)
+	List(ctx context.Context, viewer uuid.UUID, videoID string, opts CommentFetchOptions) (*MultipleCommentResponse, error)
+	Update(ctx context.Context, userID uuid.UUID, id, body string) (*Comment, error)
+	// Delete lets the author or the video's owner delete a comment.
+	Delete(ctx context.Context, userID uuid.UUID, id string) error
+	SetPinned(ctx context.Context, userID uuid.UUID, id string, pinned bool) (*Comment, error)
+	SetHidden(ctx context.Context, userID uuid.UUID, id string, hidden bool) (*Comment, error)
+	// SetCommentsDisabled stops or resumes new comments on a video. Existing
+	// comments stay visible.
+	SetCommentsDisabled(ctx context.Context, userID uuid.UUID, videoID string, disabled bool) error
+}
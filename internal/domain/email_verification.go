@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken is a pending email verification. Only a hash of the
+// token sent to the user is stored.
+type EmailVerificationToken struct {
+	TokenHash string    `gorm:"primaryKey;size:64"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Email     string    `gorm:"size:320;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+type EmailVerificationRepository interface {
+	Save(ctx context.Context, token *EmailVerificationToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*EmailVerificationToken, error)
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type EmailVerificationService interface {
+	// SendVerification emails a verification link to the user's address.
+	SendVerification(ctx context.Context, userID uuid.UUID) error
+	// Verify marks the email the token was issued for as verified.
+	Verify(ctx context.Context, token string) (*User, error)
+}
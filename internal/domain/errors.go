@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorKind classifies a domain error so transports can map it to their own
+// status codes without inspecting the message.
+type ErrorKind int
+
+const (
+	KindNotFound ErrorKind = iota + 1
+	KindPermissionDenied
+	KindInvalidArgument
+	KindConflict
+	KindFailedPrecondition
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindNotFound:
+		return "NOT_FOUND"
+	case KindPermissionDenied:
+		return "PERMISSION_DENIED"
+	case KindInvalidArgument:
+		return "INVALID_ARGUMENT"
+	case KindConflict:
+		return "CONFLICT"
+	case KindFailedPrecondition:
+		return "FAILED_PRECONDITION"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// FieldViolation describes one invalid field of a request.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is an error whose message is safe to return to clients. Anything that
+// is not an *Error is treated as internal and is never shown to callers.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+	// Resource and ID name the missing resource for KindNotFound.
+	Resource string
+	ID       string
+	// Reason is a short UPPER_SNAKE_CASE identifier clients can switch on.
+	Reason     string
+	Violations []FieldViolation
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// WithReason sets the machine readable reason of e and returns it.
+func (e *Error) WithReason(reason string) *Error {
+	e.Reason = reason
+	return e
+}
+
+// AsError returns the *Error in err's chain, if any.
+func AsError(err error) (*Error, bool) {
+	var de *Error
+	if errors.As(err, &de) {
+		return de, true
+	}
+	return nil, false
+}
+
+// IsKind reports whether err is a domain error of the given kind.
+func IsKind(err error, kind ErrorKind) bool {
+	de, ok := AsError(err)
+	return ok && de.Kind == kind
+}
+
+func NewNotFound(resource, id string) *Error {
+	return &Error{Kind: KindNotFound, Message: resource + " not found", Resource: resource, ID: id}
+}
+
+func NewPermissionDenied(format string, args ...any) *Error {
+	return &Error{Kind: KindPermissionDenied, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewInvalidArgument reports a bad request, optionally naming the offending
+// fields.
+func NewInvalidArgument(message string, violations ...FieldViolation) *Error {
+	return &Error{Kind: KindInvalidArgument, Message: message, Violations: violations}
+}
+
+// NewFieldError is shorthand for an invalid argument with a single field
+// violation.
+func NewFieldError(field, description string) *Error {
+	return NewInvalidArgument(field+": "+description, FieldViolation{Field: field, Description: description})
+}
+
+func NewConflict(format string, args ...any) *Error {
+	return &Error{Kind: KindConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewFailedPrecondition(format string, args ...any) *Error {
+	return &Error{Kind: KindFailedPrecondition, Message: fmt.Sprintf(format, args...)}
+}
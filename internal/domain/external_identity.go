@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalIdentity links a user to an account at an external OpenID Connect
+// provider. A provider and subject pair identifies exactly one user.
+type ExternalIdentity struct {
+	Model
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Provider string    `gorm:"size:64;not null;uniqueIndex:idx_external_identity_subject" json:"provider"`
+	Subject  string    `gorm:"size:255;not null;uniqueIndex:idx_external_identity_subject" json:"subject"`
+	Email    string    `gorm:"size:320" json:"email"`
+}
+
+// OIDCClaims are the verified ID token claims used to find or provision a user.
+type OIDCClaims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	GivenName         string
+	FamilyName        string
+}
+
+// OIDCProvider is a configured OpenID Connect identity provider.
+type OIDCProvider interface {
+	Name() string
+	// AuthCodeURL returns the provider's authorization endpoint URL for an
+	// authorization code request protected by PKCE with the given verifier.
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	// Exchange redeems an authorization code and returns the claims of the
+	// verified ID token, which must carry the expected nonce.
+	Exchange(ctx context.Context, code, verifier, nonce string) (*OIDCClaims, error)
+}
+
+// OIDCLoginState is kept server-side between starting and completing a login.
+type OIDCLoginState struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	// BindingHash is the hash of the secret handed to the client that
+	// started the login, so that only it can complete the login.
+	BindingHash string `json:"binding_hash"`
+}
+
+type OIDCStateRepository interface {
+	Save(ctx context.Context, state string, login OIDCLoginState, ttl time.Duration) error
+	// Take returns and deletes the login state, or nil if it is unknown or expired.
+	Take(ctx context.Context, state string) (*OIDCLoginState, error)
+}
+
+type ExternalIdentityRepository interface {
+	Create(ctx context.Context, identity *ExternalIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*ExternalIdentity, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]ExternalIdentity, error)
+}
+
+type OIDCService interface {
+	Providers() []string
+	// StartLogin returns the URL to send the user's browser to, and the
+	// binding secret the same client must present to complete the login.
+	StartLogin(ctx context.Context, provider string) (authURL, binding string, err error)
+	// CompleteLogin finishes the flow for the callback's state and code,
+	// provisioning a user on their first login. binding must be the secret
+	// StartLogin returned for the state.
+	CompleteLogin(ctx context.Context, provider, state, code, binding string) (*User, error)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidCredentials is returned for every failed password login, whether
+// or not the username exists, so the response cannot be used to enumerate
+// accounts.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrInvalidToken is returned for an access token, session or API key that
+// cannot be used. The reason is left out so that it stays the same whether
+// the token is malformed, revoked or belongs to a disabled user.
+var ErrInvalidToken = errors.New("invalid credentials")
+
+// AccountLockedError is returned while a username is locked out after too
+// many consecutive failed logins.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again after %s", e.Until.UTC().Format(time.RFC3339))
+}
+
+// TooManyAttemptsError is returned when the login rate limit for a client IP
+// or username has been exceeded.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many login attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+type LoginAttemptRepository interface {
+	// CountAttempt adds an attempt to the sliding window stored under key and
+	// returns the number of attempts in the window, including this one, along
+	// with the time of the oldest of them.
+	CountAttempt(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, error)
+	// RecordFailure increments the consecutive failed-login count for username.
+	RecordFailure(ctx context.Context, username string, ttl time.Duration) (int64, error)
+	ClearFailures(ctx context.Context, username string) error
+	Lock(ctx context.Context, username string, until time.Time) error
+	// LockedUntil returns the zero time when username is not locked.
+	LockedUntil(ctx context.Context, username string) (time.Time, error)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode is a hashed, single-use code that can stand in for a TOTP code
+// when the authenticator device is lost.
+type RecoveryCode struct {
+	Model
+	UserID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	UsedAt   *time.Time `json:"used_at,omitempty"`
+}
+
+// TOTPEnrollment is returned when a user starts setting up an authenticator app.
+type TOTPEnrollment struct {
+	Secret    string
+	URI       string
+	QRCodePNG []byte
+}
+
+type MFARepository interface {
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string, at time.Time) (bool, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+	// AdvanceTOTPStep records step as the user's last accepted TOTP step and
+	// reports false if an equal or later step was already used.
+	AdvanceTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+}
+
+// MFAChallengeRepository tracks MFA challenge tokens by their ID, so each one
+// takes only a few guesses and signs in at most once.
+type MFAChallengeRepository interface {
+	// CountAttempt adds a guess against the challenge and returns how many
+	// were made, including this one.
+	CountAttempt(ctx context.Context, id string, ttl time.Duration) (int64, error)
+	// Use marks the challenge redeemed and reports false if it already was.
+	Use(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Release undoes Use after a wrong code, so the challenge can be retried.
+	Release(ctx context.Context, id string) error
+}
+
+type MFAService interface {
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	// VerifyCode checks a TOTP or recovery code for a user with 2FA enabled.
+	VerifyCode(ctx context.Context, userID uuid.UUID, code string) (*User, error)
+	// VerifyChallenge completes a password login with a code. The challenge
+	// is the ID of the MFA challenge token: the first correct code redeems
+	// it, and it stops accepting codes after a few wrong ones. Wrong codes
+	// also count towards the login lockout.
+	VerifyChallenge(ctx context.Context, userID uuid.UUID, challengeID, code string) (*User, error)
+}
@@ -8,8 +8,15 @@ type BaseFetchOptions struct {
 	Limit int
 	// Offset for results. When Page is set, Offset is calculated as (Page-1)*Limit.
 	Offset int
-	// Sort expression, e.g. "created_at desc" or "username asc".
-	Sort string
+	// Sort is a whitelisted sort field and direction; see ParseSort.
+	Sort Sort
+	// PageToken is the next_page_token of a previous page. Services decode it
+	// into After, which takes precedence over Page and Offset.
+	PageToken string
+	After     *Cursor
+	// SkipTotal skips counting all matches, which is the slowest part of a
+	// listing; Total is then -1.
+	SkipTotal bool
 	// Query is a free-text search/filter string.
 	Query string
 }
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Sort fields accepted by list APIs.
+const (
+	SortCreatedAt   = "created_at"
+	SortViews       = "views"
+	SortTitle       = "title"
+	SortRelevance   = "relevance"
+	SortUsername    = "username"
+	SortUpdatedAt   = "updated_at"
+	SortTop         = "top"
+	SortLikedAt     = "liked_at"
+	SortPublishedAt = "published_at"
+	SortWatchedAt   = "watched_at"
+)
+
+var (
+	VideoSortFields = []string{SortCreatedAt, SortViews, SortTitle, SortRelevance}
+	UserSortFields  = []string{SortCreatedAt, SortUsername}
+	// PlaylistSortFields default to updated_at, so that recently changed
+	// playlists come first.
+	PlaylistSortFields = []string{SortUpdatedAt, SortCreatedAt, SortTitle}
+)
+
+// Sort orders a listing by one whitelisted field. Ties are broken by ID in the
+// same direction, which keeps keyset pagination stable.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return s.Field + " desc"
+	}
+	return s.Field + " asc"
+}
+
+// defaultDesc reports whether a field sorts descending when no direction is
+// given: newest, most viewed and most relevant first, names alphabetically.
+func defaultDesc(field string) bool {
+	return field != SortTitle && field != SortUsername
+}
+
+// ParseSort parses an order_by value such as "views", "title desc" or
+// "created_at asc". Fields outside allowed are rejected, so the result is safe
+// to map onto columns. An empty spec returns the zero Sort.
+func ParseSort(spec string, allowed []string) (Sort, error) {
+	parts := strings.Fields(strings.ToLower(spec))
+	if len(parts) == 0 {
+		return Sort{}, nil
+	}
+	if len(parts) > 2 || !slices.Contains(allowed, parts[0]) {
+		return Sort{}, NewFieldError("order_by", "must be one of "+strings.Join(allowed, ", ")+", optionally followed by asc or desc")
+	}
+	s := Sort{Field: parts[0], Desc: defaultDesc(parts[0])}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+			s.Desc = false
+		case "desc":
+			s.Desc = true
+		default:
+			return Sort{}, NewFieldError("order_by", "direction must be asc or desc")
+		}
+	}
+	return s, nil
+}
+
+// Cursor marks the last row of a page. Value holds that row's sort key in
+// its text form.
+type Cursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+var errPageToken = NewFieldError("page_token", "is invalid")
+
+// Encode returns the cursor as an opaque page token.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a page token for a listing sorted by sort. Tokens from
+// a listing with a different order are rejected. An empty token returns nil.
+func DecodeCursor(token string, sort Sort) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errPageToken
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == uuid.Nil {
+		return nil, errPageToken
+	}
+	if c.Sort != sort.String() {
+		return nil, NewFieldError("page_token", "was issued for a different order_by")
+	}
+	return &c, nil
+}
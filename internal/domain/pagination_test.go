@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	sort := Sort{Field: SortCreatedAt, Desc: true}
+	want := Cursor{Sort: sort.String(), Value: "2026-01-01T12:00:00.123456789Z", ID: uuid.New()}
+
+	got, err := DecodeCursor(want.Encode(), sort)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if *got != want {
+		t.Errorf("DecodeCursor = %+v, want %+v", *got, want)
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	sort := Sort{Field: SortViews, Desc: true}
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "%%%"},
+		{name: "padded base64", token: base64.URLEncoding.EncodeToString([]byte(`{"s":"views desc","v":"1","id":"` + uuid.NewString() + `"}`))},
+		{name: "not json", token: encode("views desc")},
+		{name: "missing id", token: encode(`{"s":"views desc","v":"1"}`)},
+		{name: "other direction", token: Cursor{Sort: "views asc", Value: "1", ID: uuid.New()}.Encode()},
+		{name: "other field", token: Cursor{Sort: "created_at desc", Value: "1", ID: uuid.New()}.Encode()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := DecodeCursor(tt.token, sort); err == nil {
+				t.Errorf("DecodeCursor = %+v, want an error", c)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorEmpty(t *testing.T) {
+	c, err := DecodeCursor("", Sort{Field: SortViews})
+	if c != nil || err != nil {
+		t.Errorf("DecodeCursor(\"\") = %v, %v, want nil, nil", c, err)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Sort
+		wantErr bool
+	}{
+		{spec: "", want: Sort{}},
+		{spec: "views", want: Sort{Field: SortViews, Desc: true}},
+		{spec: "title", want: Sort{Field: SortTitle}},
+		{spec: "Title DESC", want: Sort{Field: SortTitle, Desc: true}},
+		{spec: "created_at asc", want: Sort{Field: SortCreatedAt}},
+		{spec: "username", wantErr: true},
+		{spec: "views sideways", wantErr: true},
+		{spec: "views desc extra", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSort(tt.spec, VideoSortFields)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSort(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSort(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PlaylistPublic playlists are listed on their owner's profile.
+	PlaylistPublic PlaylistVisibility = "PUBLIC"
+	// PlaylistUnlisted playlists can be opened by anyone with the ID but are
+	// not listed.
+	PlaylistUnlisted PlaylistVisibility = "UNLISTED"
+	// PlaylistPrivate playlists are only visible to their owner.
+	PlaylistPrivate PlaylistVisibility = "PRIVATE"
+)
+
+type PlaylistVisibility string
+
+func (v PlaylistVisibility) Valid() bool {
+	return v == PlaylistPublic || v == PlaylistUnlisted || v == PlaylistPrivate
+}
+
+// MaxPlaylistItems bounds the size of a playlist.
+const MaxPlaylistItems = 500
+
+type Playlist struct {
+	Model
+	UserID      uuid.UUID          `gorm:"type:uuid;not null;index" json:"user_id" validate:"required"`
+	Title       string             `gorm:"size:150;not null" json:"title" validate:"required,min=1,max=150"`
+	Description string             `json:"description" validate:"omitempty,max=2000"`
+	Visibility  PlaylistVisibility `gorm:"size:16;not null;default:'PRIVATE'" json:"visibility" validate:"required,oneof=PUBLIC UNLISTED PRIVATE"`
+	// ItemCount counts every item, including ones hidden from the viewer. It
+	// is computed when playlists are loaded.
+	ItemCount int64          `gorm:"column:item_count;->;-:migration" json:"item_count"`
+	Items     []PlaylistItem `gorm:"constraint:OnDelete:CASCADE;" json:"items,omitempty"`
+}
+
+// VisibleTo reports whether viewer, uuid.Nil for anonymous callers, may open
+// the playlist.
+func (p *Playlist) VisibleTo(viewer uuid.UUID) bool {
+	return p.Visibility != PlaylistPrivate || p.UserID == viewer
+}
+
+// PlaylistItem places a video at a position of a playlist. Positions are
+// contiguous from 0. Items are removed along with their video.
+type PlaylistItem struct {
+	Model
+	PlaylistID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_playlist_items_video;index:idx_playlist_items_position,priority:1" json:"playlist_id"`
+	VideoID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_playlist_items_video" json:"video_id"`
+	Position   int       `gorm:"not null;index:idx_playlist_items_position,priority:2" json:"position"`
+	Video      *Video    `gorm:"constraint:OnDelete:CASCADE;" json:"video,omitempty"`
+}
+
+type PlaylistFetchOptions struct {
+	BaseFetchOptions
+	UserID uuid.UUID
+	// Visibilities restricts the listing; empty means any.
+	Visibilities []PlaylistVisibility
+}
+
+type MultiplePlaylistResponse struct {
+	Playlists     []Playlist `json:"playlists"`
+	Total         int64      `json:"total"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
+}
+
+// PlaylistUpdate holds the fields to change; nil fields are left alone.
+type PlaylistUpdate struct {
+	Title       *string
+	Description *string
+	Visibility  *PlaylistVisibility
+}
+
+type PlaylistRepository interface {
+	Create(ctx context.Context, playlist *Playlist) (*Playlist, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Playlist, error)
+	Update(ctx context.Context, playlist *Playlist) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Find(ctx context.Context, opts PlaylistFetchOptions) ([]Playlist, int64, error)
+	// Items returns the items of a playlist in order with their videos,
+	// leaving out videos that are not ready unless viewer uploaded them.
+	Items(ctx context.Context, playlistID, viewer uuid.UUID) ([]PlaylistItem, error)
+	// AddItem inserts item at item.Position, or appends it when the
+	// position is negative or past the end.
+	AddItem(ctx context.Context, item *PlaylistItem) error
+	RemoveItem(ctx context.Context, playlistID, itemID uuid.UUID) error
+	MoveItem(ctx context.Context, playlistID, itemID uuid.UUID, position int) error
+}
+
+type PlaylistService interface {
+	Create(ctx context.Context, userID uuid.UUID, playlist *Playlist) (*Playlist, error)
+	// Get returns a playlist with the items viewer may see.
+	Get(ctx context.Context, viewer uuid.UUID, id string) (*Playlist, error)
+	Update(ctx context.Context, userID uuid.UUID, id string, update PlaylistUpdate) (*Playlist, error)
+	Delete(ctx context.Context, userID uuid.UUID, id string) error
+	// List returns the playlists of opts.UserID; other viewers only see the
+	// public ones.
+	List(ctx context.Context, viewer uuid.UUID, opts PlaylistFetchOptions) (*MultiplePlaylistResponse, error)
+	AddItem(ctx context.Context, userID uuid.UUID, playlistID, videoID string, position int) (*PlaylistItem, error)
+	RemoveItem(ctx context.Context, userID uuid.UUID, playlistID, itemID string) error
+	MoveItem(ctx context.Context, userID uuid.UUID, playlistID, itemID string, position int) (*Playlist, error)
+	// PlayAll returns a playlist with only its ready videos, in order.
+	PlayAll(ctx context.Context, viewer uuid.UUID, id string) (*Playlist, error)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ranking names a precomputed ordering of ready videos.
+type Ranking string
+
+const (
+	// RankingTrending favours videos gaining views and likes right now.
+	RankingTrending Ranking = "trending"
+	// RankingPopular favours videos with the most views and likes overall,
+	// slowly letting older ones go.
+	RankingPopular Ranking = "popular"
+)
+
+// RankedVideo is a video's place in a ranking.
+type RankedVideo struct {
+	VideoID  uuid.UUID
+	Category Category
+	Score    float64
+}
+
+type RankingFetchOptions struct {
+	BaseFetchOptions
+	// Category limits the ranking to one category.
+	Category Category
+}
+
+// RankingWeights tune the scores of the rankings. Half-lives are in
+// seconds.
+type RankingWeights struct {
+	Like    float64
+	Dislike float64
+	// ActivityHalfLife decays the views and reactions of the trending
+	// window by how long ago they happened.
+	ActivityHalfLife float64
+	// AgeHalfLife decays a video's score by its age.
+	AgeHalfLife float64
+}
+
+// RankingRepository computes scores from the database.
+type RankingRepository interface {
+	// TrendingScores scores the videos with views or reactions since the
+	// start of the window.
+	TrendingScores(ctx context.Context, windowHours int, weights RankingWeights, size int) ([]RankedVideo, error)
+	// PopularScores scores every ready video with views or likes.
+	PopularScores(ctx context.Context, weights RankingWeights, size int) ([]RankedVideo, error)
+}
+
+// RankingStore keeps the rankings in Redis sorted sets, overall and by
+// category.
+type RankingStore interface {
+	// Replace swaps a ranking for new scores in one step.
+	Replace(ctx context.Context, ranking Ranking, videos []RankedVideo) error
+	// Range returns up to limit videos of a ranking, best first, following
+	// after when set.
+	Range(ctx context.Context, ranking Ranking, category Category, after *Cursor, limit int) ([]RankedVideo, error)
+	Count(ctx context.Context, ranking Ranking, category Category) (int64, error)
+	// Lock claims the next refresh of a ranking for ttl, so that one
+	// instance computes it.
+	Lock(ctx context.Context, ranking Ranking, ttl time.Duration) (bool, error)
+}
+
+type RankingService interface {
+	// List returns a page of a ranking, leaving out videos that stopped
+	// being ready since it was computed.
+	List(ctx context.Context, ranking Ranking, opts RankingFetchOptions) (*MultipleVideoResponse, error)
+	// Refresh recomputes a ranking.
+	Refresh(ctx context.Context, ranking Ranking) (int, error)
+	// RunRefresher refreshes the rankings periodically until ctx is done.
+	RunRefresher(ctx context.Context)
+}
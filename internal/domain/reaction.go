@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReactionLike    ReactionKind = "LIKE"
+	ReactionDislike ReactionKind = "DISLIKE"
+)
+
+type ReactionKind string
+
+func (k ReactionKind) Valid() bool {
+	return k == ReactionLike || k == ReactionDislike
+}
+
+const (
+	ReactionTargetVideo   ReactionTarget = "video"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// ReactionTarget is the kind of thing a reaction is about.
+type ReactionTarget string
+
+// Reaction is a user's like or dislike of a video or comment; a user has at
+// most one per target. The like and dislike counters of the target are
+// updated in the same transaction as its reactions.
+type Reaction struct {
+	UserID     uuid.UUID      `gorm:"type:uuid;primaryKey" json:"user_id"`
+	TargetType ReactionTarget `gorm:"size:16;primaryKey;index:idx_reactions_target,priority:1" json:"target_type"`
+	TargetID   uuid.UUID      `gorm:"type:uuid;primaryKey;index:idx_reactions_target,priority:2" json:"target_id"`
+	Kind       ReactionKind   `gorm:"size:16;not null" json:"kind"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ReactionCounts are the counters of a target after a change, along with
+// the caller's reaction, "" when none.
+type ReactionCounts struct {
+	Likes    int64        `json:"likes"`
+	Dislikes int64        `json:"dislikes"`
+	Reaction ReactionKind `json:"reaction"`
+}
+
+type ReactionRepository interface {
+	// Set stores the reaction, replacing the user's previous one, and
+	// returns the target's counters.
+	Set(ctx context.Context, reaction *Reaction) (*ReactionCounts, error)
+	// Clear removes the user's reaction, if any, and returns the target's
+	// counters.
+	Clear(ctx context.Context, userID uuid.UUID, target ReactionTarget, targetID uuid.UUID) (*ReactionCounts, error)
+	// LikedVideos lists the videos the user likes, most recently liked first,
+	// leaving out videos that are not ready unless the user uploaded them.
+	LikedVideos(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) ([]Video, error)
+}
+
+type ReactionService interface {
+	// React sets the caller's reaction to a target, or clears it when kind is
+	// empty. Repeating a call changes nothing.
+	React(ctx context.Context, userID uuid.UUID, target ReactionTarget, targetID string, kind ReactionKind) (*ReactionCounts, error)
+	ListLikedVideos(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) (*MultipleVideoResponse, error)
+}
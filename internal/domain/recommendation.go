@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MaxRecommendations bounds one list of recommendations.
+const MaxRecommendations = 50
+
+// Candidate is a video a source proposes, with a score that only compares
+// to the other candidates of the same source.
+type Candidate struct {
+	VideoID uuid.UUID
+	Score   float64
+}
+
+// RecommendationSeed is what recommendations are made from: the video being
+// watched, or the viewer's recent history. Both may be empty.
+type RecommendationSeed struct {
+	Videos []Video
+	// Viewer is uuid.Nil for anonymous callers.
+	Viewer uuid.UUID
+}
+
+func (s RecommendationSeed) VideoIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, len(s.Videos))
+	for i, v := range s.Videos {
+		ids[i] = v.ID
+	}
+	return ids
+}
+
+// CandidateSource proposes ready videos for a seed, best first. Sources are
+// blended by weight, so a new signal is added by writing a source rather
+// than changing the recommender.
+type CandidateSource interface {
+	// Name tells in responses which sources proposed a video.
+	Name() string
+	Candidates(ctx context.Context, seed RecommendationSeed, limit int) ([]Candidate, error)
+}
+
+type WeightedSource struct {
+	Source CandidateSource
+	Weight float64
+}
+
+// Recommendation is a recommended video with its blended score and the
+// sources that proposed it.
+type Recommendation struct {
+	Video   Video
+	Score   float64
+	Sources []string
+}
+
+type RecommendationService interface {
+	// Related recommends videos to watch after videoID.
+	Related(ctx context.Context, viewer uuid.UUID, videoID string, limit int) ([]Recommendation, error)
+	// ForUser recommends videos from the user's recent history, or trending
+	// videos before they have one.
+	ForUser(ctx context.Context, userID uuid.UUID, limit int) ([]Recommendation, error)
+}
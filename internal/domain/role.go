@@ -0,0 +1,45 @@
+package domain
+
+const (
+	RoleViewer    Role = "viewer"
+	RoleCreator   Role = "creator"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// Role is the coarse access level of a user. Each role grants a fixed set of
+// permissions, see rolePermissions.
+type Role string
+
+const (
+	PermVideosRead     Permission = "videos:read"
+	PermVideosWrite    Permission = "videos:write"
+	PermStream         Permission = "stream"
+	PermVideosModerate Permission = "videos:moderate"
+	PermUsersManage    Permission = "users:manage"
+)
+
+// Permission names a single capability checked before an RPC is served.
+type Permission string
+
+var rolePermissions = map[Role][]Permission{
+	RoleViewer:    {PermVideosRead, PermStream},
+	RoleCreator:   {PermVideosRead, PermStream, PermVideosWrite},
+	RoleModerator: {PermVideosRead, PermStream, PermVideosWrite, PermVideosModerate},
+	RoleAdmin:     {PermVideosRead, PermStream, PermVideosWrite, PermVideosModerate, PermUsersManage},
+}
+
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can reports whether the role grants the permission.
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
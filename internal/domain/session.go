@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one signed-in device. Tokens carry the session ID, so revoking
+// the session invalidates them.
+type Session struct {
+	Model
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	DeviceLabel string     `gorm:"size:100" json:"device_label"`
+	IPAddress   string     `gorm:"size:45" json:"ip_address"`
+	UserAgent   string     `gorm:"size:512" json:"user_agent"`
+	LastSeenAt  time.Time  `gorm:"not null" json:"last_seen_at"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt   *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the session can still be used at the given time.
+func (s *Session) Active(at time.Time) bool {
+	return s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// SessionInfo describes the client a session is started for.
+type SessionInfo struct {
+	DeviceLabel string
+	IPAddress   string
+	UserAgent   string
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	// ListActive returns the user's unrevoked, unexpired sessions, most
+	// recently used first.
+	ListActive(ctx context.Context, userID uuid.UUID, at time.Time) ([]Session, error)
+	Revoke(ctx context.Context, userID, id uuid.UUID, at time.Time) (bool, error)
+	// RevokeAllExcept revokes every active session of the user but keepID and
+	// returns how many were revoked.
+	RevokeAllExcept(ctx context.Context, userID, keepID uuid.UUID, at time.Time) (int64, error)
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type SessionService interface {
+	Start(ctx context.Context, userID uuid.UUID, info SessionInfo, ttl time.Duration) (*Session, error)
+	// Validate returns an error unless the session exists, belongs to the
+	// user and is active. It also records the session as recently seen.
+	Validate(ctx context.Context, userID, sessionID uuid.UUID) error
+	List(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeAllOthers(ctx context.Context, userID, currentID uuid.UUID) (int64, error)
+}
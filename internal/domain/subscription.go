@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedBackfill is how many of a channel's latest videos are added to the
+// feed of a new subscriber.
+const FeedBackfill = 20
+
+// Subscription makes a user follow a channel, which is another user. New
+// videos of the channel are delivered to the subscriber's feed.
+type Subscription struct {
+	SubscriberID uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_subscriptions_channel,priority:3" json:"subscriber_id"`
+	ChannelID    uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_subscriptions_channel,priority:1" json:"channel_id"`
+	CreatedAt    time.Time `gorm:"autoCreateTime;index:idx_subscriptions_channel,priority:2" json:"created_at"`
+	Subscriber   *User     `gorm:"foreignKey:SubscriberID;constraint:OnDelete:CASCADE;" json:"subscriber,omitempty"`
+	Channel      *User     `gorm:"foreignKey:ChannelID;constraint:OnDelete:CASCADE;" json:"channel,omitempty"`
+}
+
+// FeedEntry puts a video in a subscriber's feed. Entries are written when the
+// video becomes ready (fan-out on publish), so reading a feed never has to
+// look at the subscriptions.
+type FeedEntry struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_feed_entries_user_published,priority:1" json:"user_id"`
+	VideoID     uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_feed_entries_user_published,priority:3" json:"video_id"`
+	ChannelID   uuid.UUID `gorm:"type:uuid;not null" json:"channel_id"`
+	PublishedAt time.Time `gorm:"not null;index:idx_feed_entries_user_published,priority:2" json:"published_at"`
+	User        *User     `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Video       *Video    `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// SubscriptionFetchOptions lists the channels of SubscriberID, or the
+// subscribers of ChannelID, newest first.
+type SubscriptionFetchOptions struct {
+	BaseFetchOptions
+	SubscriberID uuid.UUID
+	ChannelID    uuid.UUID
+}
+
+type MultipleSubscriptionResponse struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+	// Total is -1 when counting was skipped.
+	Total         int64  `json:"total"`
+	NextPageToken string `json:"next_page_token"`
+}
+
+type SubscriptionRepository interface {
+	// Subscribe stores the subscription and adds the channel's latest ready
+	// videos to the subscriber's feed. Subscribing twice changes nothing.
+	Subscribe(ctx context.Context, subscription *Subscription) error
+	// Unsubscribe removes the subscription along with the channel's videos
+	// in the subscriber's feed.
+	Unsubscribe(ctx context.Context, subscriberID, channelID uuid.UUID) error
+	Find(ctx context.Context, opts SubscriptionFetchOptions) ([]Subscription, int64, error)
+	// FanOut adds a video to the feed of every subscriber of its channel and
+	// returns how many entries were added.
+	FanOut(ctx context.Context, video *Video, at time.Time) (int64, error)
+	// Feed lists the ready videos in a user's feed, newest first.
+	Feed(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) ([]Video, error)
+}
+
+type SubscriptionService interface {
+	Subscribe(ctx context.Context, userID uuid.UUID, channelID string) (*Subscription, error)
+	Unsubscribe(ctx context.Context, userID uuid.UUID, channelID string) error
+	ListSubscriptions(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) (*MultipleSubscriptionResponse, error)
+	ListSubscribers(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) (*MultipleSubscriptionResponse, error)
+	Feed(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) (*MultipleVideoResponse, error)
+	// FanOut delivers a video that just became ready to the feeds of its
+	// channel's subscribers. The worker calls it after transcoding.
+	FanOut(ctx context.Context, videoID string) (int64, error)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Category is one entry of the fixed video taxonomy. The empty category
+// means uncategorised.
+type Category string
+
+const (
+	CategoryMusic         Category = "music"
+	CategoryGaming        Category = "gaming"
+	CategoryEducation     Category = "education"
+	CategoryScienceTech   Category = "science-tech"
+	CategorySports        Category = "sports"
+	CategoryNews          Category = "news"
+	CategoryEntertainment Category = "entertainment"
+	CategoryComedy        Category = "comedy"
+	CategoryFilm          Category = "film"
+	CategoryHowTo         Category = "howto"
+	CategoryTravel        Category = "travel"
+	CategoryPeople        Category = "people"
+)
+
+var Categories = []Category{
+	CategoryMusic, CategoryGaming, CategoryEducation, CategoryScienceTech,
+	CategorySports, CategoryNews, CategoryEntertainment, CategoryComedy,
+	CategoryFilm, CategoryHowTo, CategoryTravel, CategoryPeople,
+}
+
+func (c Category) Valid() bool {
+	return slices.Contains(Categories, c)
+}
+
+const (
+	MaxTagsPerVideo = 10
+	MaxTagLength    = 50
+)
+
+// Tag is a free-form label shared by videos. Slug is the normalised form used
+// for matching; Name keeps the spelling of whoever used the tag first.
+type Tag struct {
+	Model
+	Slug string `gorm:"column:slug;size:50;uniqueIndex;not null" json:"slug"`
+	Name string `gorm:"column:name;size:50;not null" json:"name"`
+}
+
+// TagSlug normalises a tag name: lower case, with runs of anything other
+// than letters and digits turned into single dashes. It returns "" for names
+// without letters or digits.
+func TagSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// TagCount is a tag together with the number of videos using it.
+type TagCount struct {
+	Slug   string `json:"slug"`
+	Name   string `json:"name"`
+	Videos int64  `json:"videos"`
+}
+
+// FacetCount is the number of matching videos for one facet value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// VideoFacets breaks the matches of a listing down by category and tag. The
+// category counts ignore the category filter, so that other categories can
+// still be offered; tag counts apply every filter.
+type VideoFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Tags       []FacetCount `json:"tags"`
+}
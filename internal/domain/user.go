@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 )
 
@@ -10,31 +12,80 @@ type User struct {
 	Password  string `gorm:"column:password;not null" json:"password" validate:"required,min=6"`
 	FirstName string `gorm:"column:first_name" json:"first_name" validate:"omitempty,max=100"`
 	LastName  string `gorm:"column:last_name" json:"last_name" validate:"omitempty,max=100"`
+	// Email is optional and stored lower-cased; EmailVerified is set once the
+	// user follows the link sent to it.
+	Email         string `gorm:"column:email;size:320;index:idx_users_email,unique,where:email <> ''" json:"email" validate:"omitempty,email,max=320"`
+	EmailVerified bool   `gorm:"column:email_verified;not null;default:false" json:"email_verified"`
+	AvatarURL     string `gorm:"column:avatar_url;size:2048" json:"avatar_url" validate:"omitempty,url,max=2048"`
+	Bio           string `gorm:"column:bio;size:500" json:"bio" validate:"omitempty,max=500"`
+	Role          Role   `gorm:"column:role;not null;default:'creator'" json:"role" validate:"omitempty,oneof=viewer creator moderator admin"`
+	Disabled      bool   `gorm:"column:disabled;not null;default:false" json:"disabled"`
+	// TOTPSecret is set on enrollment; TOTPEnabled once a code has confirmed it.
+	TOTPSecret  string `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabled bool   `gorm:"column:totp_enabled;not null;default:false" json:"totp_enabled"`
+	// TOTPLastStep is the last accepted time step, so a code cannot be replayed.
+	TOTPLastStep int64 `gorm:"column:totp_last_step;not null;default:0" json:"-"`
+}
+
+// ProfileUpdate holds the profile fields a user can change themselves. Nil
+// fields are left unchanged.
+type ProfileUpdate struct {
+	FirstName *string
+	LastName  *string
+	AvatarURL *string
+	Bio       *string
+	Email     *string
 }
 
 type UserFetchOptions struct {
 	BaseFetchOptions
 	// Additional user-specific filters can go here (e.g. Username)
 	Username string
+	Role     Role
+}
+
+type MultipleUserResponse struct {
+	Users []User `json:"users"`
+	// Total is -1 when counting was skipped.
+	Total         int64  `json:"total"`
+	NextPageToken string `json:"next_page_token"`
 }
 
 type UserRepository interface {
-	Create(user *User) (*User, error)
-	Delete(id uuid.UUID) error
-	Update(user *User) error
-	GetByID(id uuid.UUID) (*User, error)
-	GetByUsername(username string) (*User, error)
-	GetAll(opts UserFetchOptions) ([]User, int64, error)
+	Create(ctx context.Context, user *User) (*User, error)
+	// Delete removes the user and everything they own in one transaction:
+	// sign-in data, videos, comments, reactions, playlists, subscriptions
+	// and history. It returns the deleted videos, whose files are still in
+	// storage.
+	Delete(ctx context.Context, id uuid.UUID) ([]Video, error)
+	Update(ctx context.Context, user *User) error
+	// UpdateFields sets the given columns of one user and leaves the rest
+	// alone.
+	UpdateFields(ctx context.Context, id uuid.UUID, fields map[string]any) error
+	// MarkEmailVerified verifies the user's email if it is still email and
+	// reports whether it was.
+	MarkEmailVerified(ctx context.Context, id uuid.UUID, email string) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	// GetByUsernames returns the users with any of the usernames; unknown
+	// names are skipped.
+	GetByUsernames(ctx context.Context, usernames []string) ([]User, error)
+	GetAll(ctx context.Context, opts UserFetchOptions) ([]User, int64, error)
 }
 
 type UserService interface {
-	CreateUser(user *User) (*User, error)
-	UpdateUser(user *User) error
-	DeleteUser(id uuid.UUID) error
-	GetUserByID(id uuid.UUID) (*User, error)
-	ListUsers(opts UserFetchOptions) ([]User, int64, error)
-	GetByUsername(username string) (*User, error)
-	Login(username, password string) (*User, error)
-	ResetPassword(username, newPassword string) error
-	ChangePassword(username, oldPassword, newPassword string) error
+	CreateUser(ctx context.Context, user *User) (*User, error)
+	UpdateUser(ctx context.Context, user *User) error
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
+	ListUsers(ctx context.Context, opts UserFetchOptions) (*MultipleUserResponse, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+	Login(ctx context.Context, username, password string) (*User, error)
+	ResetPassword(ctx context.Context, username, newPassword string) error
+	ChangePassword(ctx context.Context, username, oldPassword, newPassword string) error
+	SetRole(ctx context.Context, id uuid.UUID, role Role) (*User, error)
+	SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) (*User, error)
+	// UpdateProfile applies a profile update. Changing the email clears
+	// EmailVerified.
+	UpdateProfile(ctx context.Context, id uuid.UUID, update ProfileUpdate) (*User, error)
 }
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -26,6 +28,25 @@ type Video struct {
 	Status       VideoStatus `gorm:"default:'PENDING'" json:"status" validate:"omitempty,oneof=PENDING PROCESSING READY FAILED"`
 	UserID       uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id" validate:"required"`
 	Views        int64       `gorm:"default:0" json:"views"`
+	Category     Category    `gorm:"size:32;index" json:"category" validate:"omitempty,max=32"`
+	Tags         []Tag       `gorm:"many2many:video_tags;" json:"tags"`
+	// CommentCount counts visible comments and replies. It is kept up to
+	// date by the comment repository.
+	CommentCount     int64 `gorm:"not null;default:0" json:"comment_count"`
+	CommentsDisabled bool  `gorm:"not null;default:false" json:"comments_disabled"`
+	// LikeCount and DislikeCount are kept up to date by the reaction
+	// repository.
+	LikeCount    int64 `gorm:"not null;default:0" json:"like_count"`
+	DislikeCount int64 `gorm:"not null;default:0" json:"dislike_count"`
+	// SearchRank and the highlights are computed by full-text searches and
+	// are never stored.
+	SearchRank           float64 `gorm:"column:search_rank;->;-:migration" json:"-"`
+	TitleHighlight       string  `gorm:"column:title_highlight;->;-:migration" json:"-"`
+	DescriptionHighlight string  `gorm:"column:description_highlight;->;-:migration" json:"-"`
+	// LikedAt is set when listing the videos a user likes.
+	LikedAt *time.Time `gorm:"column:liked_at;->;-:migration" json:"-"`
+	// PublishedAt is set when reading a subscription feed.
+	PublishedAt *time.Time `gorm:"column:published_at;->;-:migration" json:"-"`
 }
 
 func (v *Video) BeforeCreate(tx *gorm.DB) error {
@@ -38,33 +59,59 @@ func (v *Video) BeforeCreate(tx *gorm.DB) error {
 
 type VideoFetchOptions struct {
 	BaseFetchOptions
-	UserID string
-	Status VideoStatus
+	UserID   string
+	Status   VideoStatus
+	Category Category
+	// Tags are slugs; a video must carry all of them to match.
+	Tags []string
+	// IncludeFacets adds category and tag counts to the response.
+	IncludeFacets bool
 }
 
 type MultipleVideoResponse struct {
 	Videos []Video `json:"videos"`
-	Total  int64   `json:"total"`
-	Page   int     `json:"page"`
-	Limit  int     `json:"limit"`
+	// Total is -1 when counting was skipped.
+	Total         int64        `json:"total"`
+	Page          int          `json:"page"`
+	Limit         int          `json:"limit"`
+	NextPageToken string       `json:"next_page_token"`
+	Facets        *VideoFacets `json:"facets,omitempty"`
 }
 
 type VideoRepository interface {
-	Create(video *Video) (*Video, error)
-	FindByID(id uuid.UUID) (*Video, error)
-	Find(opts VideoFetchOptions) ([]Video, int64, error)
-	Update(video *Video) error
-	Delete(id uuid.UUID) error
-	IncrementViews(id uuid.UUID) error
+	Create(ctx context.Context, video *Video) (*Video, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Video, error)
+	// FindByIDs returns the videos that exist among ids, in the order of
+	// ids.
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]Video, error)
+	Find(ctx context.Context, opts VideoFetchOptions) ([]Video, int64, error)
+	Update(ctx context.Context, video *Video) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// AddViews adds counted views to the videos' view counts and to the
+	// counted views of their analytics rollups of the hour containing at.
+	AddViews(ctx context.Context, views map[uuid.UUID]int64, at time.Time) error
+	// SetTags replaces the tags of video, creating tags that do not exist
+	// yet, and stores the resulting tags on it.
+	SetTags(ctx context.Context, video *Video, tags []Tag) error
+	Facets(ctx context.Context, opts VideoFetchOptions) (*VideoFacets, error)
+	// ListTags returns the tags of ready videos whose slug starts with
+	// prefix, most used first.
+	ListTags(ctx context.Context, prefix string, limit int) ([]TagCount, error)
+}
+
+// VideoStorage holds the uploaded source and the HLS output of videos.
+type VideoStorage interface {
+	// RemoveFiles deletes every stored file of the video.
+	RemoveFiles(ctx context.Context, video *Video) error
 }
 
 type VideoService interface {
-	CreateVideo(video *Video) (*Video, error)
-	FindByID(id string) (*Video, error)
-	Find(opts VideoFetchOptions) (*MultipleVideoResponse, error)
-	Update(id string, video *Video) (*Video, error)
-	Delete(id string) error
-	IncrementViews(id string) error
-	CompleteUpload(userID, videoID string) error
-	UpdateStatus(videoID string, status VideoStatus) error
+	CreateVideo(ctx context.Context, video *Video) (*Video, error)
+	FindByID(ctx context.Context, id string) (*Video, error)
+	Find(ctx context.Context, opts VideoFetchOptions) (*MultipleVideoResponse, error)
+	Update(ctx context.Context, id string, video *Video) (*Video, error)
+	Delete(ctx context.Context, id string) error
+	CompleteUpload(ctx context.Context, userID, videoID string) error
+	UpdateStatus(ctx context.Context, videoID string, status VideoStatus) error
+	ListTags(ctx context.Context, prefix string, limit int) ([]TagCount, error)
 }
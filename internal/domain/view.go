@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Viewer identifies who is watching. UserID is uuid.Nil for anonymous
+// viewers, who are told apart by IP address and user agent.
+type Viewer struct {
+	UserID    uuid.UUID
+	IP        string
+	UserAgent string
+}
+
+// ViewResult tells why a reported view was or was not counted.
+type ViewResult string
+
+const (
+	ViewCounted ViewResult = "counted"
+	// ViewDuplicate is a view already counted for the viewer in the current
+	// window.
+	ViewDuplicate ViewResult = "duplicate"
+	// ViewTooShort did not reach the watch threshold.
+	ViewTooShort ViewResult = "too_short"
+	// ViewNoPlayback reports watch time the viewer's playback cannot account
+	// for: it never fetched the playlist, or not long enough ago.
+	ViewNoPlayback ViewResult = "no_playback"
+	ViewBot        ViewResult = "bot"
+	// ViewRateLimited is over the views one IP address may add to a video in
+	// a window.
+	ViewRateLimited ViewResult = "rate_limited"
+)
+
+// ViewRepository keeps playback starts, deduplication and pending view
+// counts in Redis, so that counting a view never writes to the database.
+type ViewRepository interface {
+	// StartPlayback records when a device started playing a video, unless a
+	// playback in the window already did.
+	StartPlayback(ctx context.Context, videoID uuid.UUID, device string, at time.Time, window time.Duration) error
+	// PlaybackStart returns when the device started playing the video, or
+	// the zero time.
+	PlaybackStart(ctx context.Context, videoID uuid.UUID, device string) (time.Time, error)
+	// Count adds a pending view unless viewer already has one in the window
+	// or ip has reached perIP views of the video in it.
+	Count(ctx context.Context, videoID uuid.UUID, viewer, ip string, window time.Duration, perIP int64) (ViewResult, error)
+	// TakePending removes and returns the views counted since the last call.
+	TakePending(ctx context.Context) (map[uuid.UUID]int64, error)
+	// RestorePending puts back views that could not be saved.
+	RestorePending(ctx context.Context, views map[uuid.UUID]int64) error
+}
+
+type ViewService interface {
+	// StartPlayback is called when a viewer fetches a video's playlist.
+	StartPlayback(ctx context.Context, videoID string, viewer Viewer)
+	// RecordView is reported by the player once the viewer has watched for
+	// a while.
+	RecordView(ctx context.Context, videoID string, viewer Viewer, watched time.Duration) (ViewResult, error)
+	// Flush adds the pending views to the videos' view counts and returns
+	// how many were saved.
+	Flush(ctx context.Context) (int64, error)
+	// RunFlusher flushes periodically until ctx is done, then once more.
+	RunFlusher(ctx context.Context)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WatchCompleteMargin is how close to the end a position counts as having
+// finished the video, which then plays from the start again.
+const WatchCompleteMargin = 10 * time.Second
+
+// WatchEntry is where a user got to in a video. A user has one entry per
+// video, moved to the top of their history whenever they watch it again.
+type WatchEntry struct {
+	UserID  uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_watch_entries_user_watched,priority:1" json:"user_id"`
+	VideoID uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_watch_entries_user_watched,priority:3;index:idx_watch_entries_video_watched,priority:1" json:"video_id"`
+	// Position and Duration are in seconds; Duration is as reported by the
+	// player.
+	Position  float64   `gorm:"not null;default:0" json:"position"`
+	Duration  float64   `gorm:"not null;default:0" json:"duration"`
+	Completed bool      `gorm:"not null;default:false" json:"completed"`
+	WatchedAt time.Time `gorm:"not null;index:idx_watch_entries_user_watched,priority:2;index:idx_watch_entries_video_watched,priority:2" json:"watched_at"`
+	User      *User     `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Video     *Video    `gorm:"constraint:OnDelete:CASCADE;" json:"video,omitempty"`
+}
+
+// ResumeAt is the position to resume playback from: 0 once the video was
+// watched to the end.
+func (e *WatchEntry) ResumeAt() float64 {
+	if e.Completed {
+		return 0
+	}
+	return e.Position
+}
+
+type MultipleWatchEntryResponse struct {
+	Entries       []WatchEntry `json:"entries"`
+	NextPageToken string       `json:"next_page_token"`
+}
+
+type WatchHistoryRepository interface {
+	// Save upserts entries, keeping the stored one when it is newer.
+	Save(ctx context.Context, entries []WatchEntry) error
+	// Get returns the user's entry for a video, or nil.
+	Get(ctx context.Context, userID, videoID uuid.UUID) (*WatchEntry, error)
+	// Find lists a user's entries with their videos, most recently watched
+	// first, leaving out videos that are not ready unless the user uploaded
+	// them.
+	Find(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) ([]WatchEntry, error)
+	// Clear removes one entry, or all of a user's entries when videoID is
+	// uuid.Nil.
+	Clear(ctx context.Context, userID, videoID uuid.UUID) error
+}
+
+// WatchProgressBuffer holds the latest progress reports in Redis until they
+// are saved, so that heartbeats overwrite each other there instead of each
+// writing to the database.
+type WatchProgressBuffer interface {
+	Put(ctx context.Context, entry *WatchEntry) error
+	// Get returns the buffered entry, or nil.
+	Get(ctx context.Context, userID, videoID uuid.UUID) (*WatchEntry, error)
+	// TakePending removes and returns the entries changed since the last
+	// call.
+	TakePending(ctx context.Context) ([]WatchEntry, error)
+	// RestorePending marks entries that could not be saved as changed again.
+	RestorePending(ctx context.Context, entries []WatchEntry) error
+	// Clear drops buffered entries like WatchHistoryRepository.Clear, and
+	// remembers that they were cleared at the given time.
+	Clear(ctx context.Context, userID, videoID uuid.UUID, at time.Time) error
+	// Cleared reports, for each entry, whether it was cleared after it was
+	// watched.
+	Cleared(ctx context.Context, entries []WatchEntry) ([]bool, error)
+}
+
+type WatchHistoryService interface {
+	// ReportProgress records the position a player reached; players call it
+	// every few seconds while playing.
+	ReportProgress(ctx context.Context, userID uuid.UUID, videoID string, position, duration float64) (*WatchEntry, error)
+	// Progress returns the user's entry for a video, or nil.
+	Progress(ctx context.Context, userID uuid.UUID, videoID string) (*WatchEntry, error)
+	History(ctx context.Context, userID uuid.UUID, opts BaseFetchOptions) (*MultipleWatchEntryResponse, error)
+	// Clear removes one video from the history, or the whole history when
+	// videoID is empty.
+	Clear(ctx context.Context, userID uuid.UUID, videoID string) error
+	// Flush saves the buffered progress and returns how many entries were
+	// saved.
+	Flush(ctx context.Context) (int, error)
+	// RunFlusher flushes periodically until ctx is done, then once more.
+	RunFlusher(ctx context.Context)
+}
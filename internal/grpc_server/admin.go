@@ -0,0 +1,169 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	adminpb "github.com/hunderaweke/gostream/gen/go/admin"
+	videopb "github.com/hunderaweke/gostream/gen/go/video"
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
+)
+
+type adminService struct {
+	adminpb.UnimplementedAdminServiceServer
+	users  domain.UserService
+	videos domain.VideoService
+}
+
+func NewAdminService(users domain.UserService, videos domain.VideoService) adminpb.AdminServiceServer {
+	return &adminService{users: users, videos: videos}
+}
+
+func convertToAdminUser(u domain.User) *adminpb.AdminUser {
+	return &adminpb.AdminUser{
+		Id:        u.ID.String(),
+		Username:  u.Username,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Role:      string(u.Role),
+		Disabled:  u.Disabled,
+		CreatedAt: u.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func parseUserID(id string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, domain.NewFieldError("user_id", "must be a valid UUID")
+	}
+	return userID, nil
+}
+
+func (s *adminService) ListUsers(ctx context.Context, req *adminpb.ListUsersRequest) (*adminpb.ListUsersResponse, error) {
+	sort, err := domain.ParseSort(req.GetOrderBy(), domain.UserSortFields)
+	if err != nil {
+		return nil, err
+	}
+	opts := domain.UserFetchOptions{
+		BaseFetchOptions: domain.BaseFetchOptions{
+			Page:      int(req.GetPage()),
+			Limit:     int(req.GetLimit()),
+			Query:     req.GetQuery(),
+			Sort:      sort,
+			PageToken: req.GetPageToken(),
+			SkipTotal: req.GetSkipTotal(),
+		},
+		Role: domain.Role(req.GetRole()),
+	}
+	resp, err := s.users.ListUsers(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+	result := make([]*adminpb.AdminUser, len(resp.Users))
+	for i, u := range resp.Users {
+		result[i] = convertToAdminUser(u)
+	}
+	page := &adminpb.ListUsersResponse{
+		Users:         result,
+		Page:          req.GetPage(),
+		Limit:         req.GetLimit(),
+		NextPageToken: resp.NextPageToken,
+	}
+	if resp.Total >= 0 {
+		page.Total = &resp.Total
+	}
+	return page, nil
+}
+
+func (s *adminService) SetUserRole(ctx context.Context, req *adminpb.SetUserRoleRequest) (*adminpb.AdminUser, error) {
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	role := domain.Role(req.GetRole())
+	if !role.Valid() {
+		return nil, domain.NewFieldError("role", "must be one of: viewer, creator, moderator, admin")
+	}
+	if callerID, _ := utils.GetUserID(ctx); callerID == userID.String() && role != domain.RoleAdmin {
+		return nil, domain.NewFailedPrecondition("admins cannot demote themselves").WithReason("SELF_MODIFICATION")
+	}
+	user, err := s.users.SetRole(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return convertToAdminUser(*user), nil
+}
+
+func (s *adminService) DisableUser(ctx context.Context, req *adminpb.UserRequest) (*adminpb.AdminUser, error) {
+	return s.setDisabled(ctx, req.GetUserId(), true)
+}
+
+func (s *adminService) EnableUser(ctx context.Context, req *adminpb.UserRequest) (*adminpb.AdminUser, error) {
+	return s.setDisabled(ctx, req.GetUserId(), false)
+}
+
+func (s *adminService) setDisabled(ctx context.Context, id string, disabled bool) (*adminpb.AdminUser, error) {
+	userID, err := parseUserID(id)
+	if err != nil {
+		return nil, err
+	}
+	if callerID, _ := utils.GetUserID(ctx); callerID == userID.String() && disabled {
+		return nil, domain.NewFailedPrecondition("admins cannot disable themselves").WithReason("SELF_MODIFICATION")
+	}
+	user, err := s.users.SetDisabled(ctx, userID, disabled)
+	if err != nil {
+		return nil, err
+	}
+	return convertToAdminUser(*user), nil
+}
+
+func (s *adminService) DeleteUser(ctx context.Context, req *adminpb.UserRequest) (*adminpb.DeleteUserResponse, error) {
+	userID, err := parseUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if callerID, _ := utils.GetUserID(ctx); callerID == userID.String() {
+		return nil, domain.NewFailedPrecondition("admins cannot delete themselves").WithReason("SELF_MODIFICATION")
+	}
+	if err := s.users.DeleteUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("error deleting user: %w", err)
+	}
+	return &adminpb.DeleteUserResponse{UserId: userID.String()}, nil
+}
+
+func (s *adminService) ListVideos(ctx context.Context, req *adminpb.ListVideosRequest) (*videopb.GetVideosResponse, error) {
+	sort, err := domain.ParseSort(req.GetOrderBy(), domain.VideoSortFields)
+	if err != nil {
+		return nil, err
+	}
+	opts := domain.VideoFetchOptions{
+		Status:   domain.VideoStatus(req.GetStatus()),
+		UserID:   req.GetUserId(),
+		Category: domain.Category(req.GetCategory()),
+		Tags:     req.GetTags(),
+		BaseFetchOptions: domain.BaseFetchOptions{
+			Page:      int(req.GetPage()),
+			Limit:     int(req.GetLimit()),
+			Query:     req.GetQuery(),
+			Sort:      sort,
+			PageToken: req.GetPageToken(),
+			SkipTotal: req.GetSkipTotal(),
+		},
+	}
+	resp, err := s.videos.Find(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing videos: %w", err)
+	}
+	return convertToGrpcVideoPage(resp), nil
+}
+
+func (s *adminService) SetVideoStatus(ctx context.Context, req *adminpb.SetVideoStatusRequest) (*videopb.Video, error) {
+	video, err := s.videos.Update(ctx, req.GetVideoId(), &domain.Video{Status: domain.VideoStatus(req.GetStatus())})
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcVideo(*video), nil
+}
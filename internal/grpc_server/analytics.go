@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	analyticspb "github.com/hunderaweke/gostream/gen/go/analytics"
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
+)
+
+type analyticsService struct {
+	analyticspb.UnimplementedAnalyticsServiceServer
+	usecase domain.AnalyticsService
+}
+
+func NewAnalyticsService(usecase domain.AnalyticsService) analyticspb.AnalyticsServiceServer {
+	return &analyticsService{usecase: usecase}
+}
+
+func convertToGrpcAnalyticsBucket(b domain.AnalyticsBucket) *analyticspb.AnalyticsBucket {
+	return &analyticspb.AnalyticsBucket{
+		Start:           b.Start.Format(time.RFC3339),
+		Views:           b.Views,
+		UniqueViewers:   b.UniqueViewers,
+		WatchSeconds:    b.WatchSeconds,
+		AvgWatchSeconds: b.AvgWatchSeconds,
+		Rebuffers:       b.Rebuffers,
+		RebufferMs:      b.RebufferMs,
+		BitrateSwitches: b.BitrateSwitches,
+		Errors:          b.Errors,
+		BytesServed:     b.BytesServed,
+	}
+}
+
+// parseTime reads an optional RFC 3339 time, the zero time when empty.
+func parseTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, domain.NewFieldError(field, "must be an RFC 3339 time")
+	}
+	return t, nil
+}
+
+func (s *analyticsService) IngestPlaybackEvents(ctx context.Context, req *analyticspb.IngestPlaybackEventsRequest) (*analyticspb.IngestPlaybackEventsResponse, error) {
+	events := make([]domain.PlaybackEvent, len(req.GetEvents()))
+	for i, e := range req.GetEvents() {
+		videoID, err := uuid.Parse(e.GetVideoId())
+		if err != nil {
+			return nil, domain.NewFieldError(fmt.Sprintf("events[%d].video_id", i), "must be a valid UUID")
+		}
+		events[i] = domain.PlaybackEvent{
+			VideoID:      videoID,
+			SessionID:    e.GetSessionId(),
+			Type:         domain.PlaybackEventType(e.GetType()),
+			WatchSeconds: e.GetWatchSeconds(),
+			RebufferMs:   e.GetRebufferMs(),
+			BitrateKbps:  e.GetBitrateKbps(),
+			SegmentIndex: -1,
+			ErrorCode:    e.GetErrorCode(),
+		}
+		if e.SegmentIndex != nil {
+			if e.GetSegmentIndex() < 0 {
+				return nil, domain.NewFieldError(fmt.Sprintf("events[%d].segment_index", i), "must not be negative")
+			}
+			events[i].SegmentIndex = int(e.GetSegmentIndex())
+		}
+	}
+	viewer := domain.Viewer{
+		UserID:    viewerUUID(ctx),
+		IP:        utils.GetClientIP(ctx),
+		UserAgent: utils.GetUserAgent(ctx),
+	}
+	accepted, err := s.usecase.Ingest(ctx, viewer, events)
+	if err != nil {
+		return nil, err
+	}
+	return &analyticspb.IngestPlaybackEventsResponse{Accepted: int32(accepted)}, nil
+}
+
+func (s *analyticsService) GetVideoAnalytics(ctx context.Context, req *analyticspb.GetVideoAnalyticsRequest) (*analyticspb.VideoAnalytics, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from, err := parseTime("start_time", req.GetStartTime())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseTime("end_time", req.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.usecase.VideoAnalytics(ctx, userID, req.GetVideoId(), from, to, domain.AnalyticsGranularity(req.GetGranularity()))
+	if err != nil {
+		return nil, err
+	}
+	out := &analyticspb.VideoAnalytics{
+		VideoId:        result.VideoID.String(),
+		StartTime:      result.From.Format(time.RFC3339),
+		EndTime:        result.To.Format(time.RFC3339),
+		Granularity:    string(result.Granularity),
+		Totals:         convertToGrpcAnalyticsBucket(result.Totals),
+		Retention:      result.Retention,
+		SegmentSeconds: domain.HLSSegmentSeconds,
+	}
+	for _, b := range result.Buckets {
+		out.Buckets = append(out.Buckets, convertToGrpcAnalyticsBucket(b))
+	}
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	authpb "github.com/hunderaweke/gostream/gen/go/auth"
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type apiKeyService struct {
+	authpb.UnimplementedAPIKeyServiceServer
+	usecase domain.APIKeyService
+}
+
+func NewAPIKeyService(usecase domain.APIKeyService) authpb.APIKeyServiceServer {
+	return &apiKeyService{usecase: usecase}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func convertToGrpcAPIKey(k domain.APIKey) *authpb.APIKey {
+	scopes := k.ScopeList()
+	names := make([]string, len(scopes))
+	for i, s := range scopes {
+		names[i] = string(s)
+	}
+	return &authpb.APIKey{
+		Id:         k.ID.String(),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     names,
+		CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:  formatOptionalTime(k.ExpiresAt),
+		LastUsedAt: formatOptionalTime(k.LastUsedAt),
+		Revoked:    k.RevokedAt != nil,
+	}
+}
+
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.Unauthenticated, "error parsing user id: %v", err)
+	}
+	return id, nil
+}
+
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, req *authpb.CreateAPIKeyRequest) (*authpb.CreateAPIKeyResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != "" {
+		t, err := time.Parse(time.RFC3339, req.GetExpiresAt())
+		if err != nil {
+			return nil, domain.NewFieldError("expires_at", "must be an RFC 3339 timestamp")
+		}
+		expiresAt = &t
+	}
+	scopes := make([]domain.Permission, len(req.GetScopes()))
+	for i, s := range req.GetScopes() {
+		scopes[i] = domain.Permission(s)
+	}
+	key, rawKey, err := s.usecase.Create(ctx, userID, req.GetName(), scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.CreateAPIKeyResponse{ApiKey: convertToGrpcAPIKey(*key), Key: rawKey}, nil
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, req *authpb.ListAPIKeysRequest) (*authpb.ListAPIKeysResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.usecase.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys: %w", err)
+	}
+	result := make([]*authpb.APIKey, len(keys))
+	for i, k := range keys {
+		result[i] = convertToGrpcAPIKey(k)
+	}
+	return &authpb.ListAPIKeysResponse{ApiKeys: result}, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, req *authpb.RevokeAPIKeyRequest) (*authpb.RevokeAPIKeyResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := uuid.Parse(req.GetKeyId())
+	if err != nil {
+		return nil, domain.NewFieldError("key_id", "must be a valid UUID")
+	}
+	if err := s.usecase.Revoke(ctx, userID, keyID); err != nil {
+		return nil, err
+	}
+	return &authpb.RevokeAPIKeyResponse{KeyId: keyID.String()}, nil
+}
@@ -2,32 +2,210 @@ package grpcserver
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
 
+	"github.com/google/uuid"
 	authpb "github.com/hunderaweke/gostream/gen/go/auth"
 	"github.com/hunderaweke/gostream/internal/domain"
 	"github.com/hunderaweke/gostream/pkg/utils"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// oidcBindingCookie carries the binding secret of an OIDC login from the
+// browser that started it to the callback.
+const oidcBindingCookie = "oidc_binding"
+
+// setBindingCookie sets, or with an empty value clears, the binding cookie
+// through the gateway.
+func setBindingCookie(ctx context.Context, value string) {
+	cookie := &http.Cookie{
+		Name:     oidcBindingCookie,
+		Value:    value,
+		Path:     "/v1/auth/oidc/",
+		HttpOnly: true,
+		Secure:   true,
+		// The callback is a top-level redirect from the identity provider,
+		// which Lax cookies are sent with.
+		SameSite: http.SameSiteLaxMode,
+	}
+	if value == "" {
+		cookie.MaxAge = -1
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs("set-cookie", cookie.String())); err != nil {
+		slog.WarnContext(ctx, "error setting oidc binding cookie", "error", err)
+	}
+}
+
+// bindingCookie returns the binding cookie the gateway forwarded, if any.
+func bindingCookie(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("grpcgateway-cookie") {
+		cookies, err := http.ParseCookie(header)
+		if err != nil {
+			continue
+		}
+		for _, c := range cookies {
+			if c.Name == oidcBindingCookie {
+				return c.Value
+			}
+		}
+	}
+	return ""
+}
+
 type authService struct {
 	authpb.UnimplementedAuthServiceServer
-	usecase domain.UserService
+	usecase      domain.UserService
+	mfa          domain.MFAService
+	oidc         domain.OIDCService
+	verification domain.EmailVerificationService
+	sessions     domain.SessionService
 }
 
-func NewAuthService(usecase domain.UserService) authpb.AuthServiceServer {
-	return &authService{usecase: usecase}
+func NewAuthService(usecase domain.UserService, mfa domain.MFAService, oidc domain.OIDCService, verification domain.EmailVerificationService, sessions domain.SessionService) authpb.AuthServiceServer {
+	return &authService{usecase: usecase, mfa: mfa, oidc: oidc, verification: verification, sessions: sessions}
 }
 func (s *authService) Login(ctx context.Context, credentials *authpb.UserCredentials) (*authpb.AuthorizedUser, error) {
-	user, err := s.usecase.Login(credentials.GetUsername(), credentials.GetPassword())
+	user, err := s.usecase.Login(ctx, credentials.GetUsername(), credentials.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	return s.signIn(ctx, user, credentials.GetDeviceName())
+}
+
+func (s *authService) VerifyMFA(ctx context.Context, req *authpb.VerifyMFARequest) (*authpb.AuthorizedUser, error) {
+	claims, err := utils.ValidateToken(req.GetMfaToken(), string(utils.MFAChallengeToken))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "mfa token is invalid")
+	}
+	user, err := s.mfa.VerifyChallenge(ctx, claims.ID, claims.RegisteredClaims.ID, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, status.Error(codes.Unauthenticated, "account is disabled")
+	}
+	return s.authorize(ctx, user, req.GetDeviceName())
+}
+
+func (s *authService) EnrollTOTP(ctx context.Context, req *authpb.EnrollTOTPRequest) (*authpb.EnrollTOTPResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.mfa.EnrollTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+		QrPng:      enrollment.QRCodePNG,
+	}, nil
+}
+
+func (s *authService) ConfirmTOTP(ctx context.Context, req *authpb.MFACodeRequest) (*authpb.RecoveryCodesResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := s.mfa.ConfirmTOTP(ctx, userID, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *authService) DisableTOTP(ctx context.Context, req *authpb.MFACodeRequest) (*authpb.DisableTOTPResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfa.DisableTOTP(ctx, userID, req.GetCode()); err != nil {
+		return nil, err
+	}
+	return &authpb.DisableTOTPResponse{}, nil
+}
+
+func (s *authService) RegenerateRecoveryCodes(ctx context.Context, req *authpb.MFACodeRequest) (*authpb.RecoveryCodesResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := s.mfa.RegenerateRecoveryCodes(ctx, userID, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *authService) ListOIDCProviders(ctx context.Context, req *authpb.ListOIDCProvidersRequest) (*authpb.ListOIDCProvidersResponse, error) {
+	return &authpb.ListOIDCProvidersResponse{Providers: s.oidc.Providers()}, nil
+}
+
+func (s *authService) StartOIDCLogin(ctx context.Context, req *authpb.StartOIDCLoginRequest) (*authpb.StartOIDCLoginResponse, error) {
+	authURL, binding, err := s.oidc.StartLogin(ctx, req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+	setBindingCookie(ctx, binding)
+	return &authpb.StartOIDCLoginResponse{AuthorizationUrl: authURL, Binding: binding}, nil
+}
+
+func (s *authService) CompleteOIDCLogin(ctx context.Context, req *authpb.CompleteOIDCLoginRequest) (*authpb.AuthorizedUser, error) {
+	binding := req.GetBinding()
+	if binding == "" {
+		binding = bindingCookie(ctx)
+	}
+	// The state is spent either way, so the cookie is of no further use.
+	setBindingCookie(ctx, "")
+	user, err := s.oidc.CompleteLogin(ctx, req.GetProvider(), req.GetState(), req.GetCode(), binding)
+	if err != nil {
+		// Token exchange and ID token verification failures carry provider
+		// details that are logged rather than returned.
+		if _, ok := domain.AsError(err); !ok {
+			slog.WarnContext(ctx, "oidc login failed", "provider", req.GetProvider(), "error", err)
+			return nil, status.Error(codes.Unauthenticated, "oidc login failed")
+		}
+		return nil, err
+	}
+	return s.signIn(ctx, user, "")
+}
+
+// signIn finishes a first-factor login: users with 2FA enabled get an MFA
+// challenge token, everyone else an access and refresh token pair.
+func (s *authService) signIn(ctx context.Context, user *domain.User, deviceName string) (*authpb.AuthorizedUser, error) {
+	if user.TOTPEnabled {
+		mfaToken, err := utils.GenerateToken(*user, utils.MFAChallengeToken, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+		return &authpb.AuthorizedUser{MfaRequired: true, MfaToken: mfaToken}, nil
+	}
+	return s.authorize(ctx, user, deviceName)
+}
+
+// authorize starts a session for the calling device and issues an access and
+// refresh token pair bound to it.
+func (s *authService) authorize(ctx context.Context, user *domain.User, deviceName string) (*authpb.AuthorizedUser, error) {
+	session, err := s.sessions.Start(ctx, user.ID, domain.SessionInfo{
+		DeviceLabel: deviceName,
+		IPAddress:   utils.GetClientIP(ctx),
+		UserAgent:   utils.GetUserAgent(ctx),
+	}, utils.RefreshTokenDuration)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, err.Error())
+		return nil, fmt.Errorf("error starting session: %w", err)
 	}
-	accessToken, err := utils.GenerateToken(*user, utils.AccessToken)
+	accessToken, err := utils.GenerateToken(*user, utils.AccessToken, session.ID)
 	if err != nil {
 		return nil, err
 	}
-	refreshToken, err := utils.GenerateToken(*user, utils.RefreshToken)
+	refreshToken, err := utils.GenerateToken(*user, utils.RefreshToken, session.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -37,11 +215,15 @@ func (s *authService) Login(ctx context.Context, credentials *authpb.UserCredent
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Id:        user.ID.String(),
+		Role:      string(user.Role),
 	}}, nil
 }
 func (s *authService) Validate(ctx context.Context, req *authpb.ValidateRequest) (*authpb.ValidateResponse, error) {
 	claims, err := utils.ValidateToken(req.Token, string(utils.AccessToken))
 	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "token is invalid")
+	}
+	if err := s.sessions.Validate(ctx, claims.ID, claims.SessionID); err != nil {
 		return nil, err
 	}
 	return &authpb.ValidateResponse{UserId: claims.ID.String()}, nil
@@ -53,15 +235,22 @@ func (s *authService) Register(ctx context.Context, req *authpb.UserRegisterRequ
 		LastName:  req.LastName,
 		Password:  req.Password,
 		Username:  req.Username,
+		Email:     req.Email,
 	}
-	createdUser, err := s.usecase.CreateUser(&user)
+	createdUser, err := s.usecase.CreateUser(ctx, &user)
 	if err != nil {
 		return nil, err
 	}
+	if createdUser.Email != "" {
+		if err := s.verification.SendVerification(ctx, createdUser.ID); err != nil {
+			slog.WarnContext(ctx, "error sending verification email", "user_id", createdUser.ID, "error", err)
+		}
+	}
 	return &authpb.User{
 		FirstName: createdUser.FirstName,
 		LastName:  createdUser.LastName,
 		Username:  createdUser.Username,
 		Id:        createdUser.ID.String(),
+		Role:      string(createdUser.Role),
 	}, nil
 }
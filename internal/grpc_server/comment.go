@@ -0,0 +1,151 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	commentpb "github.com/hunderaweke/gostream/gen/go/comment"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type commentService struct {
+	commentpb.UnimplementedCommentServiceServer
+	usecase domain.CommentService
+}
+
+func NewCommentService(usecase domain.CommentService) commentpb.CommentServiceServer {
+	return &commentService{usecase: usecase}
+}
+
+func convertToGrpcComment(c domain.Comment) *commentpb.Comment {
+	pc := &commentpb.Comment{
+		Id:           c.ID.String(),
+		VideoId:      c.VideoID.String(),
+		AuthorId:     c.UserID.String(),
+		Body:         c.Body,
+		Edited:       c.Edited,
+		EditedAt:     formatOptionalTime(c.EditedAt),
+		Deleted:      c.Deleted(),
+		Pinned:       c.Pinned,
+		Hidden:       c.Hidden,
+		ReplyCount:   c.ReplyCount,
+		LikeCount:    c.LikeCount,
+		DislikeCount: c.DislikeCount,
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.ParentID != nil {
+		pc.ParentId = c.ParentID.String()
+	}
+	for _, m := range c.Mentions {
+		pc.Mentions = append(pc.Mentions, &commentpb.Mention{UserId: m.UserID.String(), Username: m.Username})
+	}
+	return pc
+}
+
+func (s *commentService) CreateComment(ctx context.Context, req *commentpb.CreateCommentRequest) (*commentpb.Comment, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.usecase.Create(ctx, userID, req.GetVideoId(), req.GetParentId(), req.GetBody())
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcComment(*comment), nil
+}
+
+func (s *commentService) ListComments(ctx context.Context, req *commentpb.ListCommentsRequest) (*commentpb.ListCommentsResponse, error) {
+	sort, err := domain.ParseSort(req.GetOrderBy(), domain.CommentSortFields)
+	if err != nil {
+		return nil, err
+	}
+	opts := domain.CommentFetchOptions{
+		BaseFetchOptions: domain.BaseFetchOptions{
+			Limit:     int(req.GetLimit()),
+			Sort:      sort,
+			PageToken: req.GetPageToken(),
+		},
+	}
+	if req.GetParentId() != "" {
+		parentID, err := uuid.Parse(req.GetParentId())
+		if err != nil {
+			return nil, domain.NewFieldError("parent_id", "must be a valid UUID")
+		}
+		opts.ParentID = &parentID
+	}
+	resp, err := s.usecase.List(ctx, viewerUUID(ctx), req.GetVideoId(), opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &commentpb.ListCommentsResponse{
+		Total:            resp.Total,
+		CommentsDisabled: resp.CommentsDisabled,
+		NextPageToken:    resp.NextPageToken,
+	}
+	if resp.Pinned != nil {
+		out.Pinned = convertToGrpcComment(*resp.Pinned)
+	}
+	for _, c := range resp.Comments {
+		out.Comments = append(out.Comments, convertToGrpcComment(c))
+	}
+	return out, nil
+}
+
+func (s *commentService) UpdateComment(ctx context.Context, req *commentpb.UpdateCommentRequest) (*commentpb.Comment, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.usecase.Update(ctx, userID, req.GetCommentId(), req.GetBody())
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcComment(*comment), nil
+}
+
+func (s *commentService) DeleteComment(ctx context.Context, req *commentpb.DeleteCommentRequest) (*commentpb.DeleteCommentResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.usecase.Delete(ctx, userID, req.GetCommentId()); err != nil {
+		return nil, err
+	}
+	return &commentpb.DeleteCommentResponse{CommentId: req.GetCommentId()}, nil
+}
+
+func (s *commentService) PinComment(ctx context.Context, req *commentpb.PinCommentRequest) (*commentpb.Comment, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.usecase.SetPinned(ctx, userID, req.GetCommentId(), req.GetPinned())
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcComment(*comment), nil
+}
+
+func (s *commentService) HideComment(ctx context.Context, req *commentpb.HideCommentRequest) (*commentpb.Comment, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.usecase.SetHidden(ctx, userID, req.GetCommentId(), req.GetHidden())
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcComment(*comment), nil
+}
+
+func (s *commentService) SetCommentsDisabled(ctx context.Context, req *commentpb.SetCommentsDisabledRequest) (*commentpb.SetCommentsDisabledResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.usecase.SetCommentsDisabled(ctx, userID, req.GetVideoId(), req.GetDisabled()); err != nil {
+		return nil, err
+	}
+	return &commentpb.SetCommentsDisabledResponse{VideoId: req.GetVideoId(), Disabled: req.GetDisabled()}, nil
+}
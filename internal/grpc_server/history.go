@@ -0,0 +1,90 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	historypb "github.com/hunderaweke/gostream/gen/go/history"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type historyService struct {
+	historypb.UnimplementedHistoryServiceServer
+	usecase domain.WatchHistoryService
+}
+
+func NewHistoryService(usecase domain.WatchHistoryService) historypb.HistoryServiceServer {
+	return &historyService{usecase: usecase}
+}
+
+func convertToGrpcProgress(e domain.WatchEntry) *historypb.PlaybackProgress {
+	return &historypb.PlaybackProgress{
+		VideoId:               e.VideoID.String(),
+		PositionSeconds:       e.Position,
+		DurationSeconds:       e.Duration,
+		Completed:             e.Completed,
+		ResumePositionSeconds: e.ResumeAt(),
+		WatchedAt:             e.WatchedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *historyService) ReportPlaybackProgress(ctx context.Context, req *historypb.ReportPlaybackProgressRequest) (*historypb.PlaybackProgress, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.usecase.ReportProgress(ctx, userID, req.GetVideoId(), req.GetPositionSeconds(), req.GetDurationSeconds())
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcProgress(*entry), nil
+}
+
+func (s *historyService) GetResumePosition(ctx context.Context, req *historypb.GetResumePositionRequest) (*historypb.PlaybackProgress, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.usecase.Progress(ctx, userID, req.GetVideoId())
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return &historypb.PlaybackProgress{VideoId: req.GetVideoId()}, nil
+	}
+	return convertToGrpcProgress(*entry), nil
+}
+
+func (s *historyService) GetWatchHistory(ctx context.Context, req *historypb.GetWatchHistoryRequest) (*historypb.GetWatchHistoryResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.usecase.History(ctx, userID, domain.BaseFetchOptions{
+		Limit:     int(req.GetLimit()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &historypb.GetWatchHistoryResponse{NextPageToken: resp.NextPageToken}
+	for _, e := range resp.Entries {
+		entry := &historypb.HistoryEntry{Progress: convertToGrpcProgress(e)}
+		if e.Video != nil {
+			entry.Video = convertToGrpcVideo(*e.Video)
+		}
+		out.Entries = append(out.Entries, entry)
+	}
+	return out, nil
+}
+
+func (s *historyService) ClearWatchHistory(ctx context.Context, req *historypb.ClearWatchHistoryRequest) (*historypb.ClearWatchHistoryResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.usecase.Clear(ctx, userID, req.GetVideoId()); err != nil {
+		return nil, err
+	}
+	return &historypb.ClearWatchHistoryResponse{}, nil
+}
@@ -0,0 +1,209 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	playlistpb "github.com/hunderaweke/gostream/gen/go/playlist"
+	"github.com/hunderaweke/gostream/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type playlistService struct {
+	playlistpb.UnimplementedPlaylistServiceServer
+	usecase domain.PlaylistService
+}
+
+func NewPlaylistService(usecase domain.PlaylistService) playlistpb.PlaylistServiceServer {
+	return &playlistService{usecase: usecase}
+}
+
+// viewerUUID is viewerID as a UUID, uuid.Nil for anonymous callers.
+func viewerUUID(ctx context.Context) uuid.UUID {
+	id, err := uuid.Parse(viewerID(ctx))
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+func convertToGrpcPlaylistItem(item domain.PlaylistItem) *playlistpb.PlaylistItem {
+	pi := &playlistpb.PlaylistItem{
+		Id:       item.ID.String(),
+		Position: int32(item.Position),
+		AddedAt:  item.CreatedAt.Format(time.RFC3339),
+	}
+	if item.Video != nil {
+		pi.Video = convertToGrpcVideo(*item.Video)
+	}
+	return pi
+}
+
+func convertToGrpcPlaylist(p domain.Playlist) *playlistpb.Playlist {
+	pp := &playlistpb.Playlist{
+		Id:          p.ID.String(),
+		OwnerId:     p.UserID.String(),
+		Title:       p.Title,
+		Description: p.Description,
+		Visibility:  string(p.Visibility),
+		ItemCount:   p.ItemCount,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, item := range p.Items {
+		pp.Items = append(pp.Items, convertToGrpcPlaylistItem(item))
+	}
+	return pp
+}
+
+func (s *playlistService) CreatePlaylist(ctx context.Context, req *playlistpb.CreatePlaylistRequest) (*playlistpb.Playlist, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	playlist, err := s.usecase.Create(ctx, userID, &domain.Playlist{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Visibility:  domain.PlaylistVisibility(req.GetVisibility()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcPlaylist(*playlist), nil
+}
+
+func (s *playlistService) GetPlaylist(ctx context.Context, req *playlistpb.GetPlaylistRequest) (*playlistpb.Playlist, error) {
+	playlist, err := s.usecase.Get(ctx, viewerUUID(ctx), req.GetPlaylistId())
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcPlaylist(*playlist), nil
+}
+
+func (s *playlistService) ListPlaylists(ctx context.Context, req *playlistpb.ListPlaylistsRequest) (*playlistpb.ListPlaylistsResponse, error) {
+	sort, err := domain.ParseSort(req.GetOrderBy(), domain.PlaylistSortFields)
+	if err != nil {
+		return nil, err
+	}
+	viewer := viewerUUID(ctx)
+	opts := domain.PlaylistFetchOptions{
+		BaseFetchOptions: domain.BaseFetchOptions{
+			Limit:     int(req.GetLimit()),
+			Sort:      sort,
+			PageToken: req.GetPageToken(),
+			SkipTotal: req.GetSkipTotal(),
+		},
+	}
+	switch {
+	case req.GetMine():
+		if viewer == uuid.Nil {
+			return nil, status.Error(codes.Unauthenticated, "mine requires authentication")
+		}
+		opts.UserID = viewer
+	case req.GetUserId() != "":
+		if opts.UserID, err = uuid.Parse(req.GetUserId()); err != nil {
+			return nil, domain.NewFieldError("user_id", "must be a valid UUID")
+		}
+	}
+	resp, err := s.usecase.List(ctx, viewer, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &playlistpb.ListPlaylistsResponse{NextPageToken: resp.NextPageToken}
+	if resp.Total >= 0 {
+		out.Total = &resp.Total
+	}
+	for _, p := range resp.Playlists {
+		out.Playlists = append(out.Playlists, convertToGrpcPlaylist(p))
+	}
+	return out, nil
+}
+
+func (s *playlistService) UpdatePlaylist(ctx context.Context, req *playlistpb.UpdatePlaylistRequest) (*playlistpb.Playlist, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update := domain.PlaylistUpdate{Title: req.Title, Description: req.Description}
+	if req.Visibility != nil {
+		visibility := domain.PlaylistVisibility(req.GetVisibility())
+		update.Visibility = &visibility
+	}
+	playlist, err := s.usecase.Update(ctx, userID, req.GetPlaylistId(), update)
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcPlaylist(*playlist), nil
+}
+
+func (s *playlistService) DeletePlaylist(ctx context.Context, req *playlistpb.DeletePlaylistRequest) (*playlistpb.DeletePlaylistResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.usecase.Delete(ctx, userID, req.GetPlaylistId()); err != nil {
+		return nil, err
+	}
+	return &playlistpb.DeletePlaylistResponse{PlaylistId: req.GetPlaylistId()}, nil
+}
+
+func (s *playlistService) AddPlaylistItem(ctx context.Context, req *playlistpb.AddPlaylistItemRequest) (*playlistpb.PlaylistItem, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	position := -1
+	if req.Position != nil {
+		position = int(req.GetPosition())
+	}
+	item, err := s.usecase.AddItem(ctx, userID, req.GetPlaylistId(), req.GetVideoId(), position)
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcPlaylistItem(*item), nil
+}
+
+func (s *playlistService) RemovePlaylistItem(ctx context.Context, req *playlistpb.RemovePlaylistItemRequest) (*playlistpb.RemovePlaylistItemResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.usecase.RemoveItem(ctx, userID, req.GetPlaylistId(), req.GetItemId()); err != nil {
+		return nil, err
+	}
+	return &playlistpb.RemovePlaylistItemResponse{ItemId: req.GetItemId()}, nil
+}
+
+func (s *playlistService) MovePlaylistItem(ctx context.Context, req *playlistpb.MovePlaylistItemRequest) (*playlistpb.Playlist, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	playlist, err := s.usecase.MoveItem(ctx, userID, req.GetPlaylistId(), req.GetItemId(), int(req.GetPosition()))
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcPlaylist(*playlist), nil
+}
+
+func (s *playlistService) PlayPlaylist(ctx context.Context, req *playlistpb.PlayPlaylistRequest) (*playlistpb.PlayPlaylistResponse, error) {
+	playlist, err := s.usecase.PlayAll(ctx, viewerUUID(ctx), req.GetPlaylistId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &playlistpb.PlayPlaylistResponse{PlaylistId: playlist.ID.String(), Title: playlist.Title}
+	for _, item := range playlist.Items {
+		resp.Items = append(resp.Items, &playlistpb.PlayItem{
+			ItemId:       item.ID.String(),
+			Position:     int32(item.Position),
+			VideoId:      item.VideoID.String(),
+			Title:        item.Video.Title,
+			ThumbnailUrl: item.Video.ThumbnailUrl,
+			StreamUrl:    fmt.Sprintf("/v1/stream/%s", item.VideoID),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	reactionpb "github.com/hunderaweke/gostream/gen/go/reaction"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type reactionService struct {
+	reactionpb.UnimplementedReactionServiceServer
+	usecase domain.ReactionService
+}
+
+func NewReactionService(usecase domain.ReactionService) reactionpb.ReactionServiceServer {
+	return &reactionService{usecase: usecase}
+}
+
+func (s *reactionService) react(ctx context.Context, target domain.ReactionTarget, targetID string, kind domain.ReactionKind) (*reactionpb.ReactionCounts, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.usecase.React(ctx, userID, target, targetID, kind)
+	if err != nil {
+		return nil, err
+	}
+	return &reactionpb.ReactionCounts{
+		LikeCount:    counts.Likes,
+		DislikeCount: counts.Dislikes,
+		Reaction:     string(counts.Reaction),
+	}, nil
+}
+
+func (s *reactionService) SetVideoReaction(ctx context.Context, req *reactionpb.SetVideoReactionRequest) (*reactionpb.ReactionCounts, error) {
+	if req.GetReaction() == "" {
+		return nil, domain.NewFieldError("reaction", "is required")
+	}
+	return s.react(ctx, domain.ReactionTargetVideo, req.GetVideoId(), domain.ReactionKind(req.GetReaction()))
+}
+
+func (s *reactionService) ClearVideoReaction(ctx context.Context, req *reactionpb.ClearVideoReactionRequest) (*reactionpb.ReactionCounts, error) {
+	return s.react(ctx, domain.ReactionTargetVideo, req.GetVideoId(), "")
+}
+
+func (s *reactionService) SetCommentReaction(ctx context.Context, req *reactionpb.SetCommentReactionRequest) (*reactionpb.ReactionCounts, error) {
+	if req.GetReaction() == "" {
+		return nil, domain.NewFieldError("reaction", "is required")
+	}
+	return s.react(ctx, domain.ReactionTargetComment, req.GetCommentId(), domain.ReactionKind(req.GetReaction()))
+}
+
+func (s *reactionService) ClearCommentReaction(ctx context.Context, req *reactionpb.ClearCommentReactionRequest) (*reactionpb.ReactionCounts, error) {
+	return s.react(ctx, domain.ReactionTargetComment, req.GetCommentId(), "")
+}
+
+func (s *reactionService) ListMyLikedVideos(ctx context.Context, req *reactionpb.ListMyLikedVideosRequest) (*reactionpb.ListMyLikedVideosResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.usecase.ListLikedVideos(ctx, userID, domain.BaseFetchOptions{
+		Limit:     int(req.GetLimit()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &reactionpb.ListMyLikedVideosResponse{NextPageToken: resp.NextPageToken}
+	for _, v := range resp.Videos {
+		liked := &reactionpb.LikedVideo{Video: convertToGrpcVideo(v)}
+		if v.LikedAt != nil {
+			liked.LikedAt = v.LikedAt.Format(time.RFC3339)
+		}
+		out.Videos = append(out.Videos, liked)
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package grpcserver
+
+import (
+	"context"
+
+	recommendationpb "github.com/hunderaweke/gostream/gen/go/recommendation"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type recommendationService struct {
+	recommendationpb.UnimplementedRecommendationServiceServer
+	usecase domain.RecommendationService
+}
+
+func NewRecommendationService(usecase domain.RecommendationService) recommendationpb.RecommendationServiceServer {
+	return &recommendationService{usecase: usecase}
+}
+
+func convertToGrpcRecommendations(recommendations []domain.Recommendation) *recommendationpb.RecommendationsResponse {
+	resp := &recommendationpb.RecommendationsResponse{}
+	for _, r := range recommendations {
+		resp.Videos = append(resp.Videos, &recommendationpb.RecommendedVideo{
+			Video:   convertToGrpcVideo(r.Video),
+			Score:   r.Score,
+			Sources: r.Sources,
+		})
+	}
+	return resp
+}
+
+func (s *recommendationService) GetRelatedVideos(ctx context.Context, req *recommendationpb.GetRelatedVideosRequest) (*recommendationpb.RecommendationsResponse, error) {
+	recommendations, err := s.usecase.Related(ctx, viewerUUID(ctx), req.GetVideoId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcRecommendations(recommendations), nil
+}
+
+func (s *recommendationService) GetRecommendedForMe(ctx context.Context, req *recommendationpb.GetRecommendedForMeRequest) (*recommendationpb.RecommendationsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recommendations, err := s.usecase.ForUser(ctx, userID, int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcRecommendations(recommendations), nil
+}
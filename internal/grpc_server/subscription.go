@@ -0,0 +1,132 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	subscriptionpb "github.com/hunderaweke/gostream/gen/go/subscription"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type subscriptionService struct {
+	subscriptionpb.UnimplementedSubscriptionServiceServer
+	usecase domain.SubscriptionService
+}
+
+func NewSubscriptionService(usecase domain.SubscriptionService) subscriptionpb.SubscriptionServiceServer {
+	return &subscriptionService{usecase: usecase}
+}
+
+func convertToGrpcChannel(u *domain.User) *subscriptionpb.Channel {
+	if u == nil {
+		return nil
+	}
+	return &subscriptionpb.Channel{
+		Id:        u.ID.String(),
+		Username:  u.Username,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		AvatarUrl: u.AvatarURL,
+	}
+}
+
+func (s *subscriptionService) Subscribe(ctx context.Context, req *subscriptionpb.SubscribeRequest) (*subscriptionpb.Subscription, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := s.usecase.Subscribe(ctx, userID, req.GetChannelId())
+	if err != nil {
+		return nil, err
+	}
+	return &subscriptionpb.Subscription{
+		Channel:      convertToGrpcChannel(subscription.Channel),
+		SubscribedAt: subscription.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *subscriptionService) Unsubscribe(ctx context.Context, req *subscriptionpb.UnsubscribeRequest) (*subscriptionpb.UnsubscribeResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.usecase.Unsubscribe(ctx, userID, req.GetChannelId()); err != nil {
+		return nil, err
+	}
+	return &subscriptionpb.UnsubscribeResponse{ChannelId: req.GetChannelId()}, nil
+}
+
+func (s *subscriptionService) ListSubscriptions(ctx context.Context, req *subscriptionpb.ListSubscriptionsRequest) (*subscriptionpb.ListSubscriptionsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.usecase.ListSubscriptions(ctx, userID, domain.BaseFetchOptions{
+		Limit:     int(req.GetLimit()),
+		PageToken: req.GetPageToken(),
+		SkipTotal: req.GetSkipTotal(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &subscriptionpb.ListSubscriptionsResponse{NextPageToken: resp.NextPageToken}
+	if resp.Total >= 0 {
+		out.Total = &resp.Total
+	}
+	for _, sub := range resp.Subscriptions {
+		out.Subscriptions = append(out.Subscriptions, &subscriptionpb.Subscription{
+			Channel:      convertToGrpcChannel(sub.Channel),
+			SubscribedAt: sub.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return out, nil
+}
+
+func (s *subscriptionService) ListSubscribers(ctx context.Context, req *subscriptionpb.ListSubscribersRequest) (*subscriptionpb.ListSubscribersResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.usecase.ListSubscribers(ctx, userID, domain.BaseFetchOptions{
+		Limit:     int(req.GetLimit()),
+		PageToken: req.GetPageToken(),
+		SkipTotal: req.GetSkipTotal(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &subscriptionpb.ListSubscribersResponse{NextPageToken: resp.NextPageToken}
+	if resp.Total >= 0 {
+		out.Total = &resp.Total
+	}
+	for _, sub := range resp.Subscriptions {
+		out.Subscribers = append(out.Subscribers, &subscriptionpb.Subscriber{
+			User:         convertToGrpcChannel(sub.Subscriber),
+			SubscribedAt: sub.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return out, nil
+}
+
+func (s *subscriptionService) GetSubscriptionFeed(ctx context.Context, req *subscriptionpb.GetSubscriptionFeedRequest) (*subscriptionpb.GetSubscriptionFeedResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.usecase.Feed(ctx, userID, domain.BaseFetchOptions{
+		Limit:     int(req.GetLimit()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &subscriptionpb.GetSubscriptionFeedResponse{NextPageToken: resp.NextPageToken}
+	for _, v := range resp.Videos {
+		item := &subscriptionpb.FeedVideo{Video: convertToGrpcVideo(v)}
+		if v.PublishedAt != nil {
+			item.PublishedAt = v.PublishedAt.Format(time.RFC3339)
+		}
+		out.Videos = append(out.Videos, item)
+	}
+	return out, nil
+}
@@ -0,0 +1,208 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	userpb "github.com/hunderaweke/gostream/gen/go/user"
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
+)
+
+type userService struct {
+	userpb.UnimplementedUserServiceServer
+	users        domain.UserService
+	mfa          domain.MFAService
+	verification domain.EmailVerificationService
+	sessions     domain.SessionService
+}
+
+func NewUserService(users domain.UserService, mfa domain.MFAService, verification domain.EmailVerificationService, sessions domain.SessionService) userpb.UserServiceServer {
+	return &userService{users: users, mfa: mfa, verification: verification, sessions: sessions}
+}
+
+func convertToProfile(u domain.User) *userpb.Profile {
+	return &userpb.Profile{
+		Id:            u.ID.String(),
+		Username:      u.Username,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		AvatarUrl:     u.AvatarURL,
+		Bio:           u.Bio,
+		Role:          string(u.Role),
+		TotpEnabled:   u.TOTPEnabled,
+		CreatedAt:     u.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *userService) currentUser(ctx context.Context) (*domain.User, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.NewNotFound("user", userID.String())
+	}
+	return user, nil
+}
+
+func (s *userService) GetMe(ctx context.Context, req *userpb.GetMeRequest) (*userpb.Profile, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertToProfile(*user), nil
+}
+
+func (s *userService) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*userpb.Profile, error) {
+	current, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.UpdateProfile(ctx, current.ID, domain.ProfileUpdate{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		AvatarURL: req.AvatarUrl,
+		Bio:       req.Bio,
+		Email:     req.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != "" && user.Email != current.Email {
+		if err := s.verification.SendVerification(ctx, user.ID); err != nil {
+			slog.WarnContext(ctx, "error sending verification email", "user_id", user.ID, "error", err)
+		}
+	}
+	return convertToProfile(*user), nil
+}
+
+func (s *userService) DeleteAccount(ctx context.Context, req *userpb.DeleteAccountRequest) (*userpb.DeleteAccountResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetConfirmUsername() != user.Username {
+		return nil, domain.NewFieldError("confirm_username", "does not match the account")
+	}
+	if err := s.reauthenticate(ctx, user, req.GetPassword(), req.GetCode()); err != nil {
+		return nil, err
+	}
+	if err := s.users.DeleteUser(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("error deleting account: %w", err)
+	}
+	return &userpb.DeleteAccountResponse{}, nil
+}
+
+// reauthenticate checks that the caller can still sign in to the account,
+// with a 2FA code when it is enabled and with the password otherwise, so a
+// stolen token alone cannot delete it.
+func (s *userService) reauthenticate(ctx context.Context, user *domain.User, password, code string) error {
+	if user.TOTPEnabled {
+		if code == "" {
+			return domain.NewFieldError("code", "is required")
+		}
+		_, err := s.mfa.VerifyCode(ctx, user.ID, code)
+		return err
+	}
+	if password == "" {
+		return domain.NewFieldError("password", "is required")
+	}
+	if _, err := s.users.Login(ctx, user.Username, password); err != nil {
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			return domain.NewFieldError("password", "is incorrect")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *userService) SendVerificationEmail(ctx context.Context, req *userpb.SendVerificationEmailRequest) (*userpb.SendVerificationEmailResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verification.SendVerification(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &userpb.SendVerificationEmailResponse{}, nil
+}
+
+func (s *userService) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.Profile, error) {
+	user, err := s.verification.Verify(ctx, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	return convertToProfile(*user), nil
+}
+
+func convertToGrpcSession(s domain.Session, currentID string) *userpb.Session {
+	return &userpb.Session{
+		Id:          s.ID.String(),
+		DeviceLabel: s.DeviceLabel,
+		IpAddress:   s.IPAddress,
+		UserAgent:   s.UserAgent,
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339),
+		LastSeenAt:  s.LastSeenAt.Format(time.RFC3339),
+		ExpiresAt:   s.ExpiresAt.Format(time.RFC3339),
+		Current:     s.ID.String() == currentID,
+	}
+}
+
+func (s *userService) ListSessions(ctx context.Context, req *userpb.ListSessionsRequest) (*userpb.ListSessionsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessions.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+	currentID := utils.GetSessionID(ctx)
+	result := make([]*userpb.Session, len(sessions))
+	for i, session := range sessions {
+		result[i] = convertToGrpcSession(session, currentID)
+	}
+	return &userpb.ListSessionsResponse{Sessions: result}, nil
+}
+
+func (s *userService) RevokeSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*userpb.RevokeSessionResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, err := uuid.Parse(req.GetSessionId())
+	if err != nil {
+		return nil, domain.NewFieldError("session_id", "must be a valid UUID")
+	}
+	if err := s.sessions.Revoke(ctx, userID, sessionID); err != nil {
+		return nil, err
+	}
+	return &userpb.RevokeSessionResponse{SessionId: sessionID.String()}, nil
+}
+
+func (s *userService) RevokeAllOtherSessions(ctx context.Context, req *userpb.RevokeAllOtherSessionsRequest) (*userpb.RevokeAllOtherSessionsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currentID, err := uuid.Parse(utils.GetSessionID(ctx))
+	if err != nil {
+		return nil, domain.NewFailedPrecondition("request is not made with a session token").WithReason("SESSION_REQUIRED")
+	}
+	revoked, err := s.sessions.RevokeAllOthers(ctx, userID, currentID)
+	if err != nil {
+		return nil, fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return &userpb.RevokeAllOtherSessionsResponse{Revoked: revoked}, nil
+}
@@ -3,58 +3,63 @@ package grpcserver
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
+
 	videopb "github.com/hunderaweke/gostream/gen/go/video"
 	"github.com/hunderaweke/gostream/internal/database"
 	"github.com/hunderaweke/gostream/internal/domain"
 	"github.com/hunderaweke/gostream/internal/queue"
 	"github.com/hunderaweke/gostream/pkg/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type videoService struct {
 	videopb.UnimplementedVideoServiceServer
 	usecase     domain.VideoService
+	views       domain.ViewService
+	history     domain.WatchHistoryService
+	rankings    domain.RankingService
 	minioClient *database.MinioClient
 	rmq         *queue.RabbitMQ
 }
 
-func NewVideoService(minioClient *database.MinioClient, usecase domain.VideoService, rmq *queue.RabbitMQ) videopb.VideoServiceServer {
-	return &videoService{usecase: usecase, minioClient: minioClient, rmq: rmq}
+func NewVideoService(minioClient *database.MinioClient, usecase domain.VideoService, views domain.ViewService, history domain.WatchHistoryService, rankings domain.RankingService, rmq *queue.RabbitMQ) videopb.VideoServiceServer {
+	return &videoService{usecase: usecase, views: views, history: history, rankings: rankings, minioClient: minioClient, rmq: rmq}
 }
 func (s *videoService) CreateVideo(ctx context.Context, req *videopb.CreateVideoRequest) (*videopb.CreateVideoResponse, error) {
-	userId, err := utils.GetUserID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("error finding user id: %v", err)
-	}
-	userUUID, err := uuid.Parse(userId)
+	userUUID, err := callerID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing user id: %v", err)
+		return nil, err
 	}
 	video := &domain.Video{
 		UserID:      userUUID,
 		Title:       req.Title,
 		Description: req.Description,
 		FileName:    req.FileExtension,
+		Category:    domain.Category(req.GetCategory()),
+		Tags:        tagsFromNames(req.GetTags()),
 	}
-	video, err = s.usecase.CreateVideo(video)
+	video, err = s.usecase.CreateVideo(ctx, video)
 	if err != nil {
-		return nil, fmt.Errorf("error creating video: %v", err)
+		return nil, err
 	}
 	objectName := fmt.Sprintf("%s.%s", video.ID.String(), req.FileExtension)
-	uploadUrl, err := s.minioClient.GeneratePresignedURL(objectName, 10*time.Hour)
+	uploadUrl, err := s.minioClient.GeneratePresignedURL(ctx, objectName, 10*time.Hour)
 	if err != nil {
-		return nil, fmt.Errorf("error creating upload url: %v", err)
+		return nil, fmt.Errorf("error creating upload url: %w", err)
 	}
 	return &videopb.CreateVideoResponse{VideoId: video.ID.String(), UploadUrl: uploadUrl}, nil
 }
 func (s *videoService) CompleteUpload(ctx context.Context, req *videopb.CompleteUploadRequest) (*videopb.CompleteUploadResponse, error) {
-	userId, err := utils.GetUserID(ctx)
+	userID, err := callerID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error finding user id: %v", err)
+		return nil, err
 	}
-	if err := s.usecase.CompleteUpload(userId, req.GetVideoId()); err != nil {
+	if err := s.usecase.CompleteUpload(ctx, userID.String(), req.GetVideoId()); err != nil {
 		return nil, err
 	}
 	return &videopb.CompleteUploadResponse{
@@ -62,25 +67,165 @@ func (s *videoService) CompleteUpload(ctx context.Context, req *videopb.Complete
 		Status:  string(domain.VideoStatusProcessing),
 	}, nil
 }
+
+// viewerID returns the caller of an optionally authenticated RPC, or "" for
+// anonymous calls.
+func viewerID(ctx context.Context) string {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		return ""
+	}
+	return userID
+}
+
 func (s *videoService) GetVideo(ctx context.Context, req *videopb.GetVideoRequest) (*videopb.Video, error) {
-	video, err := s.usecase.FindByID(req.GetVideoId())
+	video, err := s.usecase.FindByID(ctx, req.GetVideoId())
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != domain.VideoStatusReady && video.UserID.String() != viewerID(ctx) {
+		return nil, domain.NewNotFound("video", req.GetVideoId())
+	}
+	pv := convertToGrpcVideo(*video)
+	if viewer := viewerUUID(ctx); viewer != uuid.Nil {
+		// A missing resume position must not fail the lookup.
+		entry, err := s.history.Progress(ctx, viewer, req.GetVideoId())
+		if err != nil {
+			slog.WarnContext(ctx, "reading resume position failed", "video_id", req.GetVideoId(), "error", err)
+		} else if entry != nil {
+			resume := entry.ResumeAt()
+			pv.ResumePositionSeconds = &resume
+		}
+	}
+	return pv, nil
+}
+
+// UpdateVideo lets the owner of a video change its title, description,
+// category and tags.
+func (s *videoService) UpdateVideo(ctx context.Context, req *videopb.UpdateVideoRequest) (*videopb.Video, error) {
+	userID, err := callerID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting video with id: %s (%v)", req.GetVideoId(), err)
+		return nil, err
+	}
+	existing, err := s.usecase.FindByID(ctx, req.GetVideoId())
+	if err != nil {
+		return nil, err
+	}
+	if existing.UserID != userID {
+		return nil, domain.NewPermissionDenied("video does not belong to the current user")
+	}
+	changes := &domain.Video{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Category:    domain.Category(req.GetCategory()),
+	}
+	switch {
+	case req.GetClearTags():
+		changes.Tags = []domain.Tag{}
+	case len(req.GetTags()) > 0:
+		changes.Tags = tagsFromNames(req.GetTags())
+	}
+	video, err := s.usecase.Update(ctx, req.GetVideoId(), changes)
+	if err != nil {
+		return nil, err
 	}
 	return convertToGrpcVideo(*video), nil
 }
 
+func (s *videoService) ListTags(ctx context.Context, req *videopb.ListTagsRequest) (*videopb.ListTagsResponse, error) {
+	tags, err := s.usecase.ListTags(ctx, req.GetPrefix(), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	resp := &videopb.ListTagsResponse{Tags: make([]*videopb.TagCount, len(tags))}
+	for i, t := range tags {
+		resp.Tags[i] = &videopb.TagCount{Slug: t.Slug, Name: t.Name, Videos: t.Videos}
+	}
+	return resp, nil
+}
+
+func (s *videoService) RecordView(ctx context.Context, req *videopb.RecordViewRequest) (*videopb.RecordViewResponse, error) {
+	viewer := domain.Viewer{
+		UserID:    viewerUUID(ctx),
+		IP:        utils.GetClientIP(ctx),
+		UserAgent: utils.GetUserAgent(ctx),
+	}
+	watched := time.Duration(req.GetWatchedSeconds() * float64(time.Second))
+	result, err := s.views.RecordView(ctx, req.GetVideoId(), viewer, watched)
+	if err != nil {
+		return nil, err
+	}
+	return &videopb.RecordViewResponse{Counted: result == domain.ViewCounted, Result: string(result)}, nil
+}
+
+func (s *videoService) GetTrending(ctx context.Context, req *videopb.GetRankedVideosRequest) (*videopb.GetVideosResponse, error) {
+	return s.listRanking(ctx, domain.RankingTrending, req)
+}
+
+func (s *videoService) GetPopular(ctx context.Context, req *videopb.GetRankedVideosRequest) (*videopb.GetVideosResponse, error) {
+	return s.listRanking(ctx, domain.RankingPopular, req)
+}
+
+func (s *videoService) listRanking(ctx context.Context, ranking domain.Ranking, req *videopb.GetRankedVideosRequest) (*videopb.GetVideosResponse, error) {
+	resp, err := s.rankings.List(ctx, ranking, domain.RankingFetchOptions{
+		Category: domain.Category(req.GetCategory()),
+		BaseFetchOptions: domain.BaseFetchOptions{
+			Limit:     int(req.GetLimit()),
+			PageToken: req.GetPageToken(),
+			SkipTotal: req.GetSkipTotal(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToGrpcVideoPage(resp), nil
+}
+
+// tagsFromNames wraps raw tag names; the usecase derives their slugs.
+func tagsFromNames(names []string) []domain.Tag {
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{Name: name}
+	}
+	return tags
+}
+
+func convertToGrpcFacets(facets []domain.FacetCount) []*videopb.FacetCount {
+	result := make([]*videopb.FacetCount, len(facets))
+	for i, f := range facets {
+		result[i] = &videopb.FacetCount{Value: f.Value, Label: f.Label, Count: f.Count}
+	}
+	return result
+}
+
 func convertToGrpcVideo(v domain.Video) *videopb.Video {
-	return &videopb.Video{
-		Id:           v.ID.String(),
-		Title:        v.Title,
-		Description:  v.Description,
-		HlsUrl:       v.HLSUrl,
-		ThumbnailUrl: v.ThumbnailUrl,
-		Status:       string(v.Status),
-		Views:        v.Views,
-		CreatedAt:    v.CreatedAt.Format(time.RFC3339),
+	pv := &videopb.Video{
+		Id:               v.ID.String(),
+		Title:            v.Title,
+		Description:      v.Description,
+		HlsUrl:           v.HLSUrl,
+		ThumbnailUrl:     v.ThumbnailUrl,
+		Status:           string(v.Status),
+		Views:            v.Views,
+		AuthorId:         v.UserID.String(),
+		CreatedAt:        v.CreatedAt.Format(time.RFC3339),
+		Category:         string(v.Category),
+		CommentCount:     v.CommentCount,
+		CommentsDisabled: v.CommentsDisabled,
+		LikeCount:        v.LikeCount,
+		DislikeCount:     v.DislikeCount,
+	}
+	for _, t := range v.Tags {
+		pv.Tags = append(pv.Tags, &videopb.Tag{Slug: t.Slug, Name: t.Name})
 	}
+	if v.TitleHighlight != "" {
+		pv.Search = &videopb.SearchHit{
+			Score:                v.SearchRank,
+			TitleHighlight:       v.TitleHighlight,
+			DescriptionHighlight: v.DescriptionHighlight,
+		}
+	}
+	return pv
 }
 func convertToGrpcVideos(videos []domain.Video) []*videopb.Video {
 	result := make([]*videopb.Video, len(videos))
@@ -90,26 +235,61 @@ func convertToGrpcVideos(videos []domain.Video) []*videopb.Video {
 	return result
 }
 
+// convertToGrpcVideoPage builds a GetVideosResponse, leaving total out when
+// counting was skipped.
+func convertToGrpcVideoPage(resp *domain.MultipleVideoResponse) *videopb.GetVideosResponse {
+	page := &videopb.GetVideosResponse{
+		Videos:        convertToGrpcVideos(resp.Videos),
+		Page:          int32(resp.Page),
+		Limit:         int32(resp.Limit),
+		NextPageToken: resp.NextPageToken,
+	}
+	if resp.Total >= 0 {
+		page.Total = &resp.Total
+	}
+	if resp.Facets != nil {
+		page.CategoryFacets = convertToGrpcFacets(resp.Facets.Categories)
+		page.TagFacets = convertToGrpcFacets(resp.Facets.Tags)
+	}
+	return page
+}
+
 func (s *videoService) GetVideos(ctx context.Context, req *videopb.GetVideosRequest) (*videopb.GetVideosResponse, error) {
+	sort, err := domain.ParseSort(req.GetOrderBy(), domain.VideoSortFields)
+	if err != nil {
+		return nil, err
+	}
 	opts := domain.VideoFetchOptions{
-		Status: domain.VideoStatus(req.GetStatus()),
+		Status:        domain.VideoStatus(req.GetStatus()),
+		Category:      domain.Category(req.GetCategory()),
+		Tags:          req.GetTags(),
+		IncludeFacets: req.GetIncludeFacets(),
 		BaseFetchOptions: domain.BaseFetchOptions{
-			Page:  int(req.GetPage()),
-			Limit: int(req.GetLimit()),
-			Query: req.GetQuery(),
+			Page:      int(req.GetPage()),
+			Limit:     int(req.GetLimit()),
+			Query:     req.GetQuery(),
+			Sort:      sort,
+			PageToken: req.GetPageToken(),
+			SkipTotal: req.GetSkipTotal(),
 		},
 	}
 	if req.UserId != "" {
 		opts.UserID = req.GetUserId()
 	}
-	resp, err := s.usecase.Find(opts)
+	viewer := viewerID(ctx)
+	if req.GetMine() {
+		if viewer == "" {
+			return nil, status.Error(codes.Unauthenticated, "mine requires authentication")
+		}
+		opts.UserID = viewer
+	}
+	// Uploads that are not ready yet are only listed for their owner.
+	if viewer == "" || opts.UserID != viewer {
+		opts.Status = domain.VideoStatusReady
+	}
+	resp, err := s.usecase.Find(ctx, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error getting multiple videos: %v", err)
+		return nil, err
 	}
-	return &videopb.GetVideosResponse{
-		Videos: convertToGrpcVideos(resp.Videos),
-		Page:   int32(resp.Page),
-		Limit:  int32(resp.Limit),
-		Total:  resp.Total,
-	}, nil
+	return convertToGrpcVideoPage(resp), nil
 }
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// FileMailer writes messages to a directory instead of sending them, for
+// local development.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating mail directory: %v", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg domain.MailMessage) error {
+	if err := validHeader(msg.To); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405Z"), uuid.NewString())
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, compose(m.from, msg), 0o644); err != nil {
+		return fmt.Errorf("error writing mail file: %v", err)
+	}
+	slog.InfoContext(ctx, "mail written to file", "path", path, "subject", msg.Subject)
+	return nil
+}
@@ -0,0 +1,73 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// Mailer names accepted in MAILER.
+const (
+	MailerFile = "file"
+	MailerSMTP = "smtp"
+)
+
+// FromEnv builds the mailer selected by MAILER:
+//
+//   - file (default): writes each message as an .eml file to MAIL_DIR (default ./mail)
+//   - smtp: sends through SMTP_ADDR, authenticating with SMTP_USERNAME and
+//     SMTP_PASSWORD when set
+//
+// MAIL_FROM sets the sender address for both.
+func FromEnv() (domain.Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "GoStream <no-reply@gostream.local>"
+	}
+	switch name := os.Getenv("MAILER"); name {
+	case "", MailerFile:
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = "mail"
+		}
+		return NewFileMailer(dir, from)
+	case MailerSMTP:
+		addr := os.Getenv("SMTP_ADDR")
+		if addr == "" {
+			return nil, fmt.Errorf("SMTP_ADDR is required for the smtp mailer")
+		}
+		return NewSMTPMailer(addr, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer: %q", name)
+	}
+}
+
+// compose renders msg as an RFC 5322 message.
+func compose(from string, msg domain.MailMessage) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@gostream>\r\n", uuid.NewString())
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// validHeader rejects values that could inject extra headers.
+func validHeader(v string) error {
+	if strings.ContainsAny(v, "\r\n") {
+		return fmt.Errorf("header value contains a line break")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// SMTPMailer sends messages through an SMTP relay, such as a local MailHog
+// or Mailpit instance during development.
+type SMTPMailer struct {
+	addr     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(addr, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{addr: addr, username: username, password: password, from: from}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg domain.MailMessage) error {
+	if err := validHeader(msg.To); err != nil {
+		return err
+	}
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %v", err)
+	}
+	var auth smtp.Auth
+	if m.username != "" {
+		host, _, _ := net.SplitHostPort(m.addr)
+		auth = smtp.PlainAuth("", m.username, m.password, host)
+	}
+	if err := smtp.SendMail(m.addr, auth, from.Address, []string{msg.To}, compose(m.from, msg)); err != nil {
+		return fmt.Errorf("error sending mail: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "gostream"
+
+var (
+	GRPCHandledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handled_total",
+		Help:      "Total number of RPCs completed on the server, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	GRPCHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handling_seconds",
+		Help:      "Latency of RPCs handled by the server.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests served, by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	HTTPRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests, by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	StreamBytesServed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "stream",
+		Name:      "bytes_served_total",
+		Help:      "Bytes of playlists and segments served, by file type. Per-video totals are in the analytics rollups.",
+	}, []string{"type"})
+
+	TranscodeJobSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "transcode",
+		Name:      "job_duration_seconds",
+		Help:      "Wall time of a transcoding job from dequeue to ack, by result.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"result"})
+
+	TranscodeStageSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "transcode",
+		Name:      "stage_duration_seconds",
+		Help:      "Time spent in each transcoding stage (download, ffmpeg, upload).",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"stage"})
+
+	TranscodeFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transcode",
+		Name:      "failures_total",
+		Help:      "Total number of failed transcoding jobs, by reason.",
+	}, []string{"reason"})
+
+	TranscodeQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "transcode",
+		Name:      "queue_depth",
+		Help:      "Number of messages waiting in the video encoding queue.",
+	})
+
+	ViewsReportedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "views",
+		Name:      "reported_total",
+		Help:      "Total number of views reported by players, by result.",
+	}, []string{"result"})
+
+	ViewsFlushedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "views",
+		Name:      "flushed_total",
+		Help:      "Total number of counted views saved to the database.",
+	})
+
+	PlaybackEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "analytics",
+		Name:      "playback_events_total",
+		Help:      "Total number of player events ingested, by type.",
+	}, []string{"type"})
+)
+
+// RegisterDBStats exposes the connection pool statistics of db.
+func RegisterDBStats(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
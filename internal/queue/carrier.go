@@ -0,0 +1,32 @@
+package queue
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// amqpHeaderCarrier adapts AMQP message headers to the OpenTelemetry
+// propagation API so trace context travels with each job.
+type amqpHeaderCarrier amqp.Table
+
+var _ propagation.TextMapCarrier = amqpHeaderCarrier(nil)
+
+func (c amqpHeaderCarrier) Get(key string) string {
+	v, ok := c[key].(string)
+	if !ok {
+		return ""
+	}
+	return v
+}
+
+func (c amqpHeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c amqpHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
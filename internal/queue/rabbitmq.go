@@ -3,18 +3,28 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hunderaweke/gostream/internal/database"
 	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/internal/metrics"
+	"github.com/hunderaweke/gostream/internal/tracing"
 	"github.com/minio/minio-go/v7"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type RabbitMQ struct {
@@ -51,7 +61,16 @@ type VideoMessage struct {
 	FilePath string `json:"file_path,omitempty"`
 }
 
-func (r *RabbitMQ) PublishVideoUploaded(videoId, filePath string) error {
+func (r *RabbitMQ) PublishVideoUploaded(ctx context.Context, videoId, filePath string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "publish "+r.queueName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingDestinationName(r.queueName),
+			attribute.String("video.id", videoId),
+		),
+	)
+	defer span.End()
 	msg := VideoMessage{
 		VideoID:  videoId,
 		FilePath: filePath,
@@ -60,19 +79,25 @@ func (r *RabbitMQ) PublishVideoUploaded(videoId, filePath string) error {
 	if err != nil {
 		return fmt.Errorf("error parsing video message: %v", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, amqpHeaderCarrier(headers))
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	err = r.Channel.PublishWithContext(ctx, "", r.queueName, false, false, amqp.Publishing{
 		ContentType:  "application/json",
+		MessageId:    uuid.NewString(),
+		Headers:      headers,
 		Body:         body,
 		DeliveryMode: amqp.Persistent,
 	})
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "publish failed")
 		return fmt.Errorf("error publishing the event %v", err)
 	}
 	return nil
 }
-func (r *RabbitMQ) ConsumeVideoQueue(ctx context.Context, minioClient *database.MinioClient, usecase domain.VideoService) error {
+func (r *RabbitMQ) ConsumeVideoQueue(ctx context.Context, minioClient *database.MinioClient, usecase domain.VideoService, feeds domain.SubscriptionService) error {
 	msgs, err := r.Channel.Consume(
 		r.queueName,
 		"",
@@ -88,57 +113,206 @@ func (r *RabbitMQ) ConsumeVideoQueue(ctx context.Context, minioClient *database.
 	for {
 		select {
 		case <-ctx.Done():
-			log.Println("context cancelled")
+			slog.InfoContext(ctx, "video queue consumer stopped")
 			return nil
 		case d, ok := <-msgs:
 			if !ok {
 				return nil
 			}
-			log.Printf("Received a message: %s", d.Body)
-			var job VideoMessage
-			if err := json.Unmarshal(d.Body, &job); err != nil {
-				log.Printf("Error decoding message: %v", err)
-				d.Nack(false, false)
-				continue
-			}
-			if err := processVideo(minioClient, job); err != nil {
-				d.Nack(false, false)
-				log.Printf("Job Failed: %v", err)
-				usecase.UpdateStatus(job.VideoID, domain.VideoStatusFailed)
-				continue
-			}
-			usecase.UpdateStatus(job.VideoID, domain.VideoStatusReady)
-			d.Ack(false)
+			r.handleDelivery(ctx, d, minioClient, usecase, feeds)
+		}
+	}
+}
 
+// handleDelivery processes one encoding job, continuing the trace started by
+// the publisher when the message carries trace context headers. Once the
+// video is ready it is delivered to the feeds of its channel's subscribers.
+func (r *RabbitMQ) handleDelivery(ctx context.Context, d amqp.Delivery, minioClient *database.MinioClient, usecase domain.VideoService, feeds domain.SubscriptionService) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, amqpHeaderCarrier(d.Headers))
+	ctx, span := tracing.Tracer().Start(ctx, "process "+r.queueName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingDestinationName(r.queueName),
+		),
+	)
+	defer span.End()
+
+	jobID := d.MessageId
+	if jobID == "" {
+		jobID = strconv.FormatUint(d.DeliveryTag, 10)
+	}
+	logger := slog.With("job_id", jobID)
+	logger.InfoContext(ctx, "received video job", "body_bytes", len(d.Body), "redelivered", d.Redelivered)
+	start := time.Now()
+	var job VideoMessage
+	if err := json.Unmarshal(d.Body, &job); err != nil {
+		logger.ErrorContext(ctx, "error decoding video job", "error", err)
+		metrics.TranscodeFailuresTotal.WithLabelValues("decode").Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "decode failed")
+		d.Nack(false, false)
+		return
+	}
+	span.SetAttributes(attribute.String("video.id", job.VideoID), attribute.String("job.id", jobID))
+	logger = logger.With("video_id", job.VideoID)
+	if err := processVideo(ctx, logger, minioClient, job); err != nil {
+		// A shutdown kills ffmpeg through ctx; the upload is fine, so the job
+		// goes back to the queue for the next worker instead of failing.
+		if ctx.Err() != nil {
+			d.Nack(false, true)
+			logger.WarnContext(ctx, "video job interrupted by shutdown, requeued", "error", err)
+			span.SetStatus(codes.Error, "interrupted")
+			return
+		}
+		d.Nack(false, false)
+		logger.ErrorContext(ctx, "video job failed",
+			"reason", failureReason(err),
+			"error", err,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+		metrics.TranscodeFailuresTotal.WithLabelValues(failureReason(err)).Inc()
+		metrics.TranscodeJobSeconds.WithLabelValues("failed").Observe(time.Since(start).Seconds())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, failureReason(err)+" failed")
+		if err := usecase.UpdateStatus(ctx, job.VideoID, domain.VideoStatusFailed); err != nil {
+			logger.ErrorContext(ctx, "marking video as failed did not succeed", "error", err)
+		}
+		return
+	}
+	// The job is only done once the video is marked ready; otherwise it is
+	// retried, and the video stays out of the feeds while it is hidden.
+	if err := usecase.UpdateStatus(ctx, job.VideoID, domain.VideoStatusReady); err != nil {
+		d.Nack(false, true)
+		logger.ErrorContext(ctx, "marking video ready failed, requeued", "error", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "status update failed")
+		return
+	}
+	d.Ack(false)
+	logger.InfoContext(ctx, "video job finished", "duration_ms", time.Since(start).Milliseconds())
+	metrics.TranscodeJobSeconds.WithLabelValues("ready").Observe(time.Since(start).Seconds())
+	fanOut(ctx, logger, feeds, job.VideoID)
+}
+
+// fanOut adds a newly ready video to its subscribers' feeds. The job is
+// already acknowledged, so a failure is only logged rather than causing the
+// video to be transcoded again.
+func fanOut(ctx context.Context, logger *slog.Logger, feeds domain.SubscriptionService, videoID string) {
+	ctx, span := tracing.Tracer().Start(ctx, "fan out video")
+	defer span.End()
+	start := time.Now()
+	added, err := feeds.FanOut(ctx, videoID)
+	if err != nil {
+		logger.ErrorContext(ctx, "feed fan-out failed", "error", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fan-out failed")
+		return
+	}
+	span.SetAttributes(attribute.Int64("feed.entries", added))
+	logger.InfoContext(ctx, "video added to feeds", "entries", added, "duration_ms", time.Since(start).Milliseconds())
+}
+
+// WatchQueueDepth periodically reports the number of pending encoding jobs
+// until ctx is cancelled. It uses its own channel so a failed inspection
+// cannot close the consumer channel.
+func (r *RabbitMQ) WatchQueueDepth(ctx context.Context, interval time.Duration) error {
+	ch, err := r.Conn.Channel()
+	if err != nil {
+		return fmt.Errorf("error getting inspection channel: %v", err)
+	}
+	defer ch.Close()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		q, err := ch.QueueDeclarePassive(r.queueName, true, false, false, false, nil)
+		if err != nil {
+			return fmt.Errorf("error inspecting video encoding queue: %v", err)
+		}
+		metrics.TranscodeQueueDepth.Set(float64(q.Messages))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
 		}
 	}
 }
 
-func processVideo(minioClient *database.MinioClient, job VideoMessage) error {
+// Transcoding stages, used to label stage timings and failure reasons.
+const (
+	stageDownload = "download"
+	stageFFmpeg   = "ffmpeg"
+	stageUpload   = "upload"
+)
+
+type stageError struct {
+	stage string
+	err   error
+}
+
+func (e *stageError) Error() string { return e.err.Error() }
+func (e *stageError) Unwrap() error { return e.err }
+
+func failureReason(err error) string {
+	var se *stageError
+	if errors.As(err, &se) {
+		return se.stage
+	}
+	return "unknown"
+}
+
+// startStage opens a span for a transcoding stage; the returned function
+// records the stage duration and ends the span.
+func startStage(ctx context.Context, stage string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "transcode "+stage)
+	return ctx, func() {
+		metrics.TranscodeStageSeconds.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+		span.End()
+	}
+}
+
+// ffmpegOutputTail bounds how much ffmpeg output is kept in job errors.
+const ffmpegOutputTail = 2048
+
+func processVideo(ctx context.Context, logger *slog.Logger, minioClient *database.MinioClient, job VideoMessage) error {
 	tempDir := filepath.Join(os.TempDir(), "transcoder", job.VideoID)
 	os.MkdirAll(tempDir, 0755)
 	defer os.RemoveAll(tempDir)
 	localInput := filepath.Join(tempDir, "input.mp4")
-	log.Printf("Downloading raw video %s...", job.FilePath)
-	err := minioClient.Client.FGetObject(context.Background(), minioClient.Bucket, job.FilePath, localInput, minio.GetObjectOptions{})
+	logger.InfoContext(ctx, "downloading raw video", "object", job.FilePath)
+	stageCtx, endStage := startStage(ctx, stageDownload)
+	err := minioClient.Client.FGetObject(stageCtx, minioClient.Bucket, job.FilePath, localInput, minio.GetObjectOptions{})
+	endStage()
 	if err != nil {
-		return fmt.Errorf("download failed: %w", err)
+		return &stageError{stageDownload, fmt.Errorf("download failed: %w", err)}
 	}
 	outputPlaylist := filepath.Join(tempDir, "index.m3u8")
-	cmd := exec.Command("ffmpeg",
+	stageCtx, endStage = startStage(ctx, stageFFmpeg)
+	cmd := exec.CommandContext(stageCtx, "ffmpeg",
 		"-i", localInput,
 		"-codec:v", "libx264",
 		"-codec:a", "aac",
-		"-hls_time", "10",
+		"-hls_time", strconv.Itoa(domain.HLSSegmentSeconds),
 		"-hls_playlist_type", "vod",
 		"-hls_segment_filename", filepath.Join(tempDir, "segment_%03d.ts"),
 		"-start_number", "0",
 		outputPlaylist,
 	)
 
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("ffmpeg failed: %s", string(output))
+	logger.InfoContext(ctx, "transcoding video")
+	output, err := cmd.CombinedOutput()
+	endStage()
+	logger.DebugContext(ctx, "ffmpeg output", "output", string(output))
+	if err != nil {
+		tail := output
+		if len(tail) > ffmpegOutputTail {
+			tail = tail[len(tail)-ffmpegOutputTail:]
+		}
+		return &stageError{stageFFmpeg, fmt.Errorf("ffmpeg failed: %w: %s", err, tail)}
 	}
+	stageCtx, endStage = startStage(ctx, stageUpload)
+	defer endStage()
 	files, _ := os.ReadDir(tempDir)
 	for _, f := range files {
 		if f.Name() == "input.mp4" {
@@ -152,26 +326,25 @@ func processVideo(minioClient *database.MinioClient, job VideoMessage) error {
 		} else if strings.HasSuffix(f.Name(), ".ts") {
 			contentType = "video/MP2T"
 		}
-		ctx := context.Background()
 		bucket := "hls-videos"
-		exists, errBucketExists := minioClient.Client.BucketExists(ctx, bucket)
+		exists, errBucketExists := minioClient.Client.BucketExists(stageCtx, bucket)
 		if errBucketExists == nil && !exists {
-			log.Printf("bucket do not exist creating it ... %v", bucket)
-			if err := minioClient.Client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
-				return fmt.Errorf("failed to create bucket: %w", err)
+			logger.InfoContext(ctx, "bucket does not exist, creating it", "bucket", bucket)
+			if err := minioClient.Client.MakeBucket(stageCtx, bucket, minio.MakeBucketOptions{}); err != nil {
+				return &stageError{stageUpload, fmt.Errorf("failed to create bucket: %w", err)}
 			}
 		} else if errBucketExists != nil {
-			return fmt.Errorf("failed to check if bucket exists: %w", errBucketExists)
+			return &stageError{stageUpload, fmt.Errorf("failed to check if bucket exists: %w", errBucketExists)}
 		}
-		log.Printf("Uploading %s...", remotePath)
-		_, err := minioClient.Client.FPutObject(context.Background(), bucket, remotePath, localPath, minio.PutObjectOptions{
+		logger.DebugContext(ctx, "uploading hls file", "object", remotePath)
+		_, err := minioClient.Client.FPutObject(stageCtx, bucket, remotePath, localPath, minio.PutObjectOptions{
 			ContentType: contentType,
 		})
 		if err != nil {
-			return fmt.Errorf("upload failed for %s: %w", f.Name(), err)
+			return &stageError{stageUpload, fmt.Errorf("upload failed for %s: %w", f.Name(), err)}
 		}
 	}
-	log.Printf("Finished processing video")
+	logger.InfoContext(ctx, "uploaded hls output", "files", len(files)-1)
 	return nil
 }
 func (r *RabbitMQ) Close() {
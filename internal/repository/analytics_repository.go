@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) domain.AnalyticsRepository {
+	db.AutoMigrate(&domain.VideoAnalyticsHour{})
+	return &gormAnalyticsRepository{db: db}
+}
+
+func (r *gormAnalyticsRepository) Save(ctx context.Context, hours []domain.VideoAnalyticsHour) error {
+	if len(hours) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Omit("Video").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "video_id"}, {Name: "hour"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"views", "watch_seconds", "rebuffers", "rebuffer_ms", "bitrate_switches", "errors", "bytes_served",
+			"unique_viewers", "viewers_hll", "retention", "updated_at",
+		}),
+	}).CreateInBatches(hours, 500).Error
+	if err != nil {
+		// Rollups of videos deleted since their events arrived fail the
+		// foreign key; the rest of the batch is worth keeping.
+		if errors.Is(err, gorm.ErrForeignKeyViolated) && len(hours) > 1 {
+			for _, hour := range hours {
+				err := r.Save(ctx, []domain.VideoAnalyticsHour{hour})
+				if err != nil && !errors.Is(err, gorm.ErrForeignKeyViolated) {
+					return err
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to save analytics: %w", err)
+	}
+	return nil
+}
+
+func (r *gormAnalyticsRepository) Find(ctx context.Context, videoID uuid.UUID, from, to time.Time) ([]domain.VideoAnalyticsHour, error) {
+	var hours []domain.VideoAnalyticsHour
+	err := r.db.WithContext(ctx).
+		Where("video_id = ? AND hour >= ? AND hour < ?", videoID, from, to).
+		Order("hour").Find(&hours).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find analytics: %w", err)
+	}
+	return hours, nil
+}
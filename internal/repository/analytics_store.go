@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	analyticsCountersKeyPrefix  = "analytics:counters:"
+	analyticsViewersKeyPrefix   = "analytics:viewers:"
+	analyticsSessionsKeyPrefix  = "analytics:sessions:"
+	analyticsRetentionKeyPrefix = "analytics:retention:"
+	analyticsDirtyKey           = "analytics:dirty"
+	analyticsMergeKeyPrefix     = "analytics:merge:"
+	// analyticsHourTTL keeps an hour in Redis long enough for its rollup to
+	// be retried through a database outage.
+	analyticsHourTTL = 25 * time.Hour
+)
+
+// reachScript records the furthest segment a session reached. The retention
+// hash counts sessions by the furthest segment they reached, so moving a
+// session forward moves it from one field to another.
+var reachScript = redis.NewScript(`
+local previous = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '-1')
+local segment = tonumber(ARGV[2])
+if segment <= previous then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], segment)
+if previous >= 0 then
+	redis.call('HINCRBY', KEYS[2], previous, -1)
+end
+redis.call('HINCRBY', KEYS[2], segment, 1)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+return 1
+`)
+
+type redisAnalyticsStore struct {
+	rdb *redis.Client
+}
+
+func NewAnalyticsStore(rdb *redis.Client) domain.AnalyticsStore {
+	return &redisAnalyticsStore{rdb: rdb}
+}
+
+// analyticsMember names a video's hour in the dirty set and in its keys.
+func analyticsMember(videoID uuid.UUID, hour time.Time) string {
+	return videoID.String() + ":" + strconv.FormatInt(hour.Unix(), 10)
+}
+
+func parseAnalyticsMember(member string) (uuid.UUID, time.Time, bool) {
+	id, unix, ok := strings.Cut(member, ":")
+	if !ok {
+		return uuid.Nil, time.Time{}, false
+	}
+	videoID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return uuid.Nil, time.Time{}, false
+	}
+	return videoID, time.Unix(sec, 0).UTC(), true
+}
+
+func (s *redisAnalyticsStore) Record(ctx context.Context, viewer string, events []domain.PlaybackEvent, at time.Time) error {
+	hour := at.UTC().Truncate(time.Hour)
+	ttl := int64(analyticsHourTTL.Seconds())
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		touched := map[string]bool{}
+		for _, e := range events {
+			member := analyticsMember(e.VideoID, hour)
+			counters := analyticsCountersKeyPrefix + member
+			switch e.Type {
+			case domain.PlaybackStart:
+				pipe.HIncrBy(ctx, counters, "views", 1)
+				pipe.PFAdd(ctx, analyticsViewersKeyPrefix+member, viewer)
+				pipe.Expire(ctx, analyticsViewersKeyPrefix+member, analyticsHourTTL)
+			case domain.PlaybackRebuffer:
+				pipe.HIncrBy(ctx, counters, "rebuffers", 1)
+				pipe.HIncrBy(ctx, counters, "rebuffer_ms", e.RebufferMs)
+			case domain.PlaybackBitrateSwitch:
+				pipe.HIncrBy(ctx, counters, "bitrate_switches", 1)
+			case domain.PlaybackError:
+				pipe.HIncrBy(ctx, counters, "errors", 1)
+			case domain.PlaybackWatchTime:
+				pipe.HIncrByFloat(ctx, counters, "watch_seconds", e.WatchSeconds)
+			}
+			if e.SegmentIndex >= 0 {
+				keys := []string{analyticsSessionsKeyPrefix + member, analyticsRetentionKeyPrefix + member}
+				reachScript.Eval(ctx, pipe, keys, e.SessionID, e.SegmentIndex, ttl)
+			}
+			if !touched[member] {
+				touched[member] = true
+				pipe.Expire(ctx, counters, analyticsHourTTL)
+				pipe.SAdd(ctx, analyticsDirtyKey, member)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording playback events: %w", err)
+	}
+	return nil
+}
+
+func (s *redisAnalyticsStore) AddBytesServed(ctx context.Context, videoID uuid.UUID, bytes int64, at time.Time) error {
+	member := analyticsMember(videoID, at.UTC().Truncate(time.Hour))
+	counters := analyticsCountersKeyPrefix + member
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, counters, "bytes_served", bytes)
+		pipe.Expire(ctx, counters, analyticsHourTTL)
+		pipe.SAdd(ctx, analyticsDirtyKey, member)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("recording bytes served: %w", err)
+	}
+	return nil
+}
+
+// TakeDirty takes the dirty set in one step, like the view counts, so hours
+// changed during a rollup are rolled up again next time.
+func (s *redisAnalyticsStore) TakeDirty(ctx context.Context) ([]domain.VideoAnalyticsHour, error) {
+	members, err := takeSet(ctx, s.rdb, analyticsDirtyKey)
+	if err != nil {
+		return nil, fmt.Errorf("taking dirty analytics: %w", err)
+	}
+
+	type hourCmds struct {
+		counters  *redis.MapStringStringCmd
+		viewers   *redis.StringCmd
+		unique    *redis.IntCmd
+		retention *redis.MapStringStringCmd
+	}
+	var hours []domain.VideoAnalyticsHour
+	var cmds []hourCmds
+	_, err = s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, member := range members {
+			videoID, hour, ok := parseAnalyticsMember(member)
+			if !ok {
+				continue
+			}
+			hours = append(hours, domain.VideoAnalyticsHour{VideoID: videoID, Hour: hour})
+			cmds = append(cmds, hourCmds{
+				counters:  pipe.HGetAll(ctx, analyticsCountersKeyPrefix+member),
+				viewers:   pipe.Get(ctx, analyticsViewersKeyPrefix+member),
+				unique:    pipe.PFCount(ctx, analyticsViewersKeyPrefix+member),
+				retention: pipe.HGetAll(ctx, analyticsRetentionKeyPrefix+member),
+			})
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("reading analytics: %w", err)
+	}
+
+	rollups := make([]domain.VideoAnalyticsHour, 0, len(hours))
+	for i, h := range hours {
+		if err := cmds[i].counters.Err(); err != nil {
+			return nil, fmt.Errorf("reading analytics: %w", err)
+		}
+		counters := cmds[i].counters.Val()
+		// Hours that expired before being rolled up would overwrite their
+		// saved rollup with zeros.
+		if len(counters) == 0 && len(cmds[i].retention.Val()) == 0 {
+			continue
+		}
+		h.Views = parseCounter(counters["views"])
+		h.Rebuffers = parseCounter(counters["rebuffers"])
+		h.RebufferMs = parseCounter(counters["rebuffer_ms"])
+		h.BitrateSwitches = parseCounter(counters["bitrate_switches"])
+		h.Errors = parseCounter(counters["errors"])
+		h.BytesServed = parseCounter(counters["bytes_served"])
+		h.WatchSeconds, _ = strconv.ParseFloat(counters["watch_seconds"], 64)
+		if hll, err := cmds[i].viewers.Bytes(); err == nil {
+			h.ViewersHLL = hll
+			h.UniqueViewers = cmds[i].unique.Val()
+		}
+		h.Retention = retentionCurve(cmds[i].retention.Val())
+		rollups = append(rollups, h)
+	}
+	return rollups, nil
+}
+
+func parseCounter(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+// retentionCurve turns counts of sessions by the furthest segment reached
+// into counts of sessions that reached each segment.
+func retentionCurve(furthest map[string]string) []int64 {
+	var segments []int
+	counts := map[int]int64{}
+	for field, value := range furthest {
+		segment, err := strconv.Atoi(field)
+		if err != nil || segment < 0 || segment >= domain.MaxRetentionSegments {
+			continue
+		}
+		if n := parseCounter(value); n > 0 {
+			segments = append(segments, segment)
+			counts[segment] = n
+		}
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	sort.Ints(segments)
+	curve := make([]int64, segments[len(segments)-1]+1)
+	for _, segment := range segments {
+		curve[segment] = counts[segment]
+	}
+	for i := len(curve) - 2; i >= 0; i-- {
+		curve[i] += curve[i+1]
+	}
+	return curve
+}
+
+func (s *redisAnalyticsStore) RestoreDirty(ctx context.Context, hours []domain.VideoAnalyticsHour) error {
+	if len(hours) == 0 {
+		return nil
+	}
+	members := make([]any, len(hours))
+	for i, h := range hours {
+		members[i] = analyticsMember(h.VideoID, h.Hour)
+	}
+	if err := s.rdb.SAdd(ctx, analyticsDirtyKey, members...).Err(); err != nil {
+		return fmt.Errorf("restoring dirty analytics: %w", err)
+	}
+	return nil
+}
+
+// CountUnique loads the HyperLogLogs into temporary keys to merge them.
+func (s *redisAnalyticsStore) CountUnique(ctx context.Context, hlls [][]byte) (int64, error) {
+	prefix := analyticsMergeKeyPrefix + uuid.NewString() + ":"
+	var keys []string
+	for i, hll := range hlls {
+		if len(hll) > 0 {
+			keys = append(keys, prefix+strconv.Itoa(i))
+		}
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	dest := prefix + "merged"
+	var count *redis.IntCmd
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		i := 0
+		for _, hll := range hlls {
+			if len(hll) > 0 {
+				pipe.Set(ctx, keys[i], hll, time.Minute)
+				i++
+			}
+		}
+		pipe.PFMerge(ctx, dest, keys...)
+		count = pipe.PFCount(ctx, dest)
+		pipe.Del(ctx, append(keys, dest)...)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("counting unique viewers: %w", err)
+	}
+	return count.Val(), nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+func TestRetentionCurve(t *testing.T) {
+	tests := []struct {
+		name     string
+		furthest map[string]string
+		want     []int64
+	}{
+		{name: "no sessions", furthest: map[string]string{}},
+		{name: "one segment", furthest: map[string]string{"0": "4"}, want: []int64{4}},
+		{
+			name:     "sessions reach every earlier segment",
+			furthest: map[string]string{"0": "1", "1": "2", "2": "3"},
+			want:     []int64{6, 5, 3},
+		},
+		{
+			name:     "missing segments",
+			furthest: map[string]string{"1": "2", "4": "1"},
+			want:     []int64{3, 3, 1, 1, 1},
+		},
+		{
+			name:     "sessions moved on",
+			furthest: map[string]string{"0": "0", "1": "-1", "2": "2"},
+			want:     []int64{2, 2, 2},
+		},
+		{
+			name:     "bad fields",
+			furthest: map[string]string{"x": "5", "-1": "5", strconv.Itoa(domain.MaxRetentionSegments): "5", "1": "1"},
+			want:     []int64{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retentionCurve(tt.furthest); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("retentionCurve(%v) = %v, want %v", tt.furthest, got, tt.want)
+			}
+		})
+	}
+}
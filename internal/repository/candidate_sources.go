@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	// textSeedLexemes bounds the words of the seed videos a text search
+	// looks for, title words first.
+	textSeedLexemes = 32
+	// coWatchSeedViewers bounds the viewers of the seed videos whose other
+	// videos are counted, most recent first.
+	coWatchSeedViewers = 1000
+)
+
+// The candidate sources read tables that their own repositories migrate.
+// Each skips the seed videos and proposes only ready videos.
+
+type tagCandidateSource struct {
+	db *gorm.DB
+}
+
+// NewTagCandidateSource proposes videos sharing tags with the seed videos,
+// with a bonus for sharing a category.
+func NewTagCandidateSource(db *gorm.DB) domain.CandidateSource {
+	return &tagCandidateSource{db: db}
+}
+
+func (s *tagCandidateSource) Name() string { return "tags" }
+
+func (s *tagCandidateSource) Candidates(ctx context.Context, seed domain.RecommendationSeed, limit int) ([]domain.Candidate, error) {
+	if len(seed.Videos) == 0 {
+		return nil, nil
+	}
+	var categories []domain.Category
+	for _, v := range seed.Videos {
+		if v.Category != "" {
+			categories = append(categories, v.Category)
+		}
+	}
+	ids := seed.VideoIDs()
+	var candidates []domain.Candidate
+	err := s.db.WithContext(ctx).Raw(`SELECT v.id AS video_id,
+			COUNT(DISTINCT vt.tag_id) + CASE WHEN v.category IN ? THEN 0.5 ELSE 0 END AS score
+		FROM videos v
+		LEFT JOIN video_tags vt ON vt.video_id = v.id
+			AND vt.tag_id IN (SELECT tag_id FROM video_tags WHERE video_id IN ?)
+		WHERE v.status = ? AND v.id NOT IN ? AND (vt.tag_id IS NOT NULL OR v.category IN ?)
+		GROUP BY v.id, v.category
+		ORDER BY score DESC, v.id LIMIT ?`,
+		categories, ids, domain.VideoStatusReady, ids, categories, limit).Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find videos with shared tags: %w", err)
+	}
+	return candidates, nil
+}
+
+type textCandidateSource struct {
+	db *gorm.DB
+}
+
+// NewTextCandidateSource proposes videos whose title, tags and description
+// match the words of the seed videos, ranked by the search's ts_rank.
+func NewTextCandidateSource(db *gorm.DB) domain.CandidateSource {
+	return &textCandidateSource{db: db}
+}
+
+func (s *textCandidateSource) Name() string { return "text" }
+
+// Candidates ORs the seed's lexemes, which are already normalised, into a
+// tsquery. Quotes and backslashes are dropped so each lexeme stays one
+// quoted term.
+func (s *textCandidateSource) Candidates(ctx context.Context, seed domain.RecommendationSeed, limit int) ([]domain.Candidate, error) {
+	if len(seed.Videos) == 0 {
+		return nil, nil
+	}
+	ids := seed.VideoIDs()
+	var candidates []domain.Candidate
+	err := s.db.WithContext(ctx).Raw(`WITH lexemes AS (
+			SELECT lexeme, min(weight) AS weight FROM (
+				SELECT l.lexeme, w AS weight
+				FROM videos v, unnest(v.search_vector) l, unnest(l.weights) w
+				WHERE v.id IN ?
+			) AS words GROUP BY lexeme
+			ORDER BY weight, lexeme LIMIT ?
+		), seed AS (
+			SELECT CAST(string_agg('''' || translate(lexeme, '''\', '') || '''', ' | ') AS tsquery) AS q
+			FROM lexemes WHERE translate(lexeme, '''\', '') <> ''
+		)
+		SELECT v.id AS video_id, ts_rank(v.search_vector, seed.q, 32) AS score
+		FROM videos v, seed
+		WHERE seed.q IS NOT NULL AND v.search_vector @@ seed.q AND v.status = ? AND v.id NOT IN ?
+		ORDER BY score DESC, v.id LIMIT ?`,
+		ids, textSeedLexemes, domain.VideoStatusReady, ids, limit).Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find videos with similar text: %w", err)
+	}
+	return candidates, nil
+}
+
+type coWatchCandidateSource struct {
+	db *gorm.DB
+}
+
+// NewCoWatchCandidateSource proposes the videos most watched by the recent
+// viewers of the seed videos.
+func NewCoWatchCandidateSource(db *gorm.DB) domain.CandidateSource {
+	return &coWatchCandidateSource{db: db}
+}
+
+func (s *coWatchCandidateSource) Name() string { return "co_watch" }
+
+func (s *coWatchCandidateSource) Candidates(ctx context.Context, seed domain.RecommendationSeed, limit int) ([]domain.Candidate, error) {
+	if len(seed.Videos) == 0 {
+		return nil, nil
+	}
+	ids := seed.VideoIDs()
+	var candidates []domain.Candidate
+	err := s.db.WithContext(ctx).Raw(`SELECT w.video_id, COUNT(*) AS score
+		FROM (
+			SELECT user_id FROM watch_entries
+			WHERE video_id IN ? AND user_id <> ?
+			GROUP BY user_id ORDER BY max(watched_at) DESC LIMIT ?
+		) AS viewers
+		JOIN watch_entries w ON w.user_id = viewers.user_id
+		JOIN videos v ON v.id = w.video_id
+		WHERE v.status = ? AND w.video_id NOT IN ?
+		GROUP BY w.video_id
+		ORDER BY score DESC, w.video_id LIMIT ?`,
+		ids, seed.Viewer, coWatchSeedViewers, domain.VideoStatusReady, ids, limit).Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find co-watched videos: %w", err)
+	}
+	return candidates, nil
+}
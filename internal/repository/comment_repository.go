@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) domain.CommentRepository {
+	db.AutoMigrate(&domain.Comment{}, &domain.CommentMention{})
+	return &gormCommentRepository{db: db}
+}
+
+// countComment adds delta to the counters a visible comment contributes to:
+// the comment count of its video and the reply count of its parent.
+func countComment(tx *gorm.DB, comment *domain.Comment, delta int) error {
+	err := tx.Model(&domain.Video{}).Where("id = ?", comment.VideoID).
+		UpdateColumn("comment_count", gorm.Expr("comment_count + ?", delta)).Error
+	if err != nil {
+		return fmt.Errorf("failed to update comment count: %w", err)
+	}
+	if comment.ParentID == nil {
+		return nil
+	}
+	err = tx.Model(&domain.Comment{}).Where("id = ?", *comment.ParentID).
+		UpdateColumn("reply_count", gorm.Expr("reply_count + ?", delta)).Error
+	if err != nil {
+		return fmt.Errorf("failed to update reply count: %w", err)
+	}
+	return nil
+}
+
+// lockComment reloads a comment for update, so that concurrent changes to
+// it adjust the counters once.
+func lockComment(tx *gorm.DB, id uuid.UUID) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFound("comment", id.String())
+		}
+		return nil, fmt.Errorf("failed to lock comment: %w", err)
+	}
+	return &comment, nil
+}
+
+func (r *gormCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Video").Create(comment).Error; err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		return countComment(tx, comment, 1)
+	})
+}
+
+func (r *gormCommentRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	var comment domain.Comment
+	if err := r.db.WithContext(ctx).Preload("Mentions").Where("id = ?", id).First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFound("comment", id.String())
+		}
+		return nil, fmt.Errorf("failed to find comment: %w", err)
+	}
+	return &comment, nil
+}
+
+func (r *gormCommentRepository) UpdateBody(ctx context.Context, comment *domain.Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(comment).Select("Body", "Edited", "EditedAt").Updates(comment).Error
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&domain.CommentMention{}).Error; err != nil {
+			return fmt.Errorf("failed to clear mentions: %w", err)
+		}
+		if len(comment.Mentions) == 0 {
+			return nil
+		}
+		for i := range comment.Mentions {
+			comment.Mentions[i].CommentID = comment.ID
+		}
+		if err := tx.Create(&comment.Mentions).Error; err != nil {
+			return fmt.Errorf("failed to save mentions: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *gormCommentRepository) Delete(ctx context.Context, comment *domain.Comment, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockComment(tx, comment.ID)
+		if err != nil {
+			return err
+		}
+		if current.Deleted() {
+			return nil
+		}
+		err = tx.Model(current).Updates(map[string]any{"deleted_at": at, "body": "", "pinned": false}).Error
+		if err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		if err := tx.Where("comment_id = ?", current.ID).Delete(&domain.CommentMention{}).Error; err != nil {
+			return fmt.Errorf("failed to clear mentions: %w", err)
+		}
+		if current.Hidden {
+			return nil
+		}
+		return countComment(tx, current, -1)
+	})
+}
+
+func (r *gormCommentRepository) SetHidden(ctx context.Context, comment *domain.Comment, hidden bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockComment(tx, comment.ID)
+		if err != nil {
+			return err
+		}
+		if current.Hidden == hidden {
+			return nil
+		}
+		updates := map[string]any{"hidden": hidden}
+		if hidden {
+			updates["pinned"] = false
+		}
+		if err := tx.Model(current).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to hide comment: %w", err)
+		}
+		if current.Deleted() {
+			return nil
+		}
+		delta := 1
+		if hidden {
+			delta = -1
+		}
+		return countComment(tx, current, delta)
+	})
+}
+
+func (r *gormCommentRepository) SetPinned(ctx context.Context, comment *domain.Comment, pinned bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if pinned {
+			err := tx.Model(&domain.Comment{}).Where("video_id = ? AND pinned", comment.VideoID).
+				Update("pinned", false).Error
+			if err != nil {
+				return fmt.Errorf("failed to unpin comments: %w", err)
+			}
+		}
+		if err := tx.Model(comment).Update("pinned", pinned).Error; err != nil {
+			return fmt.Errorf("failed to pin comment: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *gormCommentRepository) Pinned(ctx context.Context, videoID uuid.UUID) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.WithContext(ctx).Preload("Mentions").
+		Where("video_id = ? AND pinned AND deleted_at IS NULL", videoID).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find pinned comment: %w", err)
+	}
+	return &comment, nil
+}
+
+func commentSortKey(field string) (sortKey, error) {
+	switch field {
+	case domain.SortCreatedAt:
+		return sortKey{expr: "comments.created_at", parse: parseTime}, nil
+	case domain.SortTop:
+		return sortKey{expr: "(comments.like_count + comments.reply_count)", parse: parseInt}, nil
+	}
+	return sortKey{}, fmt.Errorf("unsupported comment sort %q", field)
+}
+
+func (r *gormCommentRepository) Find(ctx context.Context, opts domain.CommentFetchOptions) ([]domain.Comment, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Comment{}).Where("comments.video_id = ?", opts.VideoID)
+	if opts.ParentID != nil {
+		query = query.Where("comments.parent_id = ?", *opts.ParentID)
+	} else {
+		query = query.Where("comments.parent_id IS NULL")
+	}
+	// Deleted comments stay as placeholders for their replies.
+	query = query.Where("comments.deleted_at IS NULL OR comments.reply_count > 0")
+	if !opts.ShowHidden {
+		query = query.Where("NOT comments.hidden OR comments.user_id = ?", opts.Viewer)
+	}
+	if opts.SkipPinned {
+		query = query.Where("NOT comments.pinned")
+	}
+	key, err := commentSortKey(opts.Sort.Field)
+	if err != nil {
+		return nil, err
+	}
+	query, err = paginate(query, key, "comments.id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, err
+	}
+	if opts.After == nil {
+		query = query.Offset(opts.Offset)
+	}
+	var comments []domain.Comment
+	if err := query.Preload("Mentions").Limit(opts.Limit).Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	return comments, nil
+}
+
+func (r *gormCommentRepository) SetCommentsDisabled(ctx context.Context, videoID uuid.UUID, disabled bool) error {
+	result := r.db.WithContext(ctx).Model(&domain.Video{}).Where("id = ?", videoID).
+		UpdateColumn("comments_disabled", disabled)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update comment settings: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFound("video", videoID.String())
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormEmailVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) domain.EmailVerificationRepository {
+	db.AutoMigrate(&domain.EmailVerificationToken{})
+	return &gormEmailVerificationRepository{db: db}
+}
+
+func (r *gormEmailVerificationRepository) Save(ctx context.Context, token *domain.EmailVerificationToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return fmt.Errorf("saving verification token: %w", err)
+	}
+	return nil
+}
+
+func (r *gormEmailVerificationRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	if err := r.db.WithContext(ctx).First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up verification token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *gormEmailVerificationRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.EmailVerificationToken{}, "user_id = ?", userID).Error; err != nil {
+		return fmt.Errorf("deleting verification tokens: %w", err)
+	}
+	return nil
+}
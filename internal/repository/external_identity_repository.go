@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormExternalIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalIdentityRepository(db *gorm.DB) domain.ExternalIdentityRepository {
+	db.AutoMigrate(&domain.ExternalIdentity{})
+	return &gormExternalIdentityRepository{db: db}
+}
+
+func (r *gormExternalIdentityRepository) Create(ctx context.Context, identity *domain.ExternalIdentity) error {
+	if err := r.db.WithContext(ctx).Create(identity).Error; err != nil {
+		return fmt.Errorf("creating external identity: %w", err)
+	}
+	return nil
+}
+
+func (r *gormExternalIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.ExternalIdentity, error) {
+	var identity domain.ExternalIdentity
+	err := r.db.WithContext(ctx).First(&identity, "provider = ? AND subject = ?", provider, subject).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get external identity: %w", err)
+	}
+	return &identity, nil
+}
+
+func (r *gormExternalIdentityRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.ExternalIdentity, error) {
+	var identities []domain.ExternalIdentity
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&identities).Error; err != nil {
+		return nil, fmt.Errorf("listing external identities: %w", err)
+	}
+	return identities, nil
+}
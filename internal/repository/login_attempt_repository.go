@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	loginRateKeyPrefix    = "login:rate:"
+	loginFailureKeyPrefix = "login:failures:"
+	loginLockKeyPrefix    = "login:lock:"
+)
+
+type redisLoginAttemptRepository struct {
+	rdb *redis.Client
+}
+
+func NewLoginAttemptRepository(rdb *redis.Client) domain.LoginAttemptRepository {
+	return &redisLoginAttemptRepository{rdb: rdb}
+}
+
+// CountAttempt keeps one sorted-set member per attempt, scored by its time in
+// milliseconds, and trims members that have left the window.
+func (r *redisLoginAttemptRepository) CountAttempt(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, error) {
+	redisKey := loginRateKeyPrefix + key
+	nowMs := now.UnixMilli()
+	var card *redis.IntCmd
+	var oldest *redis.ZSliceCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, redisKey, "-inf", strconv.FormatInt(nowMs-window.Milliseconds(), 10))
+		pipe.ZAdd(ctx, redisKey, redis.Z{Score: float64(nowMs), Member: uuid.NewString()})
+		card = pipe.ZCard(ctx, redisKey)
+		oldest = pipe.ZRangeWithScores(ctx, redisKey, 0, 0)
+		pipe.PExpire(ctx, redisKey, window)
+		return nil
+	})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("counting login attempt: %w", err)
+	}
+	oldestAt := now
+	if z := oldest.Val(); len(z) > 0 {
+		oldestAt = time.UnixMilli(int64(z[0].Score))
+	}
+	return card.Val(), oldestAt, nil
+}
+
+func (r *redisLoginAttemptRepository) RecordFailure(ctx context.Context, username string, ttl time.Duration) (int64, error) {
+	key := loginFailureKeyPrefix + username
+	var count *redis.IntCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("recording login failure: %w", err)
+	}
+	return count.Val(), nil
+}
+
+func (r *redisLoginAttemptRepository) ClearFailures(ctx context.Context, username string) error {
+	if err := r.rdb.Del(ctx, loginFailureKeyPrefix+username).Err(); err != nil {
+		return fmt.Errorf("clearing login failures: %w", err)
+	}
+	return nil
+}
+
+func (r *redisLoginAttemptRepository) Lock(ctx context.Context, username string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := r.rdb.Set(ctx, loginLockKeyPrefix+username, until.UnixMilli(), ttl).Err(); err != nil {
+		return fmt.Errorf("locking account: %w", err)
+	}
+	return nil
+}
+
+func (r *redisLoginAttemptRepository) LockedUntil(ctx context.Context, username string) (time.Time, error) {
+	ms, err := r.rdb.Get(ctx, loginLockKeyPrefix+username).Int64()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("checking account lock: %w", err)
+	}
+	return time.UnixMilli(ms), nil
+}
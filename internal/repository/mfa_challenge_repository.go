@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const mfaChallengeKeyPrefix = "mfa:challenge:"
+
+type redisMFAChallengeRepository struct {
+	rdb *redis.Client
+}
+
+func NewMFAChallengeRepository(rdb *redis.Client) domain.MFAChallengeRepository {
+	return &redisMFAChallengeRepository{rdb: rdb}
+}
+
+func (r *redisMFAChallengeRepository) CountAttempt(ctx context.Context, id string, ttl time.Duration) (int64, error) {
+	key := mfaChallengeKeyPrefix + id
+	var count *redis.IntCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.HIncrBy(ctx, key, "attempts", 1)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("counting mfa attempt: %w", err)
+	}
+	return count.Val(), nil
+}
+
+func (r *redisMFAChallengeRepository) Use(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	key := mfaChallengeKeyPrefix + id
+	var used *redis.BoolCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		used = pipe.HSetNX(ctx, key, "used", 1)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("using mfa challenge: %w", err)
+	}
+	return used.Val(), nil
+}
+
+func (r *redisMFAChallengeRepository) Release(ctx context.Context, id string) error {
+	if err := r.rdb.HDel(ctx, mfaChallengeKeyPrefix+id, "used").Err(); err != nil {
+		return fmt.Errorf("releasing mfa challenge: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormMFARepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) domain.MFARepository {
+	db.AutoMigrate(&domain.RecoveryCode{})
+	return &gormMFARepository{db: db}
+}
+
+func (r *gormMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, hashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.RecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+			return fmt.Errorf("deleting recovery codes: %w", err)
+		}
+		codes := make([]domain.RecoveryCode, len(hashes))
+		for i, h := range hashes {
+			codes[i] = domain.RecoveryCode{UserID: userID, CodeHash: h}
+		}
+		if err := tx.Create(&codes).Error; err != nil {
+			return fmt.Errorf("saving recovery codes: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *gormMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("using recovery code: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormMFARepository) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.RecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+		return fmt.Errorf("deleting recovery codes: %w", err)
+	}
+	return nil
+}
+
+func (r *gormMFARepository) AdvanceTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, fmt.Errorf("recording totp step: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
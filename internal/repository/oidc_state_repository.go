@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const oidcStateKeyPrefix = "oidc:state:"
+
+type redisOIDCStateRepository struct {
+	rdb *redis.Client
+}
+
+func NewOIDCStateRepository(rdb *redis.Client) domain.OIDCStateRepository {
+	return &redisOIDCStateRepository{rdb: rdb}
+}
+
+func (r *redisOIDCStateRepository) Save(ctx context.Context, state string, login domain.OIDCLoginState, ttl time.Duration) error {
+	data, err := json.Marshal(login)
+	if err != nil {
+		return fmt.Errorf("encoding oidc state: %w", err)
+	}
+	if err := r.rdb.Set(ctx, oidcStateKeyPrefix+state, data, ttl).Err(); err != nil {
+		return fmt.Errorf("saving oidc state: %w", err)
+	}
+	return nil
+}
+
+func (r *redisOIDCStateRepository) Take(ctx context.Context, state string) (*domain.OIDCLoginState, error) {
+	data, err := r.rdb.GetDel(ctx, oidcStateKeyPrefix+state).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading oidc state: %w", err)
+	}
+	var login domain.OIDCLoginState
+	if err := json.Unmarshal(data, &login); err != nil {
+		return nil, fmt.Errorf("decoding oidc state: %w", err)
+	}
+	return &login, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// sortKey maps a whitelisted sort field onto SQL. parse converts a cursor
+// value back into a query argument of the column's type.
+type sortKey struct {
+	expr  string
+	args  []any
+	parse func(string) (any, error)
+}
+
+func parseText(v string) (any, error) { return v, nil }
+
+func parseTime(v string) (any, error) { return time.Parse(time.RFC3339Nano, v) }
+
+func parseInt(v string) (any, error) { return strconv.ParseInt(v, 10, 64) }
+
+func parseFloat(v string) (any, error) { return strconv.ParseFloat(v, 64) }
+
+var errCursorValue = domain.NewFieldError("page_token", "is invalid")
+
+// paginate orders query by key and then idColumn, both in the sort's
+// direction, and when after is set keeps only the rows that follow it.
+func paginate(query *gorm.DB, key sortKey, idColumn string, sort domain.Sort, after *domain.Cursor) (*gorm.DB, error) {
+	dir, cmp := "ASC", ">"
+	if sort.Desc {
+		dir, cmp = "DESC", "<"
+	}
+	if after != nil {
+		value, err := key.parse(after.Value)
+		if err != nil {
+			return nil, errCursorValue
+		}
+		args := append(slices.Clone(key.args), value, after.ID)
+		query = query.Where("("+key.expr+", "+idColumn+") "+cmp+" (?, ?)", args...)
+	}
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                key.expr + " " + dir + ", " + idColumn + " " + dir,
+		Vars:               key.args,
+		WithoutParentheses: true,
+	}}), nil
+}
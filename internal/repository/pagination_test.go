@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// dryRunDB builds statements without connecting to a database.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestPaginate(t *testing.T) {
+	id := uuid.New()
+	at := time.Date(2026, 1, 1, 12, 0, 0, 123456789, time.UTC)
+	createdAt := sortKey{expr: "videos.created_at", parse: parseTime}
+	top := sortKey{expr: "(comments.like_count + comments.reply_count)", parse: parseInt}
+	relevance := sortKey{expr: "ts_rank(videos.search, query) + ?", args: []any{0.5}, parse: parseFloat}
+
+	tests := []struct {
+		name     string
+		key      sortKey
+		sort     domain.Sort
+		after    *domain.Cursor
+		wantSQL  string
+		wantVars []any
+		wantErr  error
+	}{
+		{
+			name:    "first page",
+			key:     createdAt,
+			sort:    domain.Sort{Field: domain.SortCreatedAt, Desc: true},
+			wantSQL: `SELECT * FROM "videos" ORDER BY videos.created_at DESC, videos.id DESC`,
+		},
+		{
+			name:     "descending",
+			key:      createdAt,
+			sort:     domain.Sort{Field: domain.SortCreatedAt, Desc: true},
+			after:    &domain.Cursor{Value: at.Format(time.RFC3339Nano), ID: id},
+			wantSQL:  `SELECT * FROM "videos" WHERE (videos.created_at, videos.id) < ($1, $2) ORDER BY videos.created_at DESC, videos.id DESC`,
+			wantVars: []any{at, id},
+		},
+		{
+			name:     "ascending",
+			key:      top,
+			sort:     domain.Sort{Field: domain.SortTop},
+			after:    &domain.Cursor{Value: "42", ID: id},
+			wantSQL:  `SELECT * FROM "videos" WHERE ((comments.like_count + comments.reply_count), videos.id) > ($1, $2) ORDER BY (comments.like_count + comments.reply_count) ASC, videos.id ASC`,
+			wantVars: []any{int64(42), id},
+		},
+		{
+			name:     "key with arguments",
+			key:      relevance,
+			sort:     domain.Sort{Field: domain.SortRelevance, Desc: true},
+			after:    &domain.Cursor{Value: "0.25", ID: id},
+			wantSQL:  `SELECT * FROM "videos" WHERE (ts_rank(videos.search, query) + $1, videos.id) < ($2, $3) ORDER BY ts_rank(videos.search, query) + $4 DESC, videos.id DESC`,
+			wantVars: []any{0.5, 0.25, id, 0.5},
+		},
+		{
+			name:    "value of the wrong type",
+			key:     createdAt,
+			sort:    domain.Sort{Field: domain.SortCreatedAt, Desc: true},
+			after:   &domain.Cursor{Value: "42", ID: id},
+			wantErr: errCursorValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := paginate(dryRunDB(t).Table("videos"), tt.key, "videos.id", tt.sort, tt.after)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			stmt := query.Find(&[]map[string]any{}).Statement
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("SQL = %s\nwant  %s", got, tt.wantSQL)
+			}
+			if len(stmt.Vars) != 0 || len(tt.wantVars) != 0 {
+				if !reflect.DeepEqual(stmt.Vars, tt.wantVars) {
+					t.Errorf("vars = %#v, want %#v", stmt.Vars, tt.wantVars)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormPlaylistRepository struct {
+	db       *gorm.DB
+	validate *validator.Validate
+}
+
+func NewPlaylistRepository(db *gorm.DB) domain.PlaylistRepository {
+	db.AutoMigrate(&domain.Playlist{}, &domain.PlaylistItem{})
+	return &gormPlaylistRepository{
+		db:       db,
+		validate: validator.New(),
+	}
+}
+
+const playlistItemCount = "(SELECT count(*) FROM playlist_items pi WHERE pi.playlist_id = playlists.id) AS item_count"
+
+var errPlaylistFull = domain.NewFailedPrecondition("a playlist holds at most %d videos", domain.MaxPlaylistItems).WithReason("PLAYLIST_FULL")
+
+func (r *gormPlaylistRepository) Create(ctx context.Context, playlist *domain.Playlist) (*domain.Playlist, error) {
+	if err := r.validate.Struct(playlist); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Omit("Items").Create(playlist).Error; err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+	return playlist, nil
+}
+
+func (r *gormPlaylistRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Playlist, error) {
+	var playlist domain.Playlist
+	err := r.db.WithContext(ctx).Select("playlists.*, "+playlistItemCount).
+		Where("playlists.id = ?", id).First(&playlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFound("playlist", id.String())
+		}
+		return nil, fmt.Errorf("failed to find playlist: %w", err)
+	}
+	return &playlist, nil
+}
+
+func (r *gormPlaylistRepository) Update(ctx context.Context, playlist *domain.Playlist) error {
+	if err := r.validate.Struct(playlist); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Omit("Items").Save(playlist).Error; err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+	return nil
+}
+
+func (r *gormPlaylistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// Items go with the playlist through their foreign key.
+	result := r.db.WithContext(ctx).Delete(&domain.Playlist{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete playlist: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFound("playlist", id.String())
+	}
+	return nil
+}
+
+func (r *gormPlaylistRepository) Find(ctx context.Context, opts domain.PlaylistFetchOptions) ([]domain.Playlist, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Playlist{}).Where("playlists.user_id = ?", opts.UserID)
+	if len(opts.Visibilities) > 0 {
+		query = query.Where("playlists.visibility IN ?", opts.Visibilities)
+	}
+	total := int64(-1)
+	if !opts.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to count playlists: %w", err)
+		}
+	}
+	key, err := playlistSortKey(opts.Sort.Field)
+	if err != nil {
+		return nil, 0, err
+	}
+	query, err = paginate(query, key, "playlists.id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, 0, err
+	}
+	if opts.After == nil {
+		query = query.Offset(opts.Offset)
+	}
+	var playlists []domain.Playlist
+	if err := query.Select("playlists.*, " + playlistItemCount).Limit(opts.Limit).Find(&playlists).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list playlists: %w", err)
+	}
+	return playlists, total, nil
+}
+
+func playlistSortKey(field string) (sortKey, error) {
+	switch field {
+	case domain.SortUpdatedAt:
+		return sortKey{expr: "playlists.updated_at", parse: parseTime}, nil
+	case domain.SortCreatedAt:
+		return sortKey{expr: "playlists.created_at", parse: parseTime}, nil
+	case domain.SortTitle:
+		return sortKey{expr: "playlists.title", parse: parseText}, nil
+	}
+	return sortKey{}, fmt.Errorf("unsupported playlist sort %q", field)
+}
+
+func (r *gormPlaylistRepository) Items(ctx context.Context, playlistID, viewer uuid.UUID) ([]domain.PlaylistItem, error) {
+	visible := r.db.Model(&domain.Video{}).Select("id").
+		Where("status = ? OR user_id = ?", domain.VideoStatusReady, viewer)
+	var items []domain.PlaylistItem
+	err := r.db.WithContext(ctx).Preload("Video").
+		Where("playlist_id = ? AND video_id IN (?)", playlistID, visible).
+		Order("position").Find(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list playlist items: %w", err)
+	}
+	return items, nil
+}
+
+// compactItemsSQL renumbers a playlist's items from 0 in their current order.
+// Deleting a video removes its items through the foreign key and leaves a
+// gap in the positions, which every change relies on being contiguous.
+const compactItemsSQL = `UPDATE playlist_items AS p SET position = r.rn - 1
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS rn FROM playlist_items WHERE playlist_id = ?) AS r
+WHERE p.id = r.id AND p.position <> r.rn - 1`
+
+// lockItems locks the playlist row, serialising changes to its positions,
+// closes gaps left by deleted videos and returns its number of items.
+func lockItems(tx *gorm.DB, playlistID uuid.UUID) (int, error) {
+	var playlist domain.Playlist
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+		Where("id = ?", playlistID).First(&playlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, domain.NewNotFound("playlist", playlistID.String())
+		}
+		return 0, fmt.Errorf("failed to lock playlist: %w", err)
+	}
+	if err := tx.Exec(compactItemsSQL, playlistID).Error; err != nil {
+		return 0, fmt.Errorf("failed to compact playlist items: %w", err)
+	}
+	var count int64
+	if err := tx.Model(&domain.PlaylistItem{}).Where("playlist_id = ?", playlistID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count playlist items: %w", err)
+	}
+	return int(count), nil
+}
+
+func findItem(tx *gorm.DB, playlistID, itemID uuid.UUID) (*domain.PlaylistItem, error) {
+	var item domain.PlaylistItem
+	if err := tx.Where("id = ? AND playlist_id = ?", itemID, playlistID).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFound("playlist_item", itemID.String())
+		}
+		return nil, fmt.Errorf("failed to find playlist item: %w", err)
+	}
+	return &item, nil
+}
+
+// shiftItems moves the items of a playlist in positions [from, to] by delta.
+func shiftItems(tx *gorm.DB, playlistID uuid.UUID, from, to, delta int) error {
+	err := tx.Model(&domain.PlaylistItem{}).
+		Where("playlist_id = ? AND position BETWEEN ? AND ?", playlistID, from, to).
+		Update("position", gorm.Expr("position + ?", delta)).Error
+	if err != nil {
+		return fmt.Errorf("failed to reorder playlist items: %w", err)
+	}
+	return nil
+}
+
+// touchPlaylist bumps the playlist's updated_at, which orders listings.
+func touchPlaylist(tx *gorm.DB, playlistID uuid.UUID) error {
+	if err := tx.Model(&domain.Playlist{}).Where("id = ?", playlistID).Update("updated_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+	return nil
+}
+
+func (r *gormPlaylistRepository) AddItem(ctx context.Context, item *domain.PlaylistItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count, err := lockItems(tx, item.PlaylistID)
+		if err != nil {
+			return err
+		}
+		if count >= domain.MaxPlaylistItems {
+			return errPlaylistFull
+		}
+		if item.Position < 0 || item.Position > count {
+			item.Position = count
+		}
+		if err := shiftItems(tx, item.PlaylistID, item.Position, count, 1); err != nil {
+			return err
+		}
+		if err := tx.Omit("Video").Create(item).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return domain.NewConflict("video is already in the playlist").WithReason("ALREADY_IN_PLAYLIST")
+			}
+			return fmt.Errorf("failed to add playlist item: %w", err)
+		}
+		return touchPlaylist(tx, item.PlaylistID)
+	})
+}
+
+func (r *gormPlaylistRepository) RemoveItem(ctx context.Context, playlistID, itemID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count, err := lockItems(tx, playlistID)
+		if err != nil {
+			return err
+		}
+		item, err := findItem(tx, playlistID, itemID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(item).Error; err != nil {
+			return fmt.Errorf("failed to remove playlist item: %w", err)
+		}
+		if err := shiftItems(tx, playlistID, item.Position+1, count, -1); err != nil {
+			return err
+		}
+		return touchPlaylist(tx, playlistID)
+	})
+}
+
+func (r *gormPlaylistRepository) MoveItem(ctx context.Context, playlistID, itemID uuid.UUID, position int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count, err := lockItems(tx, playlistID)
+		if err != nil {
+			return err
+		}
+		item, err := findItem(tx, playlistID, itemID)
+		if err != nil {
+			return err
+		}
+		position = min(max(position, 0), count-1)
+		switch {
+		case position < item.Position:
+			err = shiftItems(tx, playlistID, position, item.Position-1, 1)
+		case position > item.Position:
+			err = shiftItems(tx, playlistID, item.Position+1, position, -1)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(item).Update("position", position).Error; err != nil {
+			return fmt.Errorf("failed to move playlist item: %w", err)
+		}
+		return touchPlaylist(tx, playlistID)
+	})
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormRankingRepository struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewRankingRepository reads the videos, reactions and analytics tables,
+// which their own repositories migrate.
+func NewRankingRepository(db *gorm.DB) domain.RankingRepository {
+	return &gormRankingRepository{db: db, now: time.Now}
+}
+
+// decay is the SQL factor halving a score every half-life (the second
+// argument, in seconds) since column (after the reference time, the first
+// argument). It bottoms out instead of underflowing, which Postgres reports
+// as an error.
+func decay(column string) string {
+	return "exp(GREATEST(-ln(2.0) * CAST(EXTRACT(EPOCH FROM (CAST(? AS timestamptz) - " + column + ")) AS double precision) / ?, -700))"
+}
+
+// topScores keeps the size best scores overall and in each category, so
+// that a category listing is as deep as the overall one.
+func (r *gormRankingRepository) topScores(ctx context.Context, scores string, args []any, size int) ([]domain.RankedVideo, error) {
+	var videos []domain.RankedVideo
+	err := r.db.WithContext(ctx).Raw(`SELECT video_id, category, score FROM (
+		SELECT video_id, category, score,
+			ROW_NUMBER() OVER (ORDER BY score DESC, video_id) AS overall,
+			ROW_NUMBER() OVER (PARTITION BY category ORDER BY score DESC, video_id) AS in_category
+		FROM (`+scores+`) AS scores WHERE score > 0
+	) AS ranked WHERE overall <= ? OR in_category <= ?`, append(args, size, size)...).Scan(&videos).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute scores: %w", err)
+	}
+	return videos, nil
+}
+
+// TrendingScores sums the window's counted views and reactions, each
+// decayed by its age, and decays the total by the video's age. Playback
+// starts are left out: anyone can report them, any number of times.
+func (r *gormRankingRepository) TrendingScores(ctx context.Context, windowHours int, weights domain.RankingWeights, size int) ([]domain.RankedVideo, error) {
+	now := r.now()
+	since := now.Add(-time.Duration(windowHours) * time.Hour)
+	scores := `SELECT v.id AS video_id, v.category,
+			(COALESCE(a.views, 0) + COALESCE(rx.reactions, 0)) * ` + decay("v.created_at") + ` AS score
+		FROM videos v
+		LEFT JOIN (
+			SELECT video_id, SUM(counted_views * ` + decay("hour") + `) AS views
+			FROM video_analytics_hours WHERE hour >= ? AND counted_views > 0 GROUP BY video_id
+		) a ON a.video_id = v.id
+		LEFT JOIN (
+			SELECT target_id, SUM(CASE WHEN kind = ? THEN CAST(? AS double precision) ELSE -CAST(? AS double precision) END * ` + decay("updated_at") + `) AS reactions
+			FROM reactions WHERE target_type = ? AND updated_at >= ? GROUP BY target_id
+		) rx ON rx.target_id = v.id
+		WHERE v.status = ? AND (a.video_id IS NOT NULL OR rx.target_id IS NOT NULL)`
+	args := []any{
+		now, weights.AgeHalfLife,
+		now, weights.ActivityHalfLife, since,
+		domain.ReactionLike, weights.Like, weights.Dislike, now, weights.ActivityHalfLife, domain.ReactionTargetVideo, since,
+		domain.VideoStatusReady,
+	}
+	return r.topScores(ctx, scores, args, size)
+}
+
+// PopularScores weighs a video's counters and decays them by its age.
+func (r *gormRankingRepository) PopularScores(ctx context.Context, weights domain.RankingWeights, size int) ([]domain.RankedVideo, error) {
+	scores := `SELECT id AS video_id, category,
+			(views + CAST(? AS double precision) * like_count - CAST(? AS double precision) * dislike_count) * ` + decay("created_at") + ` AS score
+		FROM videos WHERE status = ?`
+	args := []any{weights.Like, weights.Dislike, r.now(), weights.AgeHalfLife, domain.VideoStatusReady}
+	return r.topScores(ctx, scores, args, size)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const rankingKeyPrefix = "ranking:"
+
+type redisRankingStore struct {
+	rdb *redis.Client
+}
+
+func NewRankingStore(rdb *redis.Client) domain.RankingStore {
+	return &redisRankingStore{rdb: rdb}
+}
+
+// rankingKey is the sorted set of a ranking, or of its videos in category
+// when one is given.
+func rankingKey(ranking domain.Ranking, category domain.Category) string {
+	if category == "" {
+		return rankingKeyPrefix + string(ranking)
+	}
+	return rankingKeyPrefix + string(ranking) + ":" + string(category)
+}
+
+// Replace fills new sorted sets under temporary keys and renames them over
+// the current ones in a transaction, so readers never see a partial ranking.
+func (s *redisRankingStore) Replace(ctx context.Context, ranking domain.Ranking, videos []domain.RankedVideo) error {
+	suffix := ":next:" + uuid.NewString()
+	members := map[string][]redis.Z{}
+	for _, v := range videos {
+		z := redis.Z{Score: v.Score, Member: v.VideoID.String()}
+		members[rankingKey(ranking, "")] = append(members[rankingKey(ranking, "")], z)
+		if v.Category.Valid() {
+			members[rankingKey(ranking, v.Category)] = append(members[rankingKey(ranking, v.Category)], z)
+		}
+	}
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, zs := range members {
+			for start := 0; start < len(zs); start += 500 {
+				pipe.ZAdd(ctx, key+suffix, zs[start:min(start+500, len(zs))]...)
+			}
+			pipe.Expire(ctx, key+suffix, time.Hour)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("writing ranking: %w", err)
+	}
+	keys := []string{rankingKey(ranking, "")}
+	for _, category := range domain.Categories {
+		keys = append(keys, rankingKey(ranking, category))
+	}
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			if len(members[key]) == 0 {
+				pipe.Del(ctx, key)
+				continue
+			}
+			pipe.Rename(ctx, key+suffix, key)
+			pipe.Persist(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("replacing ranking: %w", err)
+	}
+	return nil
+}
+
+// Range continues after the cursor's video while it is still ranked, and
+// below the cursor's score once a refresh dropped it.
+func (s *redisRankingStore) Range(ctx context.Context, ranking domain.Ranking, category domain.Category, after *domain.Cursor, limit int) ([]domain.RankedVideo, error) {
+	key := rankingKey(ranking, category)
+	var start int64
+	if after != nil {
+		rank, err := s.rdb.ZRevRank(ctx, key, after.ID.String()).Result()
+		switch {
+		case err == nil:
+			start = rank + 1
+		case err == redis.Nil:
+			return s.rangeBelow(ctx, key, category, after.Value, limit)
+		default:
+			return nil, fmt.Errorf("reading ranking: %w", err)
+		}
+	}
+	zs, err := s.rdb.ZRevRangeWithScores(ctx, key, start, start+int64(limit)-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading ranking: %w", err)
+	}
+	return rankedVideos(zs, category), nil
+}
+
+func (s *redisRankingStore) rangeBelow(ctx context.Context, key string, category domain.Category, score string, limit int) ([]domain.RankedVideo, error) {
+	if _, err := strconv.ParseFloat(score, 64); err != nil {
+		return nil, errCursorValue
+	}
+	zs, err := s.rdb.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Max:   "(" + score,
+		Min:   "-inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading ranking: %w", err)
+	}
+	return rankedVideos(zs, category), nil
+}
+
+func rankedVideos(zs []redis.Z, category domain.Category) []domain.RankedVideo {
+	videos := make([]domain.RankedVideo, 0, len(zs))
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		id, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		videos = append(videos, domain.RankedVideo{VideoID: id, Category: category, Score: z.Score})
+	}
+	return videos
+}
+
+func (s *redisRankingStore) Count(ctx context.Context, ranking domain.Ranking, category domain.Category) (int64, error) {
+	n, err := s.rdb.ZCard(ctx, rankingKey(ranking, category)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("counting ranking: %w", err)
+	}
+	return n, nil
+}
+
+func (s *redisRankingStore) Lock(ctx context.Context, ranking domain.Ranking, ttl time.Duration) (bool, error) {
+	ok, err := s.rdb.SetNX(ctx, rankingKey(ranking, "")+":lock", 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("locking ranking: %w", err)
+	}
+	return ok, nil
+}
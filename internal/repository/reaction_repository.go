@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) domain.ReactionRepository {
+	db.AutoMigrate(&domain.Reaction{})
+	return &gormReactionRepository{db: db}
+}
+
+// reactionTables maps reaction targets onto the tables holding their
+// counters.
+var reactionTables = map[domain.ReactionTarget]string{
+	domain.ReactionTargetVideo:   "videos",
+	domain.ReactionTargetComment: "comments",
+}
+
+func counterColumn(kind domain.ReactionKind) string {
+	if kind == domain.ReactionDislike {
+		return "dislike_count"
+	}
+	return "like_count"
+}
+
+// countReaction moves the target's counters from one reaction kind to
+// another, either of which may be empty, and returns the new counters.
+func countReaction(tx *gorm.DB, target domain.ReactionTarget, targetID uuid.UUID, from, to domain.ReactionKind) (*domain.ReactionCounts, error) {
+	table, ok := reactionTables[target]
+	if !ok {
+		return nil, fmt.Errorf("unknown reaction target %q", target)
+	}
+	updates := map[string]any{}
+	if from != "" {
+		updates[counterColumn(from)] = gorm.Expr(counterColumn(from) + " - 1")
+	}
+	if to != "" {
+		updates[counterColumn(to)] = gorm.Expr(counterColumn(to) + " + 1")
+	}
+	result := tx.Table(table).Where("id = ?", targetID).UpdateColumns(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update reaction counts: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.NewNotFound(string(target), targetID.String())
+	}
+	// The row stays locked until commit, so this reads our own update.
+	return readCounts(tx, target, targetID, to)
+}
+
+func readCounts(tx *gorm.DB, target domain.ReactionTarget, targetID uuid.UUID, kind domain.ReactionKind) (*domain.ReactionCounts, error) {
+	var counts domain.ReactionCounts
+	result := tx.Table(reactionTables[target]).Select("like_count AS likes, dislike_count AS dislikes").
+		Where("id = ?", targetID).Scan(&counts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to read reaction counts: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.NewNotFound(string(target), targetID.String())
+	}
+	counts.Reaction = kind
+	return &counts, nil
+}
+
+// Set inserts the reaction or, when the user already reacted, locks and
+// updates their row. Concurrent calls for the same user and target are
+// serialised on that row, so each change moves the counters exactly once.
+// A Clear can delete the row between the insert and the lock; the insert is
+// then tried again.
+func (r *gormReactionRepository) Set(ctx context.Context, reaction *domain.Reaction) (*domain.ReactionCounts, error) {
+	var counts *domain.ReactionCounts
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current domain.Reaction
+		for {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+			if result.Error != nil {
+				return fmt.Errorf("failed to save reaction: %w", result.Error)
+			}
+			if result.RowsAffected == 1 {
+				var err error
+				counts, err = countReaction(tx, reaction.TargetType, reaction.TargetID, "", reaction.Kind)
+				return err
+			}
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("user_id = ? AND target_type = ? AND target_id = ?", reaction.UserID, reaction.TargetType, reaction.TargetID).
+				First(&current).Error
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to lock reaction: %w", err)
+			}
+		}
+		var err error
+		if current.Kind == reaction.Kind {
+			counts, err = readCounts(tx, reaction.TargetType, reaction.TargetID, reaction.Kind)
+			return err
+		}
+		if err := tx.Model(&current).Update("kind", reaction.Kind).Error; err != nil {
+			return fmt.Errorf("failed to update reaction: %w", err)
+		}
+		counts, err = countReaction(tx, reaction.TargetType, reaction.TargetID, current.Kind, reaction.Kind)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *gormReactionRepository) Clear(ctx context.Context, userID uuid.UUID, target domain.ReactionTarget, targetID uuid.UUID) (*domain.ReactionCounts, error) {
+	var counts *domain.ReactionCounts
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var removed domain.Reaction
+		result := tx.Clauses(clause.Returning{}).
+			Where("user_id = ? AND target_type = ? AND target_id = ?", userID, target, targetID).
+			Delete(&removed)
+		if result.Error != nil {
+			return fmt.Errorf("failed to clear reaction: %w", result.Error)
+		}
+		var err error
+		if result.RowsAffected == 0 {
+			counts, err = readCounts(tx, target, targetID, "")
+			return err
+		}
+		counts, err = countReaction(tx, target, targetID, removed.Kind, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *gormReactionRepository) LikedVideos(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) ([]domain.Video, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Video{}).
+		Joins("JOIN reactions ON reactions.target_id = videos.id AND reactions.target_type = ?", domain.ReactionTargetVideo).
+		Where("reactions.user_id = ? AND reactions.kind = ?", userID, domain.ReactionLike).
+		Where("videos.status = ? OR videos.user_id = ?", domain.VideoStatusReady, userID)
+	query, err := paginate(query, sortKey{expr: "reactions.updated_at", parse: parseTime}, "videos.id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, err
+	}
+	if opts.After == nil {
+		query = query.Offset(opts.Offset)
+	}
+	var videos []domain.Video
+	err = query.Select("videos.*, reactions.updated_at AS liked_at").
+		Preload("Tags", withTagOrder).Limit(opts.Limit).Find(&videos).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list liked videos: %w", err)
+	}
+	return videos, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) domain.SessionRepository {
+	db.AutoMigrate(&domain.Session{})
+	return &gormSessionRepository{db: db}
+}
+
+func (r *gormSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+	return nil
+}
+
+func (r *gormSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.db.WithContext(ctx).First(&session, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get session by id: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *gormSessionRepository) ListActive(ctx context.Context, userID uuid.UUID, at time.Time) ([]domain.Session, error) {
+	var sessions []domain.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at).
+		Order("last_seen_at desc").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *gormSessionRepository) Revoke(ctx context.Context, userID, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("revoking session: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormSessionRepository) RevokeAllExcept(ctx context.Context, userID, keepID uuid.UUID, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, keepID, at).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return 0, fmt.Errorf("revoking sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *gormSessionRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ?", id).
+		UpdateColumn("last_seen_at", at).Error
+	if err != nil {
+		return fmt.Errorf("updating session last seen: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// fanOutBatch bounds how many feed entries one statement of a fan-out
+// writes, so that large channels do not hold long transactions.
+const fanOutBatch = 1000
+
+type gormSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) domain.SubscriptionRepository {
+	db.AutoMigrate(&domain.Subscription{}, &domain.FeedEntry{})
+	return &gormSubscriptionRepository{db: db}
+}
+
+func (r *gormSubscriptionRepository) Subscribe(ctx context.Context, subscription *domain.Subscription) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Subscriber", "Channel").Clauses(clause.OnConflict{DoNothing: true}).Create(subscription)
+		if result.Error != nil {
+			return fmt.Errorf("failed to subscribe: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		// Older videos have no publication time of their own; their upload
+		// time stands in for it.
+		latest := tx.Model(&domain.Video{}).
+			Select("CAST(? AS uuid), videos.id, videos.user_id, videos.created_at", subscription.SubscriberID).
+			Where("videos.user_id = ? AND videos.status = ?", subscription.ChannelID, domain.VideoStatusReady).
+			Order("videos.created_at DESC").Limit(domain.FeedBackfill)
+		err := tx.Exec("INSERT INTO feed_entries (user_id, video_id, channel_id, published_at) ? ON CONFLICT DO NOTHING", latest).Error
+		if err != nil {
+			return fmt.Errorf("failed to fill feed: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *gormSubscriptionRepository) Unsubscribe(ctx context.Context, subscriberID, channelID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("subscriber_id = ? AND channel_id = ?", subscriberID, channelID).Delete(&domain.Subscription{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to unsubscribe: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		err := tx.Where("user_id = ? AND channel_id = ?", subscriberID, channelID).Delete(&domain.FeedEntry{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to clear feed: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *gormSubscriptionRepository) Find(ctx context.Context, opts domain.SubscriptionFetchOptions) ([]domain.Subscription, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Subscription{})
+	// The cursor ID is the user on the other side of the subscription.
+	idColumn, preload := "subscriptions.channel_id", "Channel"
+	if opts.SubscriberID != uuid.Nil {
+		query = query.Where("subscriptions.subscriber_id = ?", opts.SubscriberID)
+	} else {
+		query = query.Where("subscriptions.channel_id = ?", opts.ChannelID)
+		idColumn, preload = "subscriptions.subscriber_id", "Subscriber"
+	}
+	total := int64(-1)
+	if !opts.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to count subscriptions: %w", err)
+		}
+	}
+	query, err := paginate(query, sortKey{expr: "subscriptions.created_at", parse: parseTime}, idColumn, opts.Sort, opts.After)
+	if err != nil {
+		return nil, 0, err
+	}
+	if opts.After == nil {
+		query = query.Offset(opts.Offset)
+	}
+	var subscriptions []domain.Subscription
+	if err := query.Preload(preload).Limit(opts.Limit).Find(&subscriptions).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	return subscriptions, total, nil
+}
+
+func (r *gormSubscriptionRepository) FanOut(ctx context.Context, video *domain.Video, at time.Time) (int64, error) {
+	db := r.db.WithContext(ctx)
+	var added int64
+	var after *domain.Subscription
+	for {
+		query := db.Model(&domain.Subscription{}).Where("channel_id = ?", video.UserID)
+		if after != nil {
+			query = query.Where("(created_at, subscriber_id) > (?, ?)", after.CreatedAt, after.SubscriberID)
+		}
+		var batch []domain.Subscription
+		err := query.Order("created_at, subscriber_id").Limit(fanOutBatch).Find(&batch).Error
+		if err != nil {
+			return added, fmt.Errorf("failed to list subscribers: %w", err)
+		}
+		if len(batch) == 0 {
+			return added, nil
+		}
+		entries := make([]domain.FeedEntry, len(batch))
+		for i, s := range batch {
+			entries[i] = domain.FeedEntry{UserID: s.SubscriberID, VideoID: video.ID, ChannelID: video.UserID, PublishedAt: at}
+		}
+		result := db.Omit("User", "Video").Clauses(clause.OnConflict{DoNothing: true}).Create(&entries)
+		if result.Error != nil {
+			return added, fmt.Errorf("failed to fan out video: %w", result.Error)
+		}
+		added += result.RowsAffected
+		if len(batch) < fanOutBatch {
+			return added, nil
+		}
+		after = &batch[len(batch)-1]
+	}
+}
+
+func (r *gormSubscriptionRepository) Feed(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) ([]domain.Video, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Video{}).
+		Joins("JOIN feed_entries ON feed_entries.video_id = videos.id").
+		Where("feed_entries.user_id = ? AND videos.status = ?", userID, domain.VideoStatusReady)
+	query, err := paginate(query, sortKey{expr: "feed_entries.published_at", parse: parseTime}, "feed_entries.video_id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, err
+	}
+	if opts.After == nil {
+		query = query.Offset(opts.Offset)
+	}
+	var videos []domain.Video
+	err = query.Select("videos.*, feed_entries.published_at AS published_at").
+		Preload("Tags", withTagOrder).Limit(opts.Limit).Find(&videos).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+	return videos, nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,15 +26,20 @@ type passwordResetToken struct {
 	CreatedAt time.Time
 }
 
+// errUserTaken is returned when a username or email unique index rejects a
+// write. Postgres does not say which one without parsing the constraint name,
+// and both are public anyway.
+var errUserTaken = domain.NewConflict("username or email is already in use").WithReason("USER_ALREADY_EXISTS")
+
 func NewUserRepository(db *gorm.DB) *GormUserRepository {
-	db.AutoMigrate(&domain.User{})
+	db.AutoMigrate(&domain.User{}, &passwordResetToken{})
 	return &GormUserRepository{
 		db:       db,
 		validate: validator.New(),
 	}
 }
 
-func (r *GormUserRepository) Create(user *domain.User) (*domain.User, error) {
+func (r *GormUserRepository) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
 	if user == nil {
 		return nil, fmt.Errorf("user is nil")
 	}
@@ -41,41 +48,133 @@ func (r *GormUserRepository) Create(user *domain.User) (*domain.User, error) {
 		return nil, err
 	}
 
-	if err := r.db.Create(user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errUserTaken
+		}
 		return nil, fmt.Errorf("creating user: %w", err)
 	}
 	return user, nil
 }
 
-func (r *GormUserRepository) Update(user *domain.User) error {
+func (r *GormUserRepository) Update(ctx context.Context, user *domain.User) error {
 	if user == nil {
 		return fmt.Errorf("user is nil")
 	}
 	if err := r.validate.Struct(user); err != nil {
 		return err
 	}
-	if err := r.db.Save(user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errUserTaken
+		}
 		return fmt.Errorf("updating user: %w", err)
 	}
 	return nil
 }
 
-func (r *GormUserRepository) Delete(id uuid.UUID) error {
+// UpdateFields writes only the given columns, so concurrent changes to other
+// fields, such as an admin disabling the account, are not overwritten.
+func (r *GormUserRepository) UpdateFields(ctx context.Context, id uuid.UUID, fields map[string]any) error {
 	if id == uuid.Nil {
 		return fmt.Errorf("invalid id")
 	}
-	if err := r.db.Delete(&domain.User{}, "id = ?", id).Error; err != nil {
-		return fmt.Errorf("deleting user: %w", err)
+	if err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errUserTaken
+		}
+		return fmt.Errorf("updating user: %w", err)
 	}
 	return nil
 }
 
-func (r *GormUserRepository) GetByID(id uuid.UUID) (*domain.User, error) {
+func (r *GormUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND email = ?", id, email).
+		Update("email_verified", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("marking email verified: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// accountDeletion lists the statements that remove a user and their data,
+// in order. Counters of other users' videos and comments are taken back
+// before the reactions and comments behind them go. Comments that still
+// have replies become deleted placeholders, as when they are deleted one by
+// one; the rest, and everything on the user's videos, is removed.
+var accountDeletion = []struct {
+	what string
+	sql  string
+}{
+	{"undoing video reactions", `UPDATE videos AS v SET like_count = v.like_count - r.likes, dislike_count = v.dislike_count - r.dislikes
+		FROM (SELECT target_id, COUNT(*) FILTER (WHERE kind = 'LIKE') AS likes, COUNT(*) FILTER (WHERE kind = 'DISLIKE') AS dislikes
+			FROM reactions WHERE user_id = @user AND target_type = 'video' GROUP BY target_id) AS r
+		WHERE v.id = r.target_id`},
+	{"undoing comment reactions", `UPDATE comments AS c SET like_count = c.like_count - r.likes, dislike_count = c.dislike_count - r.dislikes
+		FROM (SELECT target_id, COUNT(*) FILTER (WHERE kind = 'LIKE') AS likes, COUNT(*) FILTER (WHERE kind = 'DISLIKE') AS dislikes
+			FROM reactions WHERE user_id = @user AND target_type = 'comment' GROUP BY target_id) AS r
+		WHERE c.id = r.target_id`},
+	{"undoing comment counts", `UPDATE videos AS v SET comment_count = v.comment_count - c.n
+		FROM (SELECT video_id, COUNT(*) AS n FROM comments WHERE user_id = @user AND deleted_at IS NULL AND NOT hidden GROUP BY video_id) AS c
+		WHERE v.id = c.video_id`},
+	{"undoing reply counts", `UPDATE comments AS p SET reply_count = p.reply_count - c.n
+		FROM (SELECT parent_id, COUNT(*) AS n FROM comments
+			WHERE user_id = @user AND parent_id IS NOT NULL AND deleted_at IS NULL AND NOT hidden GROUP BY parent_id) AS c
+		WHERE p.id = c.parent_id`},
+	{"deleting reactions", `DELETE FROM reactions WHERE user_id = @user
+		OR (target_type = 'video' AND target_id IN (SELECT id FROM videos WHERE user_id = @user))
+		OR (target_type = 'comment' AND target_id IN (SELECT id FROM comments
+			WHERE user_id = @user OR video_id IN (SELECT id FROM videos WHERE user_id = @user)))`},
+	{"deleting mentions", `DELETE FROM comment_mentions WHERE user_id = @user OR comment_id IN (SELECT id FROM comments WHERE user_id = @user)`},
+	{"deleting replies", `DELETE FROM comments WHERE user_id = @user AND parent_id IS NOT NULL`},
+	{"deleting comments", `DELETE FROM comments AS c WHERE c.user_id = @user AND NOT EXISTS (SELECT 1 FROM comments AS r WHERE r.parent_id = c.id)`},
+	{"clearing comments", `UPDATE comments SET body = '', pinned = false, like_count = 0, dislike_count = 0, deleted_at = COALESCE(deleted_at, @at)
+		WHERE user_id = @user`},
+	{"deleting video tags", `DELETE FROM video_tags WHERE video_id IN (SELECT id FROM videos WHERE user_id = @user)`},
+	{"deleting videos", `DELETE FROM videos WHERE user_id = @user`},
+	{"deleting playlists", `DELETE FROM playlists WHERE user_id = @user`},
+	{"deleting subscriptions", `DELETE FROM subscriptions WHERE subscriber_id = @user OR channel_id = @user`},
+	{"deleting feed entries", `DELETE FROM feed_entries WHERE user_id = @user OR channel_id = @user`},
+	{"deleting watch history", `DELETE FROM watch_entries WHERE user_id = @user`},
+	{"deleting sessions", `DELETE FROM sessions WHERE user_id = @user`},
+	{"deleting api keys", `DELETE FROM api_keys WHERE user_id = @user`},
+	{"deleting external identities", `DELETE FROM external_identities WHERE user_id = @user`},
+	{"deleting recovery codes", `DELETE FROM recovery_codes WHERE user_id = @user`},
+	{"deleting email verification tokens", `DELETE FROM email_verification_tokens WHERE user_id = @user`},
+	{"deleting reset tokens", `DELETE FROM password_reset_tokens WHERE user_id = @user`},
+	{"deleting user", `DELETE FROM users WHERE id = @user`},
+}
+
+func (r *GormUserRepository) Delete(ctx context.Context, id uuid.UUID) ([]domain.Video, error) {
+	if id == uuid.Nil {
+		return nil, fmt.Errorf("invalid id")
+	}
+	var videos []domain.Video
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id", "file_name").Where("user_id = ?", id).Find(&videos).Error; err != nil {
+			return fmt.Errorf("listing videos: %w", err)
+		}
+		args := map[string]any{"user": id, "at": time.Now().UTC()}
+		for _, step := range accountDeletion {
+			if err := tx.Exec(step.sql, args).Error; err != nil {
+				return fmt.Errorf("%s: %w", step.what, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+func (r *GormUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	if id == uuid.Nil {
 		return nil, fmt.Errorf("invalid id")
 	}
 	var user domain.User
-	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -84,28 +183,40 @@ func (r *GormUserRepository) GetByID(id uuid.UUID) (*domain.User, error) {
 	return &user, nil
 }
 
-func (r *GormUserRepository) GetAll(opts domain.UserFetchOptions) ([]domain.User, int64, error) {
+func (r *GormUserRepository) GetAll(ctx context.Context, opts domain.UserFetchOptions) ([]domain.User, int64, error) {
 	var users []domain.User
 	var total int64
 
-	tx := r.db.Model(&domain.User{})
+	tx := r.db.WithContext(ctx).Model(&domain.User{})
 
 	if opts.Username != "" {
 		tx = tx.Where("username LIKE ?", "%"+opts.Username+"%")
 	}
+	if opts.Role != "" {
+		tx = tx.Where("role = ?", opts.Role)
+	}
 	if opts.Query != "" {
 		like := "%" + opts.Query + "%"
 		tx = tx.Where("(username LIKE ? OR first_name LIKE ? OR last_name LIKE ?)", like, like, like)
 	}
 
-	if err := tx.Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("counting users: %w", err)
+	total = -1
+	if !opts.SkipTotal {
+		if err := tx.Count(&total).Error; err != nil {
+			return nil, 0, fmt.Errorf("counting users: %w", err)
+		}
 	}
 
-	if opts.Sort != "" {
-		tx = tx.Order(opts.Sort)
-	} else {
-		tx = tx.Order("created_at desc")
+	if opts.Sort.Field == "" {
+		opts.Sort = domain.Sort{Field: domain.SortCreatedAt, Desc: true}
+	}
+	key, err := userSortKey(opts.Sort.Field)
+	if err != nil {
+		return nil, 0, err
+	}
+	tx, err = paginate(tx, key, "users.id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	limit := opts.Limit
@@ -113,6 +224,9 @@ func (r *GormUserRepository) GetAll(opts domain.UserFetchOptions) ([]domain.User
 	if opts.Page > 0 && limit > 0 {
 		offset = (opts.Page - 1) * limit
 	}
+	if opts.After != nil {
+		offset = 0
+	}
 	if limit > 0 {
 		tx = tx.Limit(limit).Offset(offset)
 	}
@@ -123,12 +237,22 @@ func (r *GormUserRepository) GetAll(opts domain.UserFetchOptions) ([]domain.User
 	return users, total, nil
 }
 
-func (r *GormUserRepository) GetByUsername(username string) (*domain.User, error) {
+func userSortKey(field string) (sortKey, error) {
+	switch field {
+	case domain.SortCreatedAt:
+		return sortKey{expr: "users.created_at", parse: parseTime}, nil
+	case domain.SortUsername:
+		return sortKey{expr: "users.username", parse: parseText}, nil
+	}
+	return sortKey{}, fmt.Errorf("unsupported user sort %q", field)
+}
+
+func (r *GormUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	if username == "" {
 		return nil, nil
 	}
 	var user domain.User
-	if err := r.db.First(&user, "username = ?", username).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "username = ?", username).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -137,7 +261,18 @@ func (r *GormUserRepository) GetByUsername(username string) (*domain.User, error
 	return &user, nil
 }
 
-func (r *GormUserRepository) SaveResetToken(token string, userID uuid.UUID, expiresAt time.Time) error {
+func (r *GormUserRepository) GetByUsernames(ctx context.Context, usernames []string) ([]domain.User, error) {
+	users := []domain.User{}
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	if err := r.db.WithContext(ctx).Where("username IN ?", usernames).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("get users by username: %w", err)
+	}
+	return users, nil
+}
+
+func (r *GormUserRepository) SaveResetToken(ctx context.Context, token string, userID uuid.UUID, expiresAt time.Time) error {
 	if token == "" || userID == uuid.Nil {
 		return fmt.Errorf("invalid token or user id")
 	}
@@ -147,18 +282,18 @@ func (r *GormUserRepository) SaveResetToken(token string, userID uuid.UUID, expi
 		ExpiresAt: expiresAt,
 		CreatedAt: time.Now().UTC(),
 	}
-	if err := r.db.Create(rec).Error; err != nil {
+	if err := r.db.WithContext(ctx).Create(rec).Error; err != nil {
 		return fmt.Errorf("saving reset token: %w", err)
 	}
 	return nil
 }
 
-func (r *GormUserRepository) GetUserIDByResetToken(token string) (uuid.UUID, error) {
+func (r *GormUserRepository) GetUserIDByResetToken(ctx context.Context, token string) (uuid.UUID, error) {
 	if token == "" {
 		return uuid.Nil, fmt.Errorf("token empty")
 	}
 	var rec passwordResetToken
-	if err := r.db.First(&rec, "token = ?", token).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&rec, "token = ?", token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return uuid.Nil, nil
 		}
@@ -170,11 +305,11 @@ func (r *GormUserRepository) GetUserIDByResetToken(token string) (uuid.UUID, err
 	return rec.UserID, nil
 }
 
-func (r *GormUserRepository) DeleteResetToken(token string) error {
+func (r *GormUserRepository) DeleteResetToken(ctx context.Context, token string) error {
 	if token == "" {
 		return nil
 	}
-	if err := r.db.Delete(&passwordResetToken{}, "token = ?", token).Error; err != nil {
+	if err := r.db.WithContext(ctx).Delete(&passwordResetToken{}, "token = ?", token).Error; err != nil {
 		return fmt.Errorf("deleting reset token: %w", err)
 	}
 	return nil
@@ -1,7 +1,12 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -16,68 +21,100 @@ type gormVideoRepository struct {
 }
 
 func NewVideoRepository(db *gorm.DB) domain.VideoRepository {
-	db.AutoMigrate(&domain.Video{})
+	db.AutoMigrate(&domain.Tag{}, &domain.Video{})
+	if err := migrateVideoSearch(db); err != nil {
+		slog.Error("video search is unavailable", "error", err)
+	}
 	return &gormVideoRepository{
 		db:       db,
 		validate: validator.New(),
 	}
 }
 
-func (r *gormVideoRepository) Create(video *domain.Video) (*domain.Video, error) {
+func (r *gormVideoRepository) Create(ctx context.Context, video *domain.Video) (*domain.Video, error) {
 	if err := r.validate.Struct(video); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	if err := r.db.Create(video).Error; err != nil {
-		return nil, fmt.Errorf("failed to create video: %w", err)
+	tags := video.Tags
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Tags").Create(video).Error; err != nil {
+			return fmt.Errorf("failed to create video: %w", err)
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		return setVideoTags(tx, video, tags)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return video, nil
 }
 
-func (r *gormVideoRepository) FindByID(id uuid.UUID) (*domain.Video, error) {
+func (r *gormVideoRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Video, error) {
 	var video domain.Video
-	if err := r.db.Where("id = ?", id).First(&video).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Tags", withTagOrder).Where("id = ?", id).First(&video).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFound("video", id.String())
+		}
 		return nil, fmt.Errorf("failed to find video: %w", err)
 	}
 	return &video, nil
 }
 
-func (r *gormVideoRepository) Find(opts domain.VideoFetchOptions) ([]domain.Video, int64, error) {
+func (r *gormVideoRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Video, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var found []domain.Video
+	if err := r.db.WithContext(ctx).Preload("Tags", withTagOrder).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, fmt.Errorf("failed to find videos: %w", err)
+	}
+	byID := make(map[uuid.UUID]domain.Video, len(found))
+	for _, video := range found {
+		byID[video.ID] = video
+	}
+	videos := make([]domain.Video, 0, len(found))
+	for _, id := range ids {
+		if video, ok := byID[id]; ok {
+			videos = append(videos, video)
+		}
+	}
+	return videos, nil
+}
+
+func (r *gormVideoRepository) Find(ctx context.Context, opts domain.VideoFetchOptions) ([]domain.Video, int64, error) {
 	var videos []domain.Video
 	var total int64
 
-	query := r.db.Model(&domain.Video{})
-
-	// Apply filters
-	if opts.UserID != "" {
-		userID, err := uuid.Parse(opts.UserID)
-		if err != nil {
-			return nil, 0, fmt.Errorf("invalid user_id: %w", err)
-		}
-		query = query.Where("user_id = ?", userID)
+	query, err := filterVideos(r.db.WithContext(ctx).Model(&domain.Video{}), opts, true)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if opts.Status != "" {
-		query = query.Where("status = ?", opts.Status)
+	// Get total count before pagination
+	total = -1
+	if !opts.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to count videos: %w", err)
+		}
 	}
 
-	// Apply search query (title or description)
 	if opts.Query != "" {
-		searchPattern := "%" + opts.Query + "%"
-		query = query.Where("title ILIKE ? OR description ILIKE ?", searchPattern, searchPattern)
+		query = withSearchColumns(query, opts.Query)
 	}
-
-	// Get total count before pagination
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count videos: %w", err)
+	if opts.Sort.Field == "" {
+		opts.Sort = domain.Sort{Field: domain.SortCreatedAt, Desc: true}
 	}
-
-	// Apply sorting
-	if opts.Sort != "" {
-		query = query.Order(opts.Sort)
-	} else {
-		query = query.Order("created_at DESC")
+	key, err := videoSortKey(opts.Sort.Field, opts.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+	query, err = paginate(query, key, "videos.id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Apply pagination
@@ -90,8 +127,11 @@ func (r *gormVideoRepository) Find(opts domain.VideoFetchOptions) ([]domain.Vide
 	if opts.Page > 0 {
 		offset = (opts.Page - 1) * limit
 	}
+	if opts.After != nil {
+		offset = 0
+	}
 
-	query = query.Limit(limit).Offset(offset)
+	query = query.Limit(limit).Offset(offset).Preload("Tags", withTagOrder)
 
 	// Execute query
 	if err := query.Find(&videos).Error; err != nil {
@@ -101,40 +141,110 @@ func (r *gormVideoRepository) Find(opts domain.VideoFetchOptions) ([]domain.Vide
 	return videos, total, nil
 }
 
-func (r *gormVideoRepository) Update(video *domain.Video) error {
-	if err := r.validate.Struct(video); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+// filterVideos applies the filters of opts to query. The category filter is
+// left out when withCategory is false, for the category facet.
+func filterVideos(query *gorm.DB, opts domain.VideoFetchOptions, withCategory bool) (*gorm.DB, error) {
+	if opts.UserID != "" {
+		userID, err := uuid.Parse(opts.UserID)
+		if err != nil {
+			return nil, domain.NewFieldError("user_id", "must be a valid UUID")
+		}
+		query = query.Where("videos.user_id = ?", userID)
 	}
-
-	if err := r.db.Save(video).Error; err != nil {
-		return fmt.Errorf("failed to update video: %w", err)
+	if opts.Status != "" {
+		query = query.Where("videos.status = ?", opts.Status)
+	}
+	if withCategory && opts.Category != "" {
+		query = query.Where("videos.category = ?", opts.Category)
+	}
+	if len(opts.Tags) > 0 {
+		query = query.Where(`videos.id IN (
+			SELECT vt.video_id FROM video_tags vt JOIN tags t ON t.id = vt.tag_id
+			WHERE t.slug IN ? GROUP BY vt.video_id HAVING count(DISTINCT t.slug) = ?)`,
+			opts.Tags, len(opts.Tags))
 	}
+	if opts.Query != "" {
+		query = searchVideos(query, opts.Query)
+	}
+	return query, nil
+}
 
-	return nil
+// videoSortKey maps a sort field onto the videos table. Relevance is the
+// search rank and so needs a query.
+func videoSortKey(field, q string) (sortKey, error) {
+	switch field {
+	case domain.SortCreatedAt:
+		return sortKey{expr: "videos.created_at", parse: parseTime}, nil
+	case domain.SortViews:
+		return sortKey{expr: "videos.views", parse: parseInt}, nil
+	case domain.SortTitle:
+		return sortKey{expr: "videos.title", parse: parseText}, nil
+	case domain.SortRelevance:
+		if q != "" {
+			return sortKey{expr: searchRank, args: []any{q, q}, parse: parseFloat}, nil
+		}
+	}
+	return sortKey{}, fmt.Errorf("unsupported video sort %q", field)
 }
 
-func (r *gormVideoRepository) Delete(id uuid.UUID) error {
-	result := r.db.Delete(&domain.Video{}, "id = ?", id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete video: %w", result.Error)
+func (r *gormVideoRepository) Update(ctx context.Context, video *domain.Video) error {
+	if err := r.validate.Struct(video); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("video not found")
+	// Tags, the comment fields and the reaction counters have their own
+	// writers; saving a stale copy must not undo them.
+	if err := r.db.WithContext(ctx).Omit("Tags", "CommentCount", "CommentsDisabled", "LikeCount", "DislikeCount", "Views").Save(video).Error; err != nil {
+		return fmt.Errorf("failed to update video: %w", err)
 	}
 
 	return nil
 }
 
-func (r *gormVideoRepository) IncrementViews(id uuid.UUID) error {
-	result := r.db.Model(&domain.Video{}).Where("id = ?", id).Update("views", gorm.Expr("views + ?", 1))
-	if result.Error != nil {
-		return fmt.Errorf("failed to increment views: %w", result.Error)
-	}
+func (r *gormVideoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM video_tags WHERE video_id = ?", id).Error; err != nil {
+			return fmt.Errorf("failed to delete video tags: %w", err)
+		}
+		result := tx.Delete(&domain.Video{}, "id = ?", id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete video: %w", result.Error)
+		}
 
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("video not found")
-	}
+		if result.RowsAffected == 0 {
+			return domain.NewNotFound("video", id.String())
+		}
 
-	return nil
+		return nil
+	})
+}
+
+// AddViews adds to the view counts and the hour's rollups of many videos,
+// one statement each. Views of videos deleted since they were counted are
+// dropped. The rollups table is migrated by the analytics repository.
+func (r *gormVideoRepository) AddViews(ctx context.Context, views map[uuid.UUID]int64, at time.Time) error {
+	if len(views) == 0 {
+		return nil
+	}
+	rows := make([]string, 0, len(views))
+	args := make([]any, 0, 2*len(views))
+	for id, n := range views {
+		rows = append(rows, "(CAST(? AS uuid), CAST(? AS bigint))")
+		args = append(args, id, n)
+	}
+	values := "(VALUES " + strings.Join(rows, ", ") + ") AS v(id, n)"
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("UPDATE videos SET views = videos.views + v.n FROM "+values+" WHERE videos.id = v.id", args...).Error
+		if err != nil {
+			return fmt.Errorf("failed to add views: %w", err)
+		}
+		err = tx.Exec(`INSERT INTO video_analytics_hours (video_id, hour, counted_views, updated_at)
+			SELECT v.id, ?, v.n, ? FROM `+values+` JOIN videos ON videos.id = v.id
+			ON CONFLICT (video_id, hour) DO UPDATE SET counted_views = video_analytics_hours.counted_views + EXCLUDED.counted_views`,
+			append([]any{at.UTC().Truncate(time.Hour), at}, args...)...).Error
+		if err != nil {
+			return fmt.Errorf("failed to add hourly views: %w", err)
+		}
+		return nil
+	})
 }
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// searchConfig is the text search configuration used for the search vector
+// and for parsing queries; both must use the same one.
+const searchConfig = "english"
+
+// videoSearchMigrations maintain videos.search_vector with a trigger, so every
+// write path keeps it current, and index it along with a trigram index on the
+// title for misspelled queries. Title matches weigh more than tag matches,
+// which weigh more than description matches. Tags live in another table, so
+// setVideoTags touches the video row to fire the trigger.
+var videoSearchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`ALTER TABLE videos ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+	`CREATE OR REPLACE FUNCTION videos_search_vector_update() RETURNS trigger AS $$
+BEGIN
+	NEW.search_vector :=
+		setweight(to_tsvector('` + searchConfig + `', coalesce(NEW.title, '')), 'A') ||
+		setweight(to_tsvector('` + searchConfig + `', coalesce((
+			SELECT string_agg(t.name, ' ') FROM video_tags vt JOIN tags t ON t.id = vt.tag_id
+			WHERE vt.video_id = NEW.id), '')), 'B') ||
+		setweight(to_tsvector('` + searchConfig + `', coalesce(NEW.description, '')), 'C');
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS videos_search_vector_trigger ON videos`,
+	`CREATE TRIGGER videos_search_vector_trigger
+	BEFORE INSERT OR UPDATE OF title, description ON videos
+	FOR EACH ROW EXECUTE FUNCTION videos_search_vector_update()`,
+	`CREATE INDEX IF NOT EXISTS idx_videos_search_vector ON videos USING GIN (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_videos_title_trgm ON videos USING GIN (title gin_trgm_ops)`,
+	// Rows written before the trigger existed; assigning title fires it.
+	`UPDATE videos SET title = title WHERE search_vector IS NULL`,
+}
+
+func migrateVideoSearch(db *gorm.DB) error {
+	for _, stmt := range videoSearchMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("migrating video search: %w", err)
+		}
+	}
+	return nil
+}
+
+const (
+	tsQuery = "websearch_to_tsquery('" + searchConfig + "', ?)"
+	// searchMatch accepts full-text matches, and titles that are
+	// trigram-similar to the query so that typos still find something.
+	searchMatch = "(videos.search_vector @@ " + tsQuery + " OR ? <% videos.title)"
+	// searchRank normalises ts_rank into [0, 1) and adds a small trigram
+	// term so fuzzy-only matches are ranked among themselves.
+	searchRank = "ts_rank(videos.search_vector, " + tsQuery + ", 32) + 0.1 * word_similarity(?, videos.title)"
+	// Highlights wrap matched words in <mark>; the description is cut down
+	// to the best fragments.
+	titleHighlight       = "ts_headline('" + searchConfig + "', videos.title, " + tsQuery + ", 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')"
+	descriptionHighlight = "ts_headline('" + searchConfig + "', videos.description, " + tsQuery + ", 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \"')"
+)
+
+// searchVideos restricts query to videos matching q.
+func searchVideos(query *gorm.DB, q string) *gorm.DB {
+	return query.Where(searchMatch, q, q)
+}
+
+// withSearchColumns selects the rank and highlights of a search for q.
+func withSearchColumns(query *gorm.DB, q string) *gorm.DB {
+	return query.Select(
+		"videos.*, "+searchRank+" AS search_rank, "+titleHighlight+" AS title_highlight, "+descriptionHighlight+" AS description_highlight",
+		q, q, q, q,
+	)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/hunderaweke/gostream/internal/database"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// hlsBucket holds the transcoded output, one prefix per video ID.
+const hlsBucket = "hls-videos"
+
+type minioVideoStorage struct {
+	client *database.MinioClient
+}
+
+func NewVideoStorage(client *database.MinioClient) domain.VideoStorage {
+	return &minioVideoStorage{client: client}
+}
+
+func (s *minioVideoStorage) RemoveFiles(ctx context.Context, video *domain.Video) error {
+	var errs []error
+	if video.FileName != "" {
+		err := s.client.Client.RemoveObject(ctx, s.client.Bucket, video.FileName, minio.RemoveObjectOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove source file: %w", err))
+		}
+	}
+	// RemoveObjects reads the listing until it is closed, so listErr is set
+	// before its results are drained.
+	var listErr error
+	objects := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objects)
+		for object := range s.client.Client.ListObjects(ctx, hlsBucket, minio.ListObjectsOptions{Prefix: video.ID.String() + "/", Recursive: true}) {
+			if object.Err != nil {
+				listErr = fmt.Errorf("failed to list hls files: %w", object.Err)
+				return
+			}
+			objects <- object
+		}
+	}()
+	for result := range s.client.Client.RemoveObjects(ctx, hlsBucket, objects, minio.RemoveObjectsOptions{}) {
+		errs = append(errs, fmt.Errorf("failed to remove %s: %w", result.ObjectName, result.Err))
+	}
+	if listErr != nil {
+		errs = append(errs, listErr)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// tagFacetLimit bounds the number of tags returned as facets.
+const tagFacetLimit = 20
+
+func withTagOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.slug")
+}
+
+func (r *gormVideoRepository) SetTags(ctx context.Context, video *domain.Video, tags []domain.Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setVideoTags(tx, video, tags)
+	})
+}
+
+// setVideoTags resolves tags by slug, inserting the missing ones, replaces
+// the video's tags with them and refreshes its search vector, which the
+// trigger builds from the joined tags.
+func setVideoTags(tx *gorm.DB, video *domain.Video, tags []domain.Tag) error {
+	resolved := []domain.Tag{}
+	if len(tags) > 0 {
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).
+			Create(&tags).Error; err != nil {
+			return fmt.Errorf("failed to create tags: %w", err)
+		}
+		slugs := make([]string, len(tags))
+		for i, t := range tags {
+			slugs[i] = t.Slug
+		}
+		if err := tx.Where("slug IN ?", slugs).Order("slug").Find(&resolved).Error; err != nil {
+			return fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+	if err := tx.Model(video).Association("Tags").Replace(resolved); err != nil {
+		return fmt.Errorf("failed to set video tags: %w", err)
+	}
+	if err := tx.Exec("UPDATE videos SET title = title WHERE id = ?", video.ID).Error; err != nil {
+		return fmt.Errorf("failed to refresh search vector: %w", err)
+	}
+	video.Tags = resolved
+	return nil
+}
+
+func (r *gormVideoRepository) Facets(ctx context.Context, opts domain.VideoFetchOptions) (*domain.VideoFacets, error) {
+	facets := &domain.VideoFacets{Categories: []domain.FacetCount{}, Tags: []domain.FacetCount{}}
+
+	categories, err := filterVideos(r.db.WithContext(ctx).Model(&domain.Video{}), opts, false)
+	if err != nil {
+		return nil, err
+	}
+	err = categories.
+		Select("videos.category AS value, videos.category AS label, count(*) AS count").
+		Where("videos.category <> ''").
+		Group("videos.category").
+		Order("count DESC, value").
+		Scan(&facets.Categories).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count categories: %w", err)
+	}
+
+	matches, err := filterVideos(r.db.WithContext(ctx).Model(&domain.Video{}).Select("videos.id"), opts, true)
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.WithContext(ctx).Table("video_tags vt").
+		Select("t.slug AS value, t.name AS label, count(*) AS count").
+		Joins("JOIN tags t ON t.id = vt.tag_id").
+		Where("vt.video_id IN (?)", matches).
+		Group("t.slug, t.name").
+		Order("count DESC, value").
+		Limit(tagFacetLimit).
+		Scan(&facets.Tags).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+	return facets, nil
+}
+
+func (r *gormVideoRepository) ListTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error) {
+	tags := []domain.TagCount{}
+	query := r.db.WithContext(ctx).Table("tags t").
+		Select("t.slug, t.name, count(*) AS videos").
+		Joins("JOIN video_tags vt ON vt.tag_id = t.id").
+		Joins("JOIN videos v ON v.id = vt.video_id AND v.status = ?", domain.VideoStatusReady)
+	if prefix != "" {
+		// Slugs contain no LIKE wildcards.
+		query = query.Where("t.slug LIKE ?", prefix+"%")
+	}
+	err := query.Group("t.slug, t.name").Order("videos DESC, t.slug").Limit(limit).Scan(&tags).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return tags, nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	viewPlaybackKeyPrefix = "views:playback:"
+	viewSeenKeyPrefix     = "views:seen:"
+	viewIPKeyPrefix       = "views:ip:"
+	viewPendingKey        = "views:pending"
+)
+
+// countViewScript marks the viewer as seen and adds a pending view, unless
+// it was already seen or its IP address is over the limit. The checks and
+// the writes happen in one step, so concurrent reports count once.
+var countViewScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 'duplicate'
+end
+local n = redis.call('INCR', KEYS[2])
+if n == 1 then
+	redis.call('EXPIRE', KEYS[2], ARGV[1])
+end
+if n > tonumber(ARGV[2]) then
+	return 'rate_limited'
+end
+redis.call('SET', KEYS[1], 1, 'EX', ARGV[1])
+redis.call('HINCRBY', KEYS[3], ARGV[3], 1)
+return 'counted'
+`)
+
+// takeHashScript and takeSetScript read and delete a pending key in one
+// step, so writes made during a flush start a fresh key and nothing is left
+// behind if the flusher dies halfway.
+var takeHashScript = redis.NewScript(`
+local fields = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return fields
+`)
+
+var takeSetScript = redis.NewScript(`
+local members = redis.call('SMEMBERS', KEYS[1])
+redis.call('DEL', KEYS[1])
+return members
+`)
+
+func takeHash(ctx context.Context, rdb *redis.Client, key string) (map[string]string, error) {
+	pairs, err := takeHashScript.Run(ctx, rdb, []string{key}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		fields[pairs[i]] = pairs[i+1]
+	}
+	return fields, nil
+}
+
+func takeSet(ctx context.Context, rdb *redis.Client, key string) ([]string, error) {
+	return takeSetScript.Run(ctx, rdb, []string{key}).StringSlice()
+}
+
+type redisViewRepository struct {
+	rdb *redis.Client
+}
+
+func NewViewRepository(rdb *redis.Client) domain.ViewRepository {
+	return &redisViewRepository{rdb: rdb}
+}
+
+func (r *redisViewRepository) StartPlayback(ctx context.Context, videoID uuid.UUID, device string, at time.Time, window time.Duration) error {
+	key := viewPlaybackKeyPrefix + videoID.String() + ":" + device
+	if err := r.rdb.SetNX(ctx, key, at.UnixMilli(), window).Err(); err != nil {
+		return fmt.Errorf("recording playback: %w", err)
+	}
+	return nil
+}
+
+func (r *redisViewRepository) PlaybackStart(ctx context.Context, videoID uuid.UUID, device string) (time.Time, error) {
+	ms, err := r.rdb.Get(ctx, viewPlaybackKeyPrefix+videoID.String()+":"+device).Int64()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading playback: %w", err)
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func (r *redisViewRepository) Count(ctx context.Context, videoID uuid.UUID, viewer, ip string, window time.Duration, perIP int64) (domain.ViewResult, error) {
+	id := videoID.String()
+	keys := []string{viewSeenKeyPrefix + id + ":" + viewer, viewIPKeyPrefix + id + ":" + ip, viewPendingKey}
+	result, err := countViewScript.Run(ctx, r.rdb, keys, int64(window.Seconds()), perIP, id).Text()
+	if err != nil {
+		return "", fmt.Errorf("counting view: %w", err)
+	}
+	return domain.ViewResult(result), nil
+}
+
+// TakePending reads and deletes the pending hash in one step, so views
+// counted while a flush runs go to a new hash instead of being lost.
+func (r *redisViewRepository) TakePending(ctx context.Context) (map[uuid.UUID]int64, error) {
+	fields, err := takeHash(ctx, r.rdb, viewPendingKey)
+	if err != nil {
+		return nil, fmt.Errorf("taking pending views: %w", err)
+	}
+	views := make(map[uuid.UUID]int64, len(fields))
+	for field, value := range fields {
+		id, err := uuid.Parse(field)
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		views[id] = n
+	}
+	return views, nil
+}
+
+func (r *redisViewRepository) RestorePending(ctx context.Context, views map[uuid.UUID]int64) error {
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for id, n := range views {
+			pipe.HIncrBy(ctx, viewPendingKey, id.String(), n)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("restoring pending views: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type gormWatchHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewWatchHistoryRepository(db *gorm.DB) domain.WatchHistoryRepository {
+	db.AutoMigrate(&domain.WatchEntry{})
+	return &gormWatchHistoryRepository{db: db}
+}
+
+func (r *gormWatchHistoryRepository) Save(ctx context.Context, entries []domain.WatchEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Omit("User", "Video").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "video_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"position", "duration", "completed", "watched_at"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "watch_entries.watched_at < excluded.watched_at"}}},
+	}).CreateInBatches(entries, 500).Error
+	if err != nil {
+		// Entries of videos deleted since they were reported fail the foreign
+		// key; the rest of the batch is worth keeping.
+		if errors.Is(err, gorm.ErrForeignKeyViolated) && len(entries) > 1 {
+			return r.saveEach(ctx, entries)
+		}
+		return fmt.Errorf("failed to save watch history: %w", err)
+	}
+	return nil
+}
+
+func (r *gormWatchHistoryRepository) saveEach(ctx context.Context, entries []domain.WatchEntry) error {
+	for _, entry := range entries {
+		err := r.Save(ctx, []domain.WatchEntry{entry})
+		if err != nil && !errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *gormWatchHistoryRepository) Get(ctx context.Context, userID, videoID uuid.UUID) (*domain.WatchEntry, error) {
+	var entry domain.WatchEntry
+	err := r.db.WithContext(ctx).Where("user_id = ? AND video_id = ?", userID, videoID).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find watch history entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *gormWatchHistoryRepository) Find(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) ([]domain.WatchEntry, error) {
+	query := r.db.WithContext(ctx).Model(&domain.WatchEntry{}).
+		Joins("JOIN videos ON videos.id = watch_entries.video_id").
+		Where("watch_entries.user_id = ?", userID).
+		Where("videos.status = ? OR videos.user_id = ?", domain.VideoStatusReady, userID)
+	query, err := paginate(query, sortKey{expr: "watch_entries.watched_at", parse: parseTime}, "watch_entries.video_id", opts.Sort, opts.After)
+	if err != nil {
+		return nil, err
+	}
+	if opts.After == nil {
+		query = query.Offset(opts.Offset)
+	}
+	var entries []domain.WatchEntry
+	err = query.Select("watch_entries.*").Preload("Video").Preload("Video.Tags", withTagOrder).
+		Limit(opts.Limit).Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watch history: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *gormWatchHistoryRepository) Clear(ctx context.Context, userID, videoID uuid.UUID) error {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if videoID != uuid.Nil {
+		query = query.Where("video_id = ?", videoID)
+	}
+	if err := query.Delete(&domain.WatchEntry{}).Error; err != nil {
+		return fmt.Errorf("failed to clear watch history: %w", err)
+	}
+	return nil
+}
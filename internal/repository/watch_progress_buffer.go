@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	watchProgressKeyPrefix = "watch:progress:"
+	watchPendingKey        = "watch:pending"
+	watchClearedKeyPrefix  = "watch:cleared:"
+	// watchClearedAll is the cleared field for a user's whole history.
+	watchClearedAll = "all"
+	// watchProgressTTL keeps recent progress in Redis after it is saved,
+	// so resuming a video rarely reads the database.
+	watchProgressTTL = 24 * time.Hour
+	// watchClearedTTL only has to outlast a flush that read the entries
+	// before they were cleared.
+	watchClearedTTL = time.Hour
+)
+
+type redisWatchProgressBuffer struct {
+	rdb *redis.Client
+}
+
+func NewWatchProgressBuffer(rdb *redis.Client) domain.WatchProgressBuffer {
+	return &redisWatchProgressBuffer{rdb: rdb}
+}
+
+func watchMember(userID, videoID uuid.UUID) string {
+	return userID.String() + ":" + videoID.String()
+}
+
+func (b *redisWatchProgressBuffer) Put(ctx context.Context, entry *domain.WatchEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding watch progress: %w", err)
+	}
+	member := watchMember(entry.UserID, entry.VideoID)
+	_, err = b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, watchProgressKeyPrefix+member, data, watchProgressTTL)
+		pipe.SAdd(ctx, watchPendingKey, member)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("buffering watch progress: %w", err)
+	}
+	return nil
+}
+
+func (b *redisWatchProgressBuffer) Get(ctx context.Context, userID, videoID uuid.UUID) (*domain.WatchEntry, error) {
+	data, err := b.rdb.Get(ctx, watchProgressKeyPrefix+watchMember(userID, videoID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading watch progress: %w", err)
+	}
+	var entry domain.WatchEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("decoding watch progress: %w", err)
+	}
+	return &entry, nil
+}
+
+// TakePending takes the pending set in one step, like the view counts, so
+// progress reported during a flush waits for the next one.
+func (b *redisWatchProgressBuffer) TakePending(ctx context.Context) ([]domain.WatchEntry, error) {
+	members, err := takeSet(ctx, b.rdb, watchPendingKey)
+	if err != nil {
+		return nil, fmt.Errorf("taking pending watch progress: %w", err)
+	}
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = watchProgressKeyPrefix + member
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	values, err := b.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading pending watch progress: %w", err)
+	}
+	entries := make([]domain.WatchEntry, 0, len(values))
+	for _, value := range values {
+		// Entries cleared since they were reported are gone.
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var entry domain.WatchEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (b *redisWatchProgressBuffer) RestorePending(ctx context.Context, entries []domain.WatchEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	members := make([]any, len(entries))
+	for i, entry := range entries {
+		members[i] = watchMember(entry.UserID, entry.VideoID)
+	}
+	if err := b.rdb.SAdd(ctx, watchPendingKey, members...).Err(); err != nil {
+		return fmt.Errorf("restoring pending watch progress: %w", err)
+	}
+	return nil
+}
+
+func (b *redisWatchProgressBuffer) Clear(ctx context.Context, userID, videoID uuid.UUID, at time.Time) error {
+	field := watchClearedAll
+	if videoID != uuid.Nil {
+		field = videoID.String()
+	}
+	key := watchClearedKeyPrefix + userID.String()
+	_, err := b.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, field, at.UnixNano())
+		pipe.Expire(ctx, key, watchClearedTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("clearing watch progress: %w", err)
+	}
+	if videoID != uuid.Nil {
+		if err := b.rdb.Del(ctx, watchProgressKeyPrefix+watchMember(userID, videoID)).Err(); err != nil {
+			return fmt.Errorf("clearing watch progress: %w", err)
+		}
+		return nil
+	}
+	iter := b.rdb.Scan(ctx, 0, watchProgressKeyPrefix+userID.String()+":*", 500).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("clearing watch progress: %w", err)
+	}
+	for len(keys) > 0 {
+		n := min(len(keys), 500)
+		if err := b.rdb.Del(ctx, keys[:n]...).Err(); err != nil {
+			return fmt.Errorf("clearing watch progress: %w", err)
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+func (b *redisWatchProgressBuffer) Cleared(ctx context.Context, entries []domain.WatchEntry) ([]bool, error) {
+	cmds := make([]*redis.SliceCmd, len(entries))
+	_, err := b.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, entry := range entries {
+			cmds[i] = pipe.HMGet(ctx, watchClearedKeyPrefix+entry.UserID.String(), entry.VideoID.String(), watchClearedAll)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading cleared watch progress: %w", err)
+	}
+	cleared := make([]bool, len(entries))
+	for i, cmd := range cmds {
+		for _, value := range cmd.Val() {
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			at, err := strconv.ParseInt(s, 10, 64)
+			if err == nil && at >= entries[i].WatchedAt.UnixNano() {
+				cleared[i] = true
+			}
+		}
+	}
+	return cleared, nil
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hunderaweke/gostream/internal/apierror"
+	"github.com/hunderaweke/gostream/pkg/utils"
+)
+
+// JWKSHandler publishes the public token verification keys so other services
+// can validate gostream access tokens without sharing a secret.
+func JWKSHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		set, err := utils.PublicJWKS()
+		if err != nil {
+			apierror.WriteError(w, r, fmt.Errorf("error building jwks: %w", err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/jwk-set+json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		writeJson(w, set)
+	}
+}
@@ -2,26 +2,46 @@ package handlers
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"strings"
 
+	"github.com/hunderaweke/gostream/internal/apierror"
+	"github.com/hunderaweke/gostream/internal/auth"
 	"github.com/hunderaweke/gostream/internal/database"
 	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/internal/metrics"
 	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
 )
 
-func SecureStreamHandler(minioClient *database.MinioClient, videoService domain.VideoService) http.HandlerFunc {
+// SecureStreamHandler serves HLS playlists and segments. Anonymous playback is
+// allowed; when credentials are sent they must be valid and grant streaming.
+// Fetching a playlist starts a playback, which views reported by the player
+// are checked against. The bytes served are added to the video's analytics.
+func SecureStreamHandler(minioClient *database.MinioClient, videoService domain.VideoService, views domain.ViewService, analytics domain.AnalyticsService, authenticator *auth.Authenticator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		principal, err := authenticator.FromRequest(r)
+		if err != nil {
+			apierror.WriteError(w, r, err)
+			return
+		}
+		if principal != nil && !principal.Can(domain.PermStream) {
+			apierror.Write(w, r, codes.PermissionDenied, fmt.Sprintf("permission %q is required", domain.PermStream))
+			return
+		}
+
 		path := strings.TrimPrefix(r.URL.Path, "/v1/stream/")
 		path = strings.TrimSuffix(path, "/")
 
 		parts := strings.SplitN(path, "/", 2)
 		if len(parts) == 0 || parts[0] == "" {
-			http.Error(w, "Invalid path", http.StatusBadRequest)
+			apierror.Write(w, r, codes.InvalidArgument, "invalid path")
 			return
 		}
 
@@ -32,23 +52,24 @@ func SecureStreamHandler(minioClient *database.MinioClient, videoService domain.
 		}
 
 		if fileName == "" && r.URL.Query().Get("info") == "true" {
-			video, err := videoService.FindByID(videoID)
+			video, err := videoService.FindByID(r.Context(), videoID)
 			if err != nil {
-				http.Error(w, "Video not found", http.StatusNotFound)
+				apierror.WriteError(w, r, err)
 				return
 			}
 			if video.Status != domain.VideoStatusReady {
-				http.Error(w, "Video not ready", http.StatusServiceUnavailable)
+				apierror.Write(w, r, codes.Unavailable, "video is not ready")
 				return
 			}
 
 			obj, err := minioClient.Client.GetObject(r.Context(), "hls-videos", videoID+"/index.m3u8", minio.GetObjectOptions{})
 			if err != nil {
-				http.Error(w, "Playlist not found", http.StatusNotFound)
+				apierror.Write(w, r, codes.NotFound, "playlist not found")
 				return
 			}
 			defer obj.Close()
 
+			views.StartPlayback(r.Context(), videoID, streamViewer(r, principal))
 			var rewritten strings.Builder
 			scanner := bufio.NewScanner(obj)
 			for scanner.Scan() {
@@ -77,13 +98,13 @@ func SecureStreamHandler(minioClient *database.MinioClient, videoService domain.
 		objectPath := fmt.Sprintf("%s/%s", videoID, fileName)
 		obj, err := minioClient.Client.GetObject(r.Context(), "hls-videos", objectPath, minio.GetObjectOptions{})
 		if err != nil {
-			http.Error(w, "Video not found", http.StatusNotFound)
+			apierror.Write(w, r, codes.NotFound, "video not found")
 			return
 		}
 		defer obj.Close()
 		stat, err := obj.Stat()
 		if err != nil {
-			http.Error(w, "Video segment not found", http.StatusNotFound)
+			apierror.Write(w, r, codes.NotFound, "video segment not found")
 			return
 		}
 		if strings.HasSuffix(fileName, ".m3u8") {
@@ -100,13 +121,18 @@ func SecureStreamHandler(minioClient *database.MinioClient, videoService domain.
 			}
 
 			if err := scanner.Err(); err != nil {
-				http.Error(w, "Error reading playlist", http.StatusInternalServerError)
+				apierror.WriteError(w, r, fmt.Errorf("error reading playlist: %w", err))
 				return
 			}
 
+			if fileName == "index.m3u8" {
+				views.StartPlayback(r.Context(), videoID, streamViewer(r, principal))
+			}
 			content := rewritten.String()
 			w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
-			w.Write([]byte(content))
+			n, _ := w.Write([]byte(content))
+			metrics.StreamBytesServed.WithLabelValues("playlist").Add(float64(n))
+			analytics.RecordBytesServed(context.WithoutCancel(r.Context()), videoID, int64(n))
 			return
 		}
 
@@ -116,9 +142,25 @@ func SecureStreamHandler(minioClient *database.MinioClient, videoService domain.
 		}
 
 		w.Header().Set("Content-Length", fmt.Sprintf("%d", stat.Size))
-		_, err = io.Copy(w, obj)
+		n, err := io.Copy(w, obj)
+		metrics.StreamBytesServed.WithLabelValues("segment").Add(float64(n))
+		// Bytes sent before the viewer went away count too.
+		analytics.RecordBytesServed(context.WithoutCancel(r.Context()), videoID, n)
 		if err != nil {
-			log.Println("Stream interrupted:", err)
+			slog.WarnContext(r.Context(), "stream interrupted", "video_id", videoID, "object", objectPath, "error", err)
 		}
 	}
 }
+
+// streamViewer identifies the viewer of a stream request the way the gRPC
+// server does, so playbacks and reported views match up.
+func streamViewer(r *http.Request, principal *auth.Principal) domain.Viewer {
+	viewer := domain.Viewer{IP: r.RemoteAddr, UserAgent: r.UserAgent()}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		viewer.IP = host
+	}
+	if principal != nil {
+		viewer.UserID = principal.UserID
+	}
+	return viewer
+}
@@ -4,20 +4,39 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 
+	"github.com/hunderaweke/gostream/internal/apierror"
+	"github.com/hunderaweke/gostream/internal/auth"
 	"github.com/hunderaweke/gostream/internal/database"
 	"github.com/hunderaweke/gostream/internal/domain"
 	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
 )
 
-func SecureUploadHandler(minioClient *database.MinioClient, videoUsecase domain.VideoService) http.HandlerFunc {
+func SecureUploadHandler(minioClient *database.MinioClient, videoUsecase domain.VideoService, authenticator *auth.Authenticator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		principal, err := authenticator.FromRequest(r)
+		if err != nil {
+			apierror.WriteError(w, r, err)
+			return
+		}
+		if principal == nil {
+			apierror.Write(w, r, codes.Unauthenticated, "authorization token is not provided")
+			return
+		}
+		if !principal.Can(domain.PermVideosWrite) {
+			apierror.Write(w, r, codes.PermissionDenied, fmt.Sprintf("permission %q is required", domain.PermVideosWrite))
+			return
+		}
 		idString := (r.PathValue("video_id"))
-		video, err := videoUsecase.FindByID(idString)
+		video, err := videoUsecase.FindByID(r.Context(), idString)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.WriteError(w, r, err)
+			return
+		}
+		if video.UserID != principal.UserID {
+			apierror.Write(w, r, codes.PermissionDenied, "video does not belong to the current user")
 			return
 		}
 		contentType := r.Header.Get("Content-Type")
@@ -35,8 +54,7 @@ func SecureUploadHandler(minioClient *database.MinioClient, videoUsecase domain.
 			},
 		)
 		if err != nil {
-			http.Error(w, "upload failed", http.StatusInternalServerError)
-			log.Printf("minio upload error: %v", err)
+			apierror.WriteError(w, r, fmt.Errorf("uploading video %s: %w", idString, err))
 			return
 		}
 		w.Header().Set("Location", video.FileName)
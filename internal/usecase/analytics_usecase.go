@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/internal/metrics"
+)
+
+const (
+	// maxSessionIDLength bounds the player-chosen session ids kept in Redis.
+	maxSessionIDLength = 64
+	// maxEventDuration bounds the watch time and stall of a single event;
+	// players report far more often than that.
+	maxEventDuration = 10 * time.Minute
+)
+
+type analyticsUsecase struct {
+	store          domain.AnalyticsStore
+	repo           domain.AnalyticsRepository
+	videos         domain.VideoRepository
+	rollupInterval time.Duration
+	now            func() time.Time
+}
+
+// NewAnalyticsUsecase saves rollups every ANALYTICS_ROLLUP_INTERVAL
+// (default 1m).
+func NewAnalyticsUsecase(store domain.AnalyticsStore, repo domain.AnalyticsRepository, videos domain.VideoRepository) domain.AnalyticsService {
+	return &analyticsUsecase{
+		store:          store,
+		repo:           repo,
+		videos:         videos,
+		rollupInterval: envDuration("ANALYTICS_ROLLUP_INTERVAL", time.Minute),
+		now:            time.Now,
+	}
+}
+
+func validatePlaybackEvent(i int, e domain.PlaybackEvent) error {
+	field := func(name string) string { return fmt.Sprintf("events[%d].%s", i, name) }
+	switch {
+	case !e.Type.Valid():
+		return domain.NewFieldError(field("type"), "must be one of START, REBUFFER, BITRATE_SWITCH, ERROR or WATCH_TIME")
+	case e.SessionID == "":
+		return domain.NewFieldError(field("session_id"), "is required")
+	case len(e.SessionID) > maxSessionIDLength:
+		return domain.NewFieldError(field("session_id"), fmt.Sprintf("must be at most %d characters long", maxSessionIDLength))
+	case e.WatchSeconds < 0 || e.WatchSeconds > maxEventDuration.Seconds():
+		return domain.NewFieldError(field("watch_seconds"), fmt.Sprintf("must be between 0 and %.0f", maxEventDuration.Seconds()))
+	case e.RebufferMs < 0 || e.RebufferMs > maxEventDuration.Milliseconds():
+		return domain.NewFieldError(field("rebuffer_ms"), fmt.Sprintf("must be between 0 and %d", maxEventDuration.Milliseconds()))
+	case e.SegmentIndex >= domain.MaxRetentionSegments:
+		return domain.NewFieldError(field("segment_index"), fmt.Sprintf("must be less than %d", domain.MaxRetentionSegments))
+	}
+	return nil
+}
+
+// Ingest takes the time events arrive as their time, so players cannot
+// write into hours that were already rolled up.
+func (u *analyticsUsecase) Ingest(ctx context.Context, viewer domain.Viewer, events []domain.PlaybackEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	if len(events) > domain.MaxPlaybackEvents {
+		return 0, domain.NewFieldError("events", fmt.Sprintf("must be at most %d events", domain.MaxPlaybackEvents))
+	}
+	checked := map[uuid.UUID]bool{}
+	for i, e := range events {
+		if err := validatePlaybackEvent(i, e); err != nil {
+			return 0, err
+		}
+		if checked[e.VideoID] {
+			continue
+		}
+		video, err := u.videos.FindByID(ctx, e.VideoID)
+		if err != nil {
+			return 0, err
+		}
+		if video.Status != domain.VideoStatusReady {
+			return 0, domain.NewNotFound("video", e.VideoID.String())
+		}
+		checked[e.VideoID] = true
+	}
+	if isBot(viewer.UserAgent) {
+		return 0, nil
+	}
+	if err := u.store.Record(ctx, viewerKey(viewer), events, u.now()); err != nil {
+		return 0, err
+	}
+	for _, e := range events {
+		metrics.PlaybackEventsTotal.WithLabelValues(string(e.Type)).Inc()
+	}
+	return len(events), nil
+}
+
+func (u *analyticsUsecase) RecordBytesServed(ctx context.Context, videoID string, bytes int64) {
+	id, err := uuid.Parse(videoID)
+	if err != nil || bytes <= 0 {
+		return
+	}
+	if err := u.store.AddBytesServed(ctx, id, bytes, u.now()); err != nil {
+		slog.WarnContext(ctx, "recording bytes served failed", "video_id", videoID, "error", err)
+	}
+}
+
+func bucketStart(t time.Time, granularity domain.AnalyticsGranularity) time.Time {
+	t = t.UTC()
+	if granularity == domain.AnalyticsDaily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(time.Hour)
+}
+
+func nextBucket(t time.Time, granularity domain.AnalyticsGranularity) time.Time {
+	if granularity == domain.AnalyticsDaily {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(time.Hour)
+}
+
+func (u *analyticsUsecase) VideoAnalytics(ctx context.Context, userID uuid.UUID, videoID string, from, to time.Time, granularity domain.AnalyticsGranularity) (*domain.VideoAnalytics, error) {
+	id, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	video, err := u.videos.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.UserID != userID {
+		return nil, errNotVideoOwner
+	}
+	if to.IsZero() {
+		to = u.now()
+	}
+	if from.IsZero() {
+		from = to.Add(-7 * 24 * time.Hour)
+	}
+	switch {
+	case !from.Before(to):
+		return nil, domain.NewFieldError("start_time", "must be before end_time")
+	case to.Sub(from) > domain.MaxAnalyticsRange:
+		return nil, domain.NewFieldError("end_time", "must be at most 90 days after start_time")
+	}
+	switch granularity {
+	case "":
+		granularity = domain.AnalyticsHourly
+		if to.Sub(from) > 72*time.Hour {
+			granularity = domain.AnalyticsDaily
+		}
+	case domain.AnalyticsHourly, domain.AnalyticsDaily:
+	default:
+		return nil, domain.NewFieldError("granularity", "must be HOUR or DAY")
+	}
+	from = bucketStart(from, granularity)
+	hours, err := u.repo.Find(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.VideoAnalytics{VideoID: id, From: from, To: to, Granularity: granularity}
+	index := map[time.Time]int{}
+	for start := from; start.Before(to); start = nextBucket(start, granularity) {
+		index[start] = len(result.Buckets)
+		result.Buckets = append(result.Buckets, domain.AnalyticsBucket{Start: start})
+	}
+	bucketHours := make([][]domain.VideoAnalyticsHour, len(result.Buckets))
+	var reached []int64
+	for _, h := range hours {
+		i, ok := index[bucketStart(h.Hour, granularity)]
+		if !ok {
+			continue
+		}
+		result.Buckets[i].Add(h.AnalyticsCounters)
+		result.Totals.Add(h.AnalyticsCounters)
+		bucketHours[i] = append(bucketHours[i], h)
+		for segment, n := range h.Retention {
+			if segment == len(reached) {
+				reached = append(reached, 0)
+			}
+			reached[segment] += n
+		}
+	}
+	for i := range result.Buckets {
+		result.Buckets[i].UniqueViewers = u.uniqueViewers(ctx, bucketHours[i])
+		averageWatchTime(&result.Buckets[i])
+	}
+	result.Totals.Start = from
+	result.Totals.UniqueViewers = u.uniqueViewers(ctx, hours)
+	averageWatchTime(&result.Totals)
+	if len(reached) > 0 && reached[0] > 0 {
+		result.Retention = make([]float64, len(reached))
+		for segment, n := range reached {
+			result.Retention[segment] = float64(n) / float64(reached[0])
+		}
+	}
+	return result, nil
+}
+
+func averageWatchTime(b *domain.AnalyticsBucket) {
+	if b.Views > 0 {
+		b.AvgWatchSeconds = b.WatchSeconds / float64(b.Views)
+	}
+}
+
+// uniqueViewers merges the HyperLogLogs of hours, as viewers of several
+// hours must count once. Without Redis it falls back to the busiest hour,
+// a lower bound.
+func (u *analyticsUsecase) uniqueViewers(ctx context.Context, hours []domain.VideoAnalyticsHour) int64 {
+	var busiest int64
+	hlls := make([][]byte, len(hours))
+	for i, h := range hours {
+		busiest = max(busiest, h.UniqueViewers)
+		hlls[i] = h.ViewersHLL
+	}
+	if len(hours) <= 1 {
+		return busiest
+	}
+	n, err := u.store.CountUnique(ctx, hlls)
+	if err != nil {
+		slog.WarnContext(ctx, "merging unique viewers failed", "error", err)
+		return busiest
+	}
+	return n
+}
+
+func (u *analyticsUsecase) Rollup(ctx context.Context) (int, error) {
+	hours, err := u.store.TakeDirty(ctx)
+	if err != nil || len(hours) == 0 {
+		return 0, err
+	}
+	if err := u.repo.Save(ctx, hours); err != nil {
+		if restoreErr := u.store.RestoreDirty(ctx, hours); restoreErr != nil {
+			slog.ErrorContext(ctx, "analytics rollups will be retried with the next events", "hours", len(hours), "error", restoreErr)
+		}
+		return 0, err
+	}
+	return len(hours), nil
+}
+
+func (u *analyticsUsecase) RunRollups(ctx context.Context) {
+	runFlusher(ctx, "analytics", u.rollupInterval, func(ctx context.Context) error {
+		_, err := u.Rollup(ctx)
+		return err
+	})
+}
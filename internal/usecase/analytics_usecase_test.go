@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type memVideos struct {
+	domain.VideoRepository
+	videos map[uuid.UUID]*domain.Video
+}
+
+func (m *memVideos) FindByID(ctx context.Context, id uuid.UUID) (*domain.Video, error) {
+	if video, ok := m.videos[id]; ok {
+		copied := *video
+		return &copied, nil
+	}
+	return nil, domain.NewNotFound("video", id.String())
+}
+
+type memAnalytics struct {
+	domain.AnalyticsRepository
+	hours []domain.VideoAnalyticsHour
+}
+
+func (m *memAnalytics) Find(ctx context.Context, videoID uuid.UUID, from, to time.Time) ([]domain.VideoAnalyticsHour, error) {
+	var found []domain.VideoAnalyticsHour
+	for _, h := range m.hours {
+		if h.VideoID == videoID && !h.Hour.Before(from) && h.Hour.Before(to) {
+			found = append(found, h)
+		}
+	}
+	return found, nil
+}
+
+// memAnalyticsStore stands in for Redis HyperLogLogs with byte strings of
+// viewer letters, whose union it counts.
+type memAnalyticsStore struct {
+	domain.AnalyticsStore
+}
+
+func (memAnalyticsStore) CountUnique(ctx context.Context, hlls [][]byte) (int64, error) {
+	seen := map[byte]bool{}
+	for _, hll := range hlls {
+		for _, viewer := range hll {
+			seen[viewer] = true
+		}
+	}
+	return int64(len(seen)), nil
+}
+
+func TestVideoAnalyticsBuckets(t *testing.T) {
+	owner := uuid.New()
+	video := &domain.Video{UserID: owner}
+	video.ID = uuid.New()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// hour is a rollup at the given hour of the test's day with views
+	// starts by the viewers named in hll.
+	hour := func(at time.Duration, views int64, hll string, retention ...int64) domain.VideoAnalyticsHour {
+		return domain.VideoAnalyticsHour{
+			VideoID:           video.ID,
+			Hour:              day.Add(at),
+			AnalyticsCounters: domain.AnalyticsCounters{Views: views, WatchSeconds: float64(60 * views)},
+			UniqueViewers:     int64(len(hll)),
+			ViewersHLL:        []byte(hll),
+			Retention:         retention,
+		}
+	}
+	type bucket struct {
+		start  time.Time
+		views  int64
+		unique int64
+	}
+
+	tests := []struct {
+		name            string
+		from, to        time.Time
+		granularity     domain.AnalyticsGranularity
+		hours           []domain.VideoAnalyticsHour
+		wantGranularity domain.AnalyticsGranularity
+		wantBuckets     []bucket
+		wantTotal       bucket
+		wantRetention   []float64
+	}{
+		{
+			name:            "hours without rollups are empty buckets",
+			from:            day.Add(10 * time.Hour),
+			to:              day.Add(13 * time.Hour),
+			hours:           []domain.VideoAnalyticsHour{hour(10*time.Hour, 3, "ab"), hour(12*time.Hour, 2, "bc")},
+			wantGranularity: domain.AnalyticsHourly,
+			wantBuckets: []bucket{
+				{start: day.Add(10 * time.Hour), views: 3, unique: 2},
+				{start: day.Add(11 * time.Hour)},
+				{start: day.Add(12 * time.Hour), views: 2, unique: 2},
+			},
+			wantTotal: bucket{start: day.Add(10 * time.Hour), views: 5, unique: 3},
+		},
+		{
+			name:        "days start at midnight",
+			from:        day.Add(10 * time.Hour),
+			to:          day.Add(48 * time.Hour),
+			granularity: domain.AnalyticsDaily,
+			hours: []domain.VideoAnalyticsHour{
+				hour(1*time.Hour, 1, "a"), hour(23*time.Hour, 2, "ab"), hour(30*time.Hour, 4, "cd"),
+			},
+			wantGranularity: domain.AnalyticsDaily,
+			wantBuckets: []bucket{
+				{start: day, views: 3, unique: 2},
+				{start: day.Add(24 * time.Hour), views: 4, unique: 2},
+			},
+			wantTotal: bucket{start: day, views: 7, unique: 4},
+		},
+		{
+			name:            "long ranges default to days",
+			from:            day,
+			to:              day.Add(4 * 24 * time.Hour),
+			hours:           []domain.VideoAnalyticsHour{hour(50*time.Hour, 1, "a")},
+			wantGranularity: domain.AnalyticsDaily,
+			wantBuckets: []bucket{
+				{start: day}, {start: day.Add(24 * time.Hour)},
+				{start: day.Add(48 * time.Hour), views: 1, unique: 1}, {start: day.Add(72 * time.Hour)},
+			},
+			wantTotal: bucket{start: day, views: 1, unique: 1},
+		},
+		{
+			name:            "retention is relative to the first segment",
+			from:            day,
+			to:              day.Add(2 * time.Hour),
+			hours:           []domain.VideoAnalyticsHour{hour(0, 4, "a", 4, 2), hour(time.Hour, 2, "a", 4, 2, 1)},
+			wantGranularity: domain.AnalyticsHourly,
+			wantBuckets: []bucket{
+				{start: day, views: 4, unique: 1},
+				{start: day.Add(time.Hour), views: 2, unique: 1},
+			},
+			wantTotal:     bucket{start: day, views: 6, unique: 1},
+			wantRetention: []float64{1, 0.5, 0.125},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &analyticsUsecase{
+				store:  memAnalyticsStore{},
+				repo:   &memAnalytics{hours: tt.hours},
+				videos: &memVideos{videos: map[uuid.UUID]*domain.Video{video.ID: video}},
+				now:    func() time.Time { return tt.to },
+			}
+			got, err := u.VideoAnalytics(context.Background(), owner, video.ID.String(), tt.from, tt.to, tt.granularity)
+			if err != nil {
+				t.Fatalf("VideoAnalytics: %v", err)
+			}
+			if got.Granularity != tt.wantGranularity {
+				t.Errorf("granularity = %s, want %s", got.Granularity, tt.wantGranularity)
+			}
+			var buckets []bucket
+			for _, b := range got.Buckets {
+				buckets = append(buckets, bucket{start: b.Start, views: b.Views, unique: b.UniqueViewers})
+			}
+			if !reflect.DeepEqual(buckets, tt.wantBuckets) {
+				t.Errorf("buckets = %v, want %v", buckets, tt.wantBuckets)
+			}
+			total := bucket{start: got.Totals.Start, views: got.Totals.Views, unique: got.Totals.UniqueViewers}
+			if total != tt.wantTotal {
+				t.Errorf("totals = %v, want %v", total, tt.wantTotal)
+			}
+			if got.Totals.Views > 0 && got.Totals.AvgWatchSeconds != 60 {
+				t.Errorf("average watch time = %v, want 60", got.Totals.AvgWatchSeconds)
+			}
+			if !reflect.DeepEqual(got.Retention, tt.wantRetention) {
+				t.Errorf("retention = %v, want %v", got.Retention, tt.wantRetention)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// lastUsedResolution limits how often last-used timestamps of API keys and
+// sessions are written.
+const lastUsedResolution = time.Minute
+
+type apiKeyUsecase struct {
+	repo  domain.APIKeyRepository
+	users domain.UserRepository
+	now   func() time.Time
+}
+
+func NewAPIKeyUsecase(repo domain.APIKeyRepository, users domain.UserRepository) domain.APIKeyService {
+	return &apiKeyUsecase{
+		repo:  repo,
+		users: users,
+		now:   time.Now,
+	}
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func (u *apiKeyUsecase) Create(ctx context.Context, userID uuid.UUID, name string, scopes []domain.Permission, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", domain.NewFieldError("scopes", "at least one scope is required")
+	}
+	user, err := u.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("lookup user: %w", err)
+	}
+	if user == nil {
+		return nil, "", domain.NewNotFound("user", userID.String())
+	}
+	names := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(domain.APIKeyScopes, scope) {
+			return nil, "", domain.NewFieldError("scopes", fmt.Sprintf("unknown scope %q", scope))
+		}
+		if !user.Role.Can(scope) {
+			return nil, "", domain.NewPermissionDenied("role %q cannot grant scope %q", user.Role, scope)
+		}
+		if !slices.Contains(names, string(scope)) {
+			names = append(names, string(scope))
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(u.now()) {
+		return nil, "", domain.NewFieldError("expires_at", "must be in the future")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("generating api key: %w", err)
+	}
+	rawKey := domain.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	key := &domain.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    rawKey[:len(domain.APIKeyPrefix)+6],
+		KeyHash:   hashAPIKey(rawKey),
+		Scopes:    strings.Join(names, ","),
+		ExpiresAt: expiresAt,
+	}
+	created, err := u.repo.Create(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, rawKey, nil
+}
+
+func (u *apiKeyUsecase) List(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error) {
+	return u.repo.ListByUser(ctx, userID)
+}
+
+func (u *apiKeyUsecase) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	return u.repo.Revoke(ctx, userID, id, u.now())
+}
+
+// Authenticate resolves a plaintext key to its record and owner. Revoked,
+// expired and unknown keys, as well as keys of disabled users, are rejected
+// with the same error.
+func (u *apiKeyUsecase) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, *domain.User, error) {
+	if !strings.HasPrefix(rawKey, domain.APIKeyPrefix) {
+		return nil, nil, domain.ErrInvalidToken
+	}
+	key, err := u.repo.GetByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := u.now()
+	if key == nil || !key.Active(now) {
+		return nil, nil, domain.ErrInvalidToken
+	}
+	user, err := u.users.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil || user.Disabled {
+		return nil, nil, domain.ErrInvalidToken
+	}
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := u.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			slog.WarnContext(ctx, "error recording api key use", "api_key_id", key.ID.String(), "error", err)
+		}
+	}
+	return key, user, nil
+}
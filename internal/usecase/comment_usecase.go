@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type commentUsecase struct {
+	repo   domain.CommentRepository
+	videos domain.VideoRepository
+	users  domain.UserRepository
+	now    func() time.Time
+}
+
+var (
+	errInvalidCommentID = domain.NewFieldError("comment_id", "must be a valid UUID")
+	errNotVideoOwner    = domain.NewPermissionDenied("only the video's owner can do this")
+)
+
+func NewCommentUsecase(repo domain.CommentRepository, videos domain.VideoRepository, users domain.UserRepository) domain.CommentService {
+	return &commentUsecase{
+		repo:   repo,
+		videos: videos,
+		users:  users,
+		now:    time.Now,
+	}
+}
+
+// visibleVideo loads a video, reporting videos that are not ready as missing
+// to everyone but their owner, like GetVideo does.
+func (u *commentUsecase) visibleVideo(ctx context.Context, viewer uuid.UUID, id uuid.UUID) (*domain.Video, error) {
+	video, err := u.videos.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != domain.VideoStatusReady && video.UserID != viewer {
+		return nil, domain.NewNotFound("video", id.String())
+	}
+	return video, nil
+}
+
+// visibleComment loads a live comment along with its video.
+func (u *commentUsecase) visibleComment(ctx context.Context, viewer uuid.UUID, id string) (*domain.Comment, *domain.Video, error) {
+	commentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, errInvalidCommentID
+	}
+	comment, err := u.repo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	video, err := u.visibleVideo(ctx, viewer, comment.VideoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comment.Deleted() || (comment.Hidden && comment.UserID != viewer && video.UserID != viewer) {
+		return nil, nil, domain.NewNotFound("comment", id)
+	}
+	return comment, video, nil
+}
+
+// commentBody validates a comment body and resolves its mentions.
+func (u *commentUsecase) commentBody(ctx context.Context, body string) (string, []domain.CommentMention, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", nil, domain.NewFieldError("body", "is required")
+	}
+	if utf8.RuneCountInString(body) > domain.MaxCommentLength {
+		return "", nil, domain.NewFieldError("body", fmt.Sprintf("must be at most %d characters long", domain.MaxCommentLength))
+	}
+	names := domain.ParseMentions(body)
+	if len(names) > domain.MaxCommentMentions {
+		names = names[:domain.MaxCommentMentions]
+	}
+	users, err := u.users.GetByUsernames(ctx, names)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolving mentions: %w", err)
+	}
+	mentions := make([]domain.CommentMention, len(users))
+	for i, user := range users {
+		mentions[i] = domain.CommentMention{UserID: user.ID, Username: user.Username}
+	}
+	return body, mentions, nil
+}
+
+func (u *commentUsecase) Create(ctx context.Context, userID uuid.UUID, videoID, parentID, body string) (*domain.Comment, error) {
+	vid, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	video, err := u.visibleVideo(ctx, userID, vid)
+	if err != nil {
+		return nil, err
+	}
+	if video.CommentsDisabled {
+		return nil, domain.NewFailedPrecondition("comments are turned off for this video").WithReason("COMMENTS_DISABLED")
+	}
+	comment := &domain.Comment{VideoID: video.ID, UserID: userID}
+	if parentID != "" {
+		parent, _, err := u.visibleComment(ctx, userID, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.VideoID != video.ID {
+			return nil, domain.NewFieldError("parent_id", "must be a comment on the same video")
+		}
+		// Replies to replies join the thread of the top-level comment.
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		} else {
+			comment.ParentID = &parent.ID
+		}
+	}
+	comment.Body, comment.Mentions, err = u.commentBody(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (u *commentUsecase) List(ctx context.Context, viewer uuid.UUID, videoID string, opts domain.CommentFetchOptions) (*domain.MultipleCommentResponse, error) {
+	vid, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	video, err := u.visibleVideo(ctx, viewer, vid)
+	if err != nil {
+		return nil, err
+	}
+	opts.VideoID = video.ID
+	opts.Viewer = viewer
+	opts.ShowHidden = video.UserID == viewer
+	resp := &domain.MultipleCommentResponse{Total: video.CommentCount, CommentsDisabled: video.CommentsDisabled}
+
+	if opts.ParentID != nil {
+		// Replies of deleted comments stay readable, so the parent is not
+		// required to be live.
+		parent, err := u.repo.FindByID(ctx, *opts.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.VideoID != video.ID || (parent.Hidden && !opts.ShowHidden && parent.UserID != viewer) {
+			return nil, domain.NewNotFound("comment", opts.ParentID.String())
+		}
+		resp.Total = parent.ReplyCount
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	if opts.Sort.Field == "" {
+		// Threads read oldest first; the comment section newest first.
+		opts.Sort = domain.Sort{Field: domain.SortCreatedAt, Desc: opts.ParentID == nil}
+	}
+	firstPage := opts.PageToken == "" && opts.Page <= 1
+	if opts.ParentID == nil {
+		opts.SkipPinned = true
+		if firstPage {
+			resp.Pinned, err = u.repo.Pinned(ctx, video.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	limit, err := openPage(&opts.BaseFetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := u.repo.Find(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp.Comments, resp.NextPageToken = closePage(comments, limit, opts.Sort, commentSortValue(opts.Sort.Field))
+	return resp, nil
+}
+
+func (u *commentUsecase) Update(ctx context.Context, userID uuid.UUID, id, body string) (*domain.Comment, error) {
+	comment, _, err := u.visibleComment(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, domain.NewPermissionDenied("only the author can edit a comment")
+	}
+	body, mentions, err := u.commentBody(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	if body == comment.Body {
+		return comment, nil
+	}
+	now := u.now()
+	comment.Body = body
+	comment.Mentions = mentions
+	comment.Edited = true
+	comment.EditedAt = &now
+	if err := u.repo.UpdateBody(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (u *commentUsecase) Delete(ctx context.Context, userID uuid.UUID, id string) error {
+	comment, video, err := u.visibleComment(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID && video.UserID != userID {
+		return domain.NewPermissionDenied("only the author or the video's owner can delete a comment")
+	}
+	return u.repo.Delete(ctx, comment, u.now())
+}
+
+func (u *commentUsecase) SetPinned(ctx context.Context, userID uuid.UUID, id string, pinned bool) (*domain.Comment, error) {
+	comment, video, err := u.visibleComment(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.UserID != userID {
+		return nil, errNotVideoOwner
+	}
+	if pinned && (comment.ParentID != nil || comment.Hidden) {
+		return nil, domain.NewFailedPrecondition("only visible top-level comments can be pinned").WithReason("NOT_PINNABLE")
+	}
+	if err := u.repo.SetPinned(ctx, comment, pinned); err != nil {
+		return nil, err
+	}
+	comment.Pinned = pinned
+	return comment, nil
+}
+
+func (u *commentUsecase) SetHidden(ctx context.Context, userID uuid.UUID, id string, hidden bool) (*domain.Comment, error) {
+	comment, video, err := u.visibleComment(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.UserID != userID {
+		return nil, errNotVideoOwner
+	}
+	if err := u.repo.SetHidden(ctx, comment, hidden); err != nil {
+		return nil, err
+	}
+	comment.Hidden = hidden
+	if hidden {
+		comment.Pinned = false
+	}
+	return comment, nil
+}
+
+func (u *commentUsecase) SetCommentsDisabled(ctx context.Context, userID uuid.UUID, videoID string, disabled bool) error {
+	vid, err := uuid.Parse(videoID)
+	if err != nil {
+		return errInvalidVideoID
+	}
+	video, err := u.visibleVideo(ctx, userID, vid)
+	if err != nil {
+		return err
+	}
+	if video.UserID != userID {
+		return errNotVideoOwner
+	}
+	return u.repo.SetCommentsDisabled(ctx, video.ID, disabled)
+}
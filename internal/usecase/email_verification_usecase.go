@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+var errVerificationToken = domain.NewFieldError("token", "is invalid or has expired")
+
+type emailVerificationUsecase struct {
+	repo      domain.EmailVerificationRepository
+	users     domain.UserRepository
+	mailer    domain.Mailer
+	verifyURL string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewEmailVerificationUsecase builds the verification service. Links point at
+// EMAIL_VERIFY_URL with the token appended as a query parameter, and stay
+// valid for EMAIL_VERIFICATION_TTL (default 24h).
+func NewEmailVerificationUsecase(repo domain.EmailVerificationRepository, users domain.UserRepository, mailer domain.Mailer) domain.EmailVerificationService {
+	verifyURL := os.Getenv("EMAIL_VERIFY_URL")
+	if verifyURL == "" {
+		verifyURL = "http://localhost:8080/v1/users/email/verify"
+	}
+	return &emailVerificationUsecase{
+		repo:      repo,
+		users:     users,
+		mailer:    mailer,
+		verifyURL: verifyURL,
+		ttl:       envDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+		now:       time.Now,
+	}
+}
+
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (u *emailVerificationUsecase) SendVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := u.users.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("lookup user: %w", err)
+	}
+	if user == nil {
+		return domain.NewNotFound("user", userID.String())
+	}
+	if user.Email == "" {
+		return domain.NewFailedPrecondition("user has no email address").WithReason("EMAIL_MISSING")
+	}
+	if user.EmailVerified {
+		return domain.NewFailedPrecondition("email address is already verified").WithReason("EMAIL_ALREADY_VERIFIED")
+	}
+	token, err := randomURLToken()
+	if err != nil {
+		return err
+	}
+	if err := u.repo.DeleteByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	err = u.repo.Save(ctx, &domain.EmailVerificationToken{
+		TokenHash: hashVerificationToken(token),
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: u.now().Add(u.ttl),
+	})
+	if err != nil {
+		return err
+	}
+	link, err := url.Parse(u.verifyURL)
+	if err != nil {
+		return fmt.Errorf("invalid EMAIL_VERIFY_URL: %w", err)
+	}
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+	return u.mailer.Send(ctx, domain.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your GoStream email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm that %s is your email address by opening this link:\n\n%s\n\nThe link expires in %s. If you did not request this, you can ignore this email.\n",
+			user.Username, user.Email, link.String(), u.ttl),
+	})
+}
+
+func (u *emailVerificationUsecase) Verify(ctx context.Context, token string) (*domain.User, error) {
+	if token == "" {
+		return nil, domain.NewFieldError("token", "is required")
+	}
+	rec, err := u.repo.GetByHash(ctx, hashVerificationToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil || rec.ExpiresAt.Before(u.now()) {
+		return nil, errVerificationToken
+	}
+	user, err := u.users.GetByID(ctx, rec.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	// The address may have changed since the link was sent.
+	if user == nil || user.Email != rec.Email {
+		return nil, errVerificationToken
+	}
+	// The write checks the address again, in case it changed since the read.
+	verified, err := u.users.MarkEmailVerified(ctx, user.ID, rec.Email)
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		return nil, errVerificationToken
+	}
+	user.EmailVerified = true
+	if err := u.repo.DeleteByUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
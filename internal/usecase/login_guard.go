@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hunderaweke/gostream/internal/audit"
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// loginPolicy holds the brute-force limits applied to password logins.
+type loginPolicy struct {
+	window           time.Duration
+	perIP            int64
+	perUsername      int64
+	lockoutThreshold int64
+	lockoutBase      time.Duration
+	lockoutMax       time.Duration
+	failureTTL       time.Duration
+}
+
+// loginPolicyFromEnv reads the limits from the environment:
+//
+//   - LOGIN_RATE_WINDOW: sliding window for the rate limits (default 1m)
+//   - LOGIN_RATE_PER_IP: attempts per client IP per window (default 20)
+//   - LOGIN_RATE_PER_USERNAME: attempts per username per window (default 10)
+//   - LOGIN_LOCKOUT_THRESHOLD: consecutive failures before a lockout (default 5)
+//   - LOGIN_LOCKOUT_DURATION: first lockout, doubled on each further one (default 1m)
+//   - LOGIN_LOCKOUT_MAX: upper bound on a single lockout (default 1h)
+func loginPolicyFromEnv() loginPolicy {
+	return loginPolicy{
+		window:           envDuration("LOGIN_RATE_WINDOW", time.Minute),
+		perIP:            envInt("LOGIN_RATE_PER_IP", 20),
+		perUsername:      envInt("LOGIN_RATE_PER_USERNAME", 10),
+		lockoutThreshold: envInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+		lockoutBase:      envDuration("LOGIN_LOCKOUT_DURATION", time.Minute),
+		lockoutMax:       envDuration("LOGIN_LOCKOUT_MAX", time.Hour),
+		failureTTL:       24 * time.Hour,
+	}
+}
+
+// lockoutDuration doubles the base lockout for every threshold's worth of
+// consecutive failures past the first.
+func (p loginPolicy) lockoutDuration(failures int64) time.Duration {
+	d := p.lockoutBase
+	for n := failures / p.lockoutThreshold; n > 1 && d < p.lockoutMax; n-- {
+		d *= 2
+	}
+	return min(d, p.lockoutMax)
+}
+
+// loginGuard applies rate limits and lockouts around password checks. Store
+// errors are logged and the attempt is let through, so a Redis outage does
+// not lock every user out.
+type loginGuard struct {
+	attempts domain.LoginAttemptRepository
+	policy   loginPolicy
+	now      func() time.Time
+}
+
+func newLoginGuard(attempts domain.LoginAttemptRepository) *loginGuard {
+	return &loginGuard{attempts: attempts, policy: loginPolicyFromEnv(), now: time.Now}
+}
+
+func loginKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// check returns an error if the username is locked or the IP or username has
+// exceeded its rate limit. Every call counts as an attempt.
+func (g *loginGuard) check(ctx context.Context, username, ip string) error {
+	if g == nil {
+		return nil
+	}
+	now := g.now()
+	key := loginKey(username)
+	until, err := g.attempts.LockedUntil(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "login lockout check failed", "error", err)
+	} else if until.After(now) {
+		return &domain.AccountLockedError{Until: until}
+	}
+	limits := []struct {
+		scope string
+		key   string
+		limit int64
+	}{
+		{"ip", ip, g.policy.perIP},
+		{"username", key, g.policy.perUsername},
+	}
+	for _, l := range limits {
+		if l.key == "" || l.limit <= 0 {
+			continue
+		}
+		count, oldest, err := g.attempts.CountAttempt(ctx, l.scope+":"+l.key, g.policy.window, now)
+		if err != nil {
+			slog.WarnContext(ctx, "login rate limit check failed", "scope", l.scope, "error", err)
+			continue
+		}
+		if count > l.limit {
+			audit.Record(ctx, audit.EventLoginRateLimited, "scope", l.scope, "username", key, "client_ip", ip)
+			return &domain.TooManyAttemptsError{RetryAfter: max(oldest.Add(g.policy.window).Sub(now), time.Second)}
+		}
+	}
+	return nil
+}
+
+// failed records a failed password check and locks the username when it
+// reaches a multiple of the lockout threshold.
+func (g *loginGuard) failed(ctx context.Context, username, ip string) {
+	if g == nil {
+		return
+	}
+	key := loginKey(username)
+	failures, err := g.attempts.RecordFailure(ctx, key, g.policy.failureTTL)
+	if err != nil {
+		slog.WarnContext(ctx, "recording login failure failed", "error", err)
+		return
+	}
+	if g.policy.lockoutThreshold <= 0 || failures%g.policy.lockoutThreshold != 0 {
+		return
+	}
+	duration := g.policy.lockoutDuration(failures)
+	until := g.now().Add(duration)
+	if err := g.attempts.Lock(ctx, key, until); err != nil {
+		slog.WarnContext(ctx, "locking account failed", "error", err)
+		return
+	}
+	audit.Record(ctx, audit.EventLoginLockedOut,
+		"username", key,
+		"client_ip", ip,
+		"failures", failures,
+		"locked_until", until.UTC().Format(time.RFC3339),
+		"lockout_seconds", int64(duration.Seconds()),
+	)
+}
+
+func (g *loginGuard) succeeded(ctx context.Context, username string) {
+	if g == nil {
+		return
+	}
+	if err := g.attempts.ClearFailures(ctx, loginKey(username)); err != nil {
+		slog.WarnContext(ctx, "clearing login failures failed", "error", err)
+	}
+}
+
+func envInt(name string, def int64) int64 {
+	if v, err := strconv.ParseInt(os.Getenv(name), 10, 64); err == nil {
+		return v
+	}
+	return def
+}
+
+func envDuration(name string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(name)); err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	p := loginPolicy{lockoutThreshold: 5, lockoutBase: time.Minute, lockoutMax: time.Hour}
+
+	tests := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{failures: 5, want: time.Minute},
+		{failures: 9, want: time.Minute},
+		{failures: 10, want: 2 * time.Minute},
+		{failures: 15, want: 4 * time.Minute},
+		{failures: 30, want: 32 * time.Minute},
+		{failures: 35, want: time.Hour},
+		{failures: 500, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := p.lockoutDuration(tt.failures); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginGuard(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Each step fails (or, with ok, passes) a login at the given time after
+	// start; wantErr is what the guard's check returns before it.
+	type step struct {
+		after   time.Duration
+		ip      string
+		ok      bool
+		wantErr string
+	}
+	tests := []struct {
+		name   string
+		policy loginPolicy
+		steps  []step
+	}{
+		{
+			name:   "locked after the threshold",
+			policy: loginPolicy{window: time.Minute, lockoutThreshold: 3, lockoutBase: time.Minute, lockoutMax: time.Hour},
+			steps: []step{
+				{}, {}, {},
+				{after: 30 * time.Second, wantErr: "locked"},
+				{after: 61 * time.Second},
+			},
+		},
+		{
+			name:   "success resets the failures",
+			policy: loginPolicy{window: time.Minute, lockoutThreshold: 3, lockoutBase: time.Minute, lockoutMax: time.Hour},
+			steps: []step{
+				{}, {}, {ok: true}, {}, {},
+				{after: time.Second},
+			},
+		},
+		{
+			name:   "second lockout is longer",
+			policy: loginPolicy{window: time.Minute, lockoutThreshold: 2, lockoutBase: time.Minute, lockoutMax: time.Hour},
+			steps: []step{
+				{}, {},
+				{after: 61 * time.Second}, {after: 61 * time.Second},
+				{after: 3 * time.Minute, wantErr: "locked"},
+				{after: 3*time.Minute + 2*time.Second},
+			},
+		},
+		{
+			name:   "username rate limit",
+			policy: loginPolicy{window: time.Minute, perUsername: 2},
+			steps: []step{
+				{ok: true}, {ok: true},
+				{after: 10 * time.Second, ok: true, wantErr: "rate limited"},
+				{after: 61 * time.Second, ok: true},
+			},
+		},
+		{
+			name:   "ip rate limit",
+			policy: loginPolicy{window: time.Minute, perIP: 1},
+			steps: []step{
+				{ip: "10.0.0.1", ok: true},
+				{ip: "10.0.0.2", ok: true},
+				{ip: "10.0.0.1", ok: true, wantErr: "rate limited"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			attempts := newMemLoginAttempts()
+			g := &loginGuard{attempts: attempts, policy: tt.policy, now: func() time.Time { return now }}
+			ctx := context.Background()
+			for i, s := range tt.steps {
+				now = start.Add(s.after)
+				err := g.check(ctx, "Alice", s.ip)
+				var locked *domain.AccountLockedError
+				var limited *domain.TooManyAttemptsError
+				got := ""
+				switch {
+				case errors.As(err, &locked):
+					got = "locked"
+				case errors.As(err, &limited):
+					got = "rate limited"
+				case err != nil:
+					t.Fatalf("step %d: unexpected error %v", i, err)
+				}
+				if got != s.wantErr {
+					t.Fatalf("step %d: check = %q, want %q", i, got, s.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				if s.ok {
+					g.succeeded(ctx, "alice")
+				} else {
+					g.failed(ctx, "alice ", s.ip)
+				}
+			}
+		})
+	}
+}
+
+func TestNilLoginGuard(t *testing.T) {
+	var g *loginGuard
+	ctx := context.Background()
+	if err := g.check(ctx, "alice", "10.0.0.1"); err != nil {
+		t.Fatalf("check = %v, want nil", err)
+	}
+	g.failed(ctx, "alice", "10.0.0.1")
+	g.succeeded(ctx, "alice")
+}
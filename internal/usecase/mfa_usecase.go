@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
+)
+
+const (
+	totpPeriod         = 30
+	totpSkew           = 1
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// recoveryCodeAlphabet leaves out characters that are easy to misread.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+var (
+	errTOTPEnabled = domain.NewFailedPrecondition("two-factor authentication is already enabled").WithReason("TOTP_ALREADY_ENABLED")
+	errInvalidCode = domain.NewFieldError("code", "is invalid")
+	errCodeReused  = domain.NewFieldError("code", "has already been used")
+	// errChallengeSpent is returned for a challenge that was redeemed or ran
+	// out of attempts; the user has to enter their password again.
+	errChallengeSpent = domain.NewFailedPrecondition("mfa challenge is no longer valid, sign in again").WithReason("MFA_CHALLENGE_SPENT")
+)
+
+type mfaUsecase struct {
+	repo       domain.MFARepository
+	users      domain.UserRepository
+	challenges domain.MFAChallengeRepository
+	guard      *loginGuard
+	issuer     string
+	// maxChallengeAttempts is how many codes one MFA challenge accepts.
+	maxChallengeAttempts int64
+	now                  func() time.Time
+}
+
+// NewMFAUsecase builds the MFA service. attempts may be nil, like for
+// NewUserUsecase, in which case wrong codes do not lock the account.
+// MFA_CHALLENGE_ATTEMPTS (default 5) caps the codes tried per challenge.
+func NewMFAUsecase(repo domain.MFARepository, users domain.UserRepository, challenges domain.MFAChallengeRepository, attempts domain.LoginAttemptRepository) domain.MFAService {
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = "GoStream"
+	}
+	u := &mfaUsecase{
+		repo:                 repo,
+		users:                users,
+		challenges:           challenges,
+		issuer:               issuer,
+		maxChallengeAttempts: envInt("MFA_CHALLENGE_ATTEMPTS", 5),
+		now:                  time.Now,
+	}
+	if attempts != nil {
+		u.guard = newLoginGuard(attempts)
+	}
+	return u
+}
+
+func (u *mfaUsecase) getUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := u.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.NewNotFound("user", userID.String())
+	}
+	return user, nil
+}
+
+func (u *mfaUsecase) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPEnrollment, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, errTOTPEnabled
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      u.issuer,
+		AccountName: user.Username,
+		Period:      totpPeriod,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating totp secret: %w", err)
+	}
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return nil, fmt.Errorf("rendering qr code: %w", err)
+	}
+	var qr bytes.Buffer
+	if err := png.Encode(&qr, img); err != nil {
+		return nil, fmt.Errorf("encoding qr code: %w", err)
+	}
+	if err := u.users.UpdateFields(ctx, user.ID, map[string]any{"totp_secret": key.Secret(), "totp_last_step": 0}); err != nil {
+		return nil, fmt.Errorf("saving totp secret: %w", err)
+	}
+	return &domain.TOTPEnrollment{
+		Secret:    key.Secret(),
+		URI:       key.URL(),
+		QRCodePNG: qr.Bytes(),
+	}, nil
+}
+
+func (u *mfaUsecase) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, errTOTPEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, domain.NewFailedPrecondition("totp enrollment has not been started").WithReason("TOTP_NOT_ENROLLED")
+	}
+	if err := u.checkTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+	codes, err := u.issueRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.users.UpdateFields(ctx, user.ID, map[string]any{"totp_enabled": true}); err != nil {
+		return nil, fmt.Errorf("enabling totp: %w", err)
+	}
+	return codes, nil
+}
+
+func (u *mfaUsecase) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := u.VerifyCode(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+	fields := map[string]any{"totp_enabled": false, "totp_secret": "", "totp_last_step": 0}
+	if err := u.users.UpdateFields(ctx, user.ID, fields); err != nil {
+		return fmt.Errorf("disabling totp: %w", err)
+	}
+	return u.repo.DeleteRecoveryCodes(ctx, user.ID)
+}
+
+func (u *mfaUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := u.VerifyCode(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	return u.issueRecoveryCodes(ctx, user.ID)
+}
+
+func (u *mfaUsecase) VerifyCode(ctx context.Context, userID uuid.UUID, code string) (*domain.User, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, domain.NewFailedPrecondition("two-factor authentication is not enabled").WithReason("TOTP_DISABLED")
+	}
+	code = normalizeCode(code)
+	if len(code) == recoveryCodeLength {
+		used, err := u.repo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code), u.now())
+		if err != nil {
+			return nil, err
+		}
+		if !used {
+			return nil, errInvalidCode
+		}
+		return user, nil
+	}
+	if err := u.checkTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *mfaUsecase) VerifyChallenge(ctx context.Context, userID uuid.UUID, challengeID, code string) (*domain.User, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ip := utils.GetClientIP(ctx)
+	if err := u.guard.check(ctx, user.Username, ip); err != nil {
+		return nil, err
+	}
+	// Unlike the login guard, the challenge store fails closed: without it a
+	// challenge could be replayed.
+	attempts, err := u.challenges.CountAttempt(ctx, challengeID, utils.MFAChallengeTokenDuration)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > u.maxChallengeAttempts {
+		return nil, errChallengeSpent
+	}
+	// The challenge is reserved before the code is checked, so a repeated
+	// submit cannot burn a recovery code or TOTP step and then fail.
+	fresh, err := u.challenges.Use(ctx, challengeID, utils.MFAChallengeTokenDuration)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, errChallengeSpent
+	}
+	verified, err := u.VerifyCode(ctx, userID, code)
+	if err != nil {
+		if releaseErr := u.challenges.Release(ctx, challengeID); releaseErr != nil {
+			slog.WarnContext(ctx, "releasing mfa challenge failed", "error", releaseErr)
+		}
+		if errors.Is(err, errInvalidCode) || errors.Is(err, errCodeReused) {
+			u.guard.failed(ctx, user.Username, ip)
+		}
+		return nil, err
+	}
+	u.guard.succeeded(ctx, user.Username)
+	return verified, nil
+}
+
+// checkTOTP accepts a code from the current or an adjacent time step, and
+// only if that step is later than the last one the user redeemed.
+func (u *mfaUsecase) checkTOTP(ctx context.Context, user *domain.User, code string) error {
+	now := u.now()
+	current := now.Unix() / totpPeriod
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		step := current + int64(skew)
+		expected, err := totp.GenerateCodeCustom(user.TOTPSecret, time.Unix(step*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return fmt.Errorf("generating totp code: %w", err)
+		}
+		if !constantTimeEqual(expected, code) {
+			continue
+		}
+		fresh, err := u.repo.AdvanceTOTPStep(ctx, user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return errCodeReused
+		}
+		user.TOTPLastStep = step
+		return nil
+	}
+	return errInvalidCode
+}
+
+func (u *mfaUsecase) issueRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	buf := make([]byte, recoveryCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+		codes[i] = sb.String()
+		hashes[i] = hashRecoveryCode(normalizeCode(codes[i]))
+	}
+	if err := u.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func constantTimeEqual(a, b string) bool {
+	return len(a) == len(b) && subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	testTOTPSecret   = "JBSWY3DPEHPK3PXP"
+	testRecoveryCode = "abcdefghjk"
+)
+
+type memUsers struct {
+	domain.UserRepository
+	users map[uuid.UUID]*domain.User
+}
+
+func (m *memUsers) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if user, ok := m.users[id]; ok {
+		copied := *user
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *memUsers) Update(ctx context.Context, user *domain.User) error {
+	copied := *user
+	m.users[user.ID] = &copied
+	return nil
+}
+
+type memMFA struct {
+	domain.MFARepository
+	users *memUsers
+	// recovery maps unused recovery code hashes to true.
+	recovery map[string]bool
+}
+
+func (m *memMFA) AdvanceTOTPStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	user := m.users.users[userID]
+	if user.TOTPLastStep >= step {
+		return false, nil
+	}
+	user.TOTPLastStep = step
+	return true, nil
+}
+
+func (m *memMFA) UseRecoveryCode(ctx context.Context, userID uuid.UUID, hash string, at time.Time) (bool, error) {
+	if !m.recovery[hash] {
+		return false, nil
+	}
+	delete(m.recovery, hash)
+	return true, nil
+}
+
+type memChallenges struct {
+	attempts map[string]int64
+	used     map[string]bool
+}
+
+func (m *memChallenges) CountAttempt(ctx context.Context, id string, ttl time.Duration) (int64, error) {
+	m.attempts[id]++
+	return m.attempts[id], nil
+}
+
+func (m *memChallenges) Use(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	if m.used[id] {
+		return false, nil
+	}
+	m.used[id] = true
+	return true, nil
+}
+
+func (m *memChallenges) Release(ctx context.Context, id string) error {
+	delete(m.used, id)
+	return nil
+}
+
+type memLoginAttempts struct {
+	attempts map[string][]time.Time
+	failures map[string]int64
+	locks    map[string]time.Time
+}
+
+func newMemLoginAttempts() *memLoginAttempts {
+	return &memLoginAttempts{attempts: map[string][]time.Time{}, failures: map[string]int64{}, locks: map[string]time.Time{}}
+}
+
+func (m *memLoginAttempts) CountAttempt(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, error) {
+	var kept []time.Time
+	for _, at := range m.attempts[key] {
+		if at.After(now.Add(-window)) {
+			kept = append(kept, at)
+		}
+	}
+	kept = append(kept, now)
+	m.attempts[key] = kept
+	return int64(len(kept)), kept[0], nil
+}
+
+func (m *memLoginAttempts) RecordFailure(ctx context.Context, username string, ttl time.Duration) (int64, error) {
+	m.failures[username]++
+	return m.failures[username], nil
+}
+
+func (m *memLoginAttempts) ClearFailures(ctx context.Context, username string) error {
+	delete(m.failures, username)
+	return nil
+}
+
+func (m *memLoginAttempts) Lock(ctx context.Context, username string, until time.Time) error {
+	m.locks[username] = until
+	return nil
+}
+
+func (m *memLoginAttempts) LockedUntil(ctx context.Context, username string) (time.Time, error) {
+	return m.locks[username], nil
+}
+
+// newTestMFA returns an MFA service for one user with 2FA enabled, whose
+// clock is read from *now.
+func newTestMFA(t *testing.T, now *time.Time) (*mfaUsecase, *domain.User, *memLoginAttempts) {
+	t.Helper()
+	user := &domain.User{Username: "alice", TOTPEnabled: true, TOTPSecret: testTOTPSecret}
+	user.ID = uuid.New()
+	users := &memUsers{users: map[uuid.UUID]*domain.User{user.ID: user}}
+	attempts := newMemLoginAttempts()
+	clock := func() time.Time { return *now }
+	u := &mfaUsecase{
+		repo:                 &memMFA{users: users, recovery: map[string]bool{hashRecoveryCode(testRecoveryCode): true}},
+		users:                users,
+		challenges:           &memChallenges{attempts: map[string]int64{}, used: map[string]bool{}},
+		guard:                &loginGuard{attempts: attempts, policy: testLoginPolicy(), now: clock},
+		maxChallengeAttempts: 3,
+		now:                  clock,
+	}
+	return u, user, attempts
+}
+
+func testLoginPolicy() loginPolicy {
+	return loginPolicy{
+		window:           time.Minute,
+		perIP:            100,
+		perUsername:      100,
+		lockoutThreshold: 5,
+		lockoutBase:      time.Minute,
+		lockoutMax:       time.Hour,
+		failureTTL:       time.Hour,
+	}
+}
+
+// codeAt returns the TOTP code for the step containing at.
+func codeAt(t *testing.T, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCodeCustom(testTOTPSecret, at, totp.ValidateOpts{
+		Period:    totpPeriod,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestVerifyCodeRejectsReplayedSteps(t *testing.T) {
+	start := time.Unix(1_800_000_000-1_800_000_000%totpPeriod, 0)
+	step := totpPeriod * time.Second
+
+	// Each case runs against the same user in order, so later cases see the
+	// steps redeemed by earlier ones.
+	tests := []struct {
+		name    string
+		now     time.Time
+		codeFor time.Time
+		wantErr error
+	}{
+		{name: "current step", now: start, codeFor: start},
+		{name: "same code again", now: start.Add(5 * time.Second), codeFor: start, wantErr: errCodeReused},
+		{name: "previous step after the current one", now: start, codeFor: start.Add(-step), wantErr: errCodeReused},
+		{name: "next step within the skew", now: start, codeFor: start.Add(step)},
+		{name: "step before the last redeemed one", now: start.Add(2 * step), codeFor: start, wantErr: errInvalidCode},
+		{name: "later step", now: start.Add(2 * step), codeFor: start.Add(2 * step)},
+		{name: "wrong code", now: start.Add(3 * step), codeFor: start.Add(10 * step), wantErr: errInvalidCode},
+	}
+	now := start
+	u, user, _ := newTestMFA(t, &now)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = tt.now
+			_, err := u.VerifyCode(context.Background(), user.ID, codeAt(t, tt.codeFor))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyChallenge(t *testing.T) {
+	step := totpPeriod * time.Second
+
+	tests := []struct {
+		name string
+		// wrong is how many wrong codes are entered before the right one.
+		wrong   int
+		reuse   bool
+		wantErr error
+	}{
+		{name: "right code"},
+		{name: "after wrong codes", wrong: 2},
+		{name: "attempts used up", wrong: 3, wantErr: errChallengeSpent},
+		{name: "challenge reused", reuse: true, wantErr: errChallengeSpent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1_800_000_000, 0)
+			u, user, attempts := newTestMFA(t, &now)
+			ctx := context.Background()
+			challenge := uuid.NewString()
+			for range tt.wrong {
+				if _, err := u.VerifyChallenge(ctx, user.ID, challenge, "000000"); err == nil {
+					t.Fatal("wrong code accepted")
+				}
+			}
+			if got := attempts.failures["alice"]; got != int64(tt.wrong) {
+				t.Errorf("recorded %d failures, want %d", got, tt.wrong)
+			}
+			if tt.reuse {
+				if _, err := u.VerifyChallenge(ctx, user.ID, challenge, codeAt(t, now)); err != nil {
+					t.Fatalf("first use: %v", err)
+				}
+				now = now.Add(step)
+			}
+			_, err := u.VerifyChallenge(ctx, user.ID, challenge, codeAt(t, now))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && attempts.failures["alice"] != 0 {
+				t.Errorf("failures not cleared after a successful challenge")
+			}
+		})
+	}
+}
+
+func TestVerifyChallengeWrongCodesLockTheAccount(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	u, user, _ := newTestMFA(t, &now)
+	ctx := context.Background()
+	// Each password login hands out a new challenge; the failures carry over.
+	for i := range u.guard.policy.lockoutThreshold {
+		challenge := uuid.NewString()
+		if _, err := u.VerifyChallenge(ctx, user.ID, challenge, "000000"); !errors.Is(err, errInvalidCode) {
+			t.Fatalf("attempt %d: error = %v, want %v", i, err, errInvalidCode)
+		}
+	}
+	_, err := u.VerifyChallenge(ctx, user.ID, uuid.NewString(), codeAt(t, now))
+	var locked *domain.AccountLockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("error = %v, want an account lockout", err)
+	}
+}
+
+func TestVerifyChallengeReplayKeepsRecoveryCode(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	u, user, _ := newTestMFA(t, &now)
+	ctx := context.Background()
+	challenge := uuid.NewString()
+	if _, err := u.VerifyChallenge(ctx, user.ID, challenge, codeAt(t, now)); err != nil {
+		t.Fatalf("first submit: %v", err)
+	}
+	if _, err := u.VerifyChallenge(ctx, user.ID, challenge, testRecoveryCode); !errors.Is(err, errChallengeSpent) {
+		t.Fatalf("repeated submit: error = %v, want %v", err, errChallengeSpent)
+	}
+	if _, err := u.VerifyChallenge(ctx, user.ID, uuid.NewString(), testRecoveryCode); err != nil {
+		t.Fatalf("recovery code was burnt by the repeated submit: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// oidcLoginTTL bounds how long a user may take at the identity provider.
+const oidcLoginTTL = 10 * time.Minute
+
+var usernameDisallowed = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type oidcUsecase struct {
+	providers  map[string]domain.OIDCProvider
+	states     domain.OIDCStateRepository
+	identities domain.ExternalIdentityRepository
+	users      domain.UserService
+}
+
+func NewOIDCUsecase(providers []domain.OIDCProvider, states domain.OIDCStateRepository, identities domain.ExternalIdentityRepository, users domain.UserService) domain.OIDCService {
+	byName := make(map[string]domain.OIDCProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+	return &oidcUsecase{
+		providers:  byName,
+		states:     states,
+		identities: identities,
+		users:      users,
+	}
+}
+
+func (u *oidcUsecase) Providers() []string {
+	names := make([]string, 0, len(u.providers))
+	for name := range u.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (u *oidcUsecase) provider(name string) (domain.OIDCProvider, error) {
+	p, ok := u.providers[name]
+	if !ok {
+		return nil, domain.NewNotFound("identity provider", name)
+	}
+	return p, nil
+}
+
+func (u *oidcUsecase) StartLogin(ctx context.Context, providerName string) (string, string, error) {
+	p, err := u.provider(providerName)
+	if err != nil {
+		return "", "", err
+	}
+	login := domain.OIDCLoginState{Provider: p.Name()}
+	var state, binding string
+	for _, v := range []*string{&state, &binding, &login.Nonce, &login.Verifier} {
+		if *v, err = randomURLToken(); err != nil {
+			return "", "", err
+		}
+	}
+	login.BindingHash = hashLoginBinding(binding)
+	authURL, err := p.AuthCodeURL(ctx, state, login.Nonce, login.Verifier)
+	if err != nil {
+		return "", "", err
+	}
+	if err := u.states.Save(ctx, state, login, oidcLoginTTL); err != nil {
+		return "", "", err
+	}
+	return authURL, binding, nil
+}
+
+// hashLoginBinding hashes the binding secret of a login. The state store
+// only keeps the hash, so reading it does not let anyone complete logins.
+func hashLoginBinding(binding string) string {
+	sum := sha256.Sum256([]byte(binding))
+	return hex.EncodeToString(sum[:])
+}
+
+func (u *oidcUsecase) CompleteLogin(ctx context.Context, providerName, state, code, binding string) (*domain.User, error) {
+	p, err := u.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	if state == "" || code == "" {
+		return nil, domain.NewInvalidArgument("state and code are required")
+	}
+	login, err := u.states.Take(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	// A state redeemed without the binding secret was started by someone
+	// else, who may be trying to sign the caller in to their own account.
+	if login == nil || login.Provider != p.Name() || !constantTimeEqual(login.BindingHash, hashLoginBinding(binding)) {
+		return nil, domain.NewFailedPrecondition("login state is invalid or has expired").WithReason("LOGIN_STATE_INVALID")
+	}
+	claims, err := p.Exchange(ctx, code, login.Verifier, login.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("id token has no subject")
+	}
+	user, err := u.userFor(ctx, p.Name(), claims)
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.NewPermissionDenied("account is disabled").WithReason("ACCOUNT_DISABLED")
+	}
+	return user, nil
+}
+
+// userFor returns the user linked to the external account, provisioning a
+// new one on first login.
+func (u *oidcUsecase) userFor(ctx context.Context, provider string, claims *domain.OIDCClaims) (*domain.User, error) {
+	identity, err := u.identities.GetByProviderSubject(ctx, provider, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		return u.linkedUser(ctx, identity)
+	}
+
+	username, err := u.availableUsername(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	password, err := randomURLToken()
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.users.CreateUser(ctx, &domain.User{
+		Username:  username,
+		Password:  password,
+		FirstName: truncate(claims.GivenName, 100),
+		LastName:  truncate(claims.FamilyName, 100),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("provisioning user: %w", err)
+	}
+	err = u.identities.Create(ctx, &domain.ExternalIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		// A concurrent login for the same account may have won the race.
+		existing, lookupErr := u.identities.GetByProviderSubject(ctx, provider, claims.Subject)
+		if lookupErr != nil || existing == nil {
+			return nil, err
+		}
+		if delErr := u.users.DeleteUser(ctx, user.ID); delErr != nil {
+			slog.WarnContext(ctx, "error removing duplicate provisioned user", "user_id", user.ID, "error", delErr)
+		}
+		return u.linkedUser(ctx, existing)
+	}
+	slog.InfoContext(ctx, "provisioned user from identity provider", "provider", provider, "user_id", user.ID)
+	return user, nil
+}
+
+func (u *oidcUsecase) linkedUser(ctx context.Context, identity *domain.ExternalIdentity) (*domain.User, error) {
+	user, err := u.users.GetUserByID(ctx, identity.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("linked user no longer exists")
+	}
+	return user, nil
+}
+
+// availableUsername derives a username from the preferred_username or email
+// claim, adding a random suffix when it is already taken.
+func (u *oidcUsecase) availableUsername(ctx context.Context, provider string, claims *domain.OIDCClaims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = strings.Trim(usernameDisallowed.ReplaceAllString(strings.ToLower(base), "-"), "-.")
+	if len(base) < 3 {
+		base = provider + "-user"
+	}
+	base = truncate(base, 40)
+	candidate := base
+	for range 5 {
+		existing, err := u.users.GetByUsername(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("lookup user: %w", err)
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", fmt.Errorf("generating username suffix: %w", err)
+		}
+		candidate = base + "-" + hex.EncodeToString(suffix)
+	}
+	return "", fmt.Errorf("could not find a free username for %q", base)
+}
+
+// randomURLToken returns 32 random bytes encoded for use in URLs. The result
+// is also a valid PKCE code verifier.
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+var errExchanged = errors.New("exchanged")
+
+// stubProvider puts the state in the authorization URL and fails every
+// exchange with errExchanged, which marks a login that got past the state.
+type stubProvider struct{}
+
+func (stubProvider) Name() string { return "stub" }
+
+func (stubProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	return "https://idp.example/authorize?state=" + url.QueryEscape(state), nil
+}
+
+func (stubProvider) Exchange(ctx context.Context, code, verifier, nonce string) (*domain.OIDCClaims, error) {
+	return nil, errExchanged
+}
+
+type memOIDCStates map[string]domain.OIDCLoginState
+
+func (m memOIDCStates) Save(ctx context.Context, state string, login domain.OIDCLoginState, ttl time.Duration) error {
+	m[state] = login
+	return nil
+}
+
+func (m memOIDCStates) Take(ctx context.Context, state string) (*domain.OIDCLoginState, error) {
+	login, ok := m[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(m, state)
+	return &login, nil
+}
+
+func TestCompleteLoginRequiresBinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		binding func(own, other string) string
+		wantErr error
+	}{
+		{name: "binding of the login", binding: func(own, other string) string { return own }, wantErr: errExchanged},
+		{name: "no binding", binding: func(own, other string) string { return "" }},
+		{name: "binding of another login", binding: func(own, other string) string { return other }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewOIDCUsecase([]domain.OIDCProvider{stubProvider{}}, memOIDCStates{}, nil, nil)
+			ctx := context.Background()
+			authURL, own, err := u.StartLogin(ctx, "stub")
+			if err != nil {
+				t.Fatalf("StartLogin: %v", err)
+			}
+			_, other, err := u.StartLogin(ctx, "stub")
+			if err != nil {
+				t.Fatalf("StartLogin: %v", err)
+			}
+			parsed, err := url.Parse(authURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = u.CompleteLogin(ctx, "stub", parsed.Query().Get("state"), "code", tt.binding(own, other))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if e, ok := domain.AsError(err); !ok || e.Reason != "LOGIN_STATE_INVALID" {
+				t.Fatalf("error = %v, want an invalid login state", err)
+			}
+		})
+	}
+}
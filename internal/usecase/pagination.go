@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// openPage resolves the page token of opts against its (already defaulted)
+// sort and turns Page into an Offset, then raises Limit by one: getting the
+// extra row back tells closePage that another page follows. It returns the
+// limit the caller asked for.
+func openPage(opts *domain.BaseFetchOptions) (int, error) {
+	after, err := domain.DecodeCursor(opts.PageToken, opts.Sort)
+	if err != nil {
+		return 0, err
+	}
+	opts.After = after
+	limit := opts.Limit
+	if opts.Page > 0 {
+		opts.Offset = (opts.Page - 1) * limit
+		opts.Page = 0
+	}
+	opts.Limit = limit + 1
+	return limit, nil
+}
+
+// closePage trims the extra row fetched by openPage and returns the token
+// for the next page, or "" on the last one.
+func closePage[T any](items []T, limit int, sort domain.Sort, key func(T) (string, uuid.UUID)) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	value, id := key(items[limit-1])
+	return items, domain.Cursor{Sort: sort.String(), Value: value, ID: id}.Encode()
+}
+
+func videoSortValue(field string) func(domain.Video) (string, uuid.UUID) {
+	return func(v domain.Video) (string, uuid.UUID) {
+		switch field {
+		case domain.SortViews:
+			return strconv.FormatInt(v.Views, 10), v.ID
+		case domain.SortTitle:
+			return v.Title, v.ID
+		case domain.SortRelevance:
+			return strconv.FormatFloat(v.SearchRank, 'g', -1, 64), v.ID
+		default:
+			return v.CreatedAt.Format(time.RFC3339Nano), v.ID
+		}
+	}
+}
+
+func playlistSortValue(field string) func(domain.Playlist) (string, uuid.UUID) {
+	return func(p domain.Playlist) (string, uuid.UUID) {
+		switch field {
+		case domain.SortCreatedAt:
+			return p.CreatedAt.Format(time.RFC3339Nano), p.ID
+		case domain.SortTitle:
+			return p.Title, p.ID
+		default:
+			return p.UpdatedAt.Format(time.RFC3339Nano), p.ID
+		}
+	}
+}
+
+func commentSortValue(field string) func(domain.Comment) (string, uuid.UUID) {
+	return func(c domain.Comment) (string, uuid.UUID) {
+		if field == domain.SortTop {
+			return strconv.FormatInt(c.LikeCount+c.ReplyCount, 10), c.ID
+		}
+		return c.CreatedAt.Format(time.RFC3339Nano), c.ID
+	}
+}
+
+func userSortValue(field string) func(domain.User) (string, uuid.UUID) {
+	return func(u domain.User) (string, uuid.UUID) {
+		if field == domain.SortUsername {
+			return u.Username, u.ID
+		}
+		return u.CreatedAt.Format(time.RFC3339Nano), u.ID
+	}
+}
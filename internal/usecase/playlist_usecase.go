@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type playlistUsecase struct {
+	repo     domain.PlaylistRepository
+	videos   domain.VideoRepository
+	validate *validator.Validate
+}
+
+var (
+	errInvalidPlaylistID = domain.NewFieldError("playlist_id", "must be a valid UUID")
+	errInvalidItemID     = domain.NewFieldError("item_id", "must be a valid UUID")
+	errInvalidVisibility = domain.NewFieldError("visibility", "must be one of: PUBLIC, UNLISTED, PRIVATE")
+	errNotPlaylistOwner  = domain.NewPermissionDenied("playlist does not belong to the current user")
+)
+
+func NewPlaylistUsecase(repo domain.PlaylistRepository, videos domain.VideoRepository) domain.PlaylistService {
+	return &playlistUsecase{
+		repo:     repo,
+		videos:   videos,
+		validate: newValidator(),
+	}
+}
+
+// visiblePlaylist loads a playlist, reporting private playlists of other
+// users as missing.
+func (u *playlistUsecase) visiblePlaylist(ctx context.Context, viewer uuid.UUID, id string) (*domain.Playlist, error) {
+	playlistID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errInvalidPlaylistID
+	}
+	playlist, err := u.repo.FindByID(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	if !playlist.VisibleTo(viewer) {
+		return nil, domain.NewNotFound("playlist", id)
+	}
+	return playlist, nil
+}
+
+// ownPlaylist loads a playlist the caller is about to change.
+func (u *playlistUsecase) ownPlaylist(ctx context.Context, userID uuid.UUID, id string) (*domain.Playlist, error) {
+	playlist, err := u.visiblePlaylist(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.UserID != userID {
+		return nil, errNotPlaylistOwner
+	}
+	return playlist, nil
+}
+
+func (u *playlistUsecase) Create(ctx context.Context, userID uuid.UUID, playlist *domain.Playlist) (*domain.Playlist, error) {
+	playlist.UserID = userID
+	if playlist.Visibility == "" {
+		playlist.Visibility = domain.PlaylistPrivate
+	}
+	if !playlist.Visibility.Valid() {
+		return nil, errInvalidVisibility
+	}
+	if err := u.validate.Struct(playlist); err != nil {
+		return nil, invalidInput(err, "")
+	}
+	created, err := u.repo.Create(ctx, playlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+	return created, nil
+}
+
+func (u *playlistUsecase) Get(ctx context.Context, viewer uuid.UUID, id string) (*domain.Playlist, error) {
+	playlist, err := u.visiblePlaylist(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+	playlist.Items, err = u.repo.Items(ctx, playlist.ID, viewer)
+	if err != nil {
+		return nil, err
+	}
+	return playlist, nil
+}
+
+func (u *playlistUsecase) Update(ctx context.Context, userID uuid.UUID, id string, update domain.PlaylistUpdate) (*domain.Playlist, error) {
+	playlist, err := u.ownPlaylist(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if update.Title != nil {
+		playlist.Title = *update.Title
+	}
+	if update.Description != nil {
+		playlist.Description = *update.Description
+	}
+	if update.Visibility != nil {
+		if !update.Visibility.Valid() {
+			return nil, errInvalidVisibility
+		}
+		playlist.Visibility = *update.Visibility
+	}
+	if err := u.validate.Struct(playlist); err != nil {
+		return nil, invalidInput(err, "")
+	}
+	if err := u.repo.Update(ctx, playlist); err != nil {
+		return nil, fmt.Errorf("failed to update playlist: %w", err)
+	}
+	return playlist, nil
+}
+
+func (u *playlistUsecase) Delete(ctx context.Context, userID uuid.UUID, id string) error {
+	playlist, err := u.ownPlaylist(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.Delete(ctx, playlist.ID); err != nil {
+		return fmt.Errorf("failed to delete playlist: %w", err)
+	}
+	return nil
+}
+
+func (u *playlistUsecase) List(ctx context.Context, viewer uuid.UUID, opts domain.PlaylistFetchOptions) (*domain.MultiplePlaylistResponse, error) {
+	if opts.UserID == uuid.Nil {
+		return nil, domain.NewFieldError("user_id", "is required")
+	}
+	if opts.UserID != viewer {
+		opts.Visibilities = []domain.PlaylistVisibility{domain.PlaylistPublic}
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	if opts.Sort.Field == "" {
+		opts.Sort = domain.Sort{Field: domain.SortUpdatedAt, Desc: true}
+	}
+	limit, err := openPage(&opts.BaseFetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	playlists, total, err := u.repo.Find(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	playlists, next := closePage(playlists, limit, opts.Sort, playlistSortValue(opts.Sort.Field))
+	return &domain.MultiplePlaylistResponse{Playlists: playlists, Total: total, NextPageToken: next}, nil
+}
+
+func (u *playlistUsecase) AddItem(ctx context.Context, userID uuid.UUID, playlistID, videoID string, position int) (*domain.PlaylistItem, error) {
+	playlist, err := u.ownPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	vid, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	video, err := u.videos.FindByID(ctx, vid)
+	if err != nil {
+		return nil, err
+	}
+	// Videos that are not ready can only be added by their uploader.
+	if video.Status != domain.VideoStatusReady && video.UserID != userID {
+		return nil, domain.NewNotFound("video", videoID)
+	}
+	item := &domain.PlaylistItem{PlaylistID: playlist.ID, VideoID: video.ID, Position: position}
+	if err := u.repo.AddItem(ctx, item); err != nil {
+		return nil, err
+	}
+	item.Video = video
+	return item, nil
+}
+
+func (u *playlistUsecase) RemoveItem(ctx context.Context, userID uuid.UUID, playlistID, itemID string) error {
+	playlist, err := u.ownPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return errInvalidItemID
+	}
+	return u.repo.RemoveItem(ctx, playlist.ID, id)
+}
+
+func (u *playlistUsecase) MoveItem(ctx context.Context, userID uuid.UUID, playlistID, itemID string, position int) (*domain.Playlist, error) {
+	playlist, err := u.ownPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, errInvalidItemID
+	}
+	if position < 0 {
+		return nil, domain.NewFieldError("position", "must not be negative")
+	}
+	if err := u.repo.MoveItem(ctx, playlist.ID, id, position); err != nil {
+		return nil, err
+	}
+	playlist.Items, err = u.repo.Items(ctx, playlist.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return playlist, nil
+}
+
+func (u *playlistUsecase) PlayAll(ctx context.Context, viewer uuid.UUID, id string) (*domain.Playlist, error) {
+	playlist, err := u.Get(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+	ready := playlist.Items[:0]
+	for _, item := range playlist.Items {
+		if item.Video != nil && item.Video.Status == domain.VideoStatusReady {
+			ready = append(ready, item)
+		}
+	}
+	playlist.Items = ready
+	return playlist, nil
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// A like lifts a video as much as five views; a dislike sinks it as much.
+const (
+	rankingLikeWeight    = 5
+	rankingDislikeWeight = 5
+)
+
+// rankingPolicy holds the settings of the rankings.
+type rankingPolicy struct {
+	refreshInterval time.Duration
+	size            int
+	trendingWindow  time.Duration
+	trending        domain.RankingWeights
+	popular         domain.RankingWeights
+}
+
+// rankingPolicyFromEnv reads the settings from the environment:
+//
+//   - RANKING_REFRESH_INTERVAL: how often rankings are recomputed (default 5m)
+//   - RANKING_SIZE: videos kept per ranking and per category (default 1000)
+//   - TRENDING_WINDOW: how far back views and reactions count (default 48h)
+//   - TRENDING_HALF_LIFE: halves the weight of views and reactions (default 12h)
+//   - TRENDING_AGE_HALF_LIFE: halves the trending score of videos by age (default 72h)
+//   - POPULAR_AGE_HALF_LIFE: halves the popular score of videos by age (default 720h)
+func rankingPolicyFromEnv() rankingPolicy {
+	return rankingPolicy{
+		refreshInterval: envDuration("RANKING_REFRESH_INTERVAL", 5*time.Minute),
+		size:            int(envInt("RANKING_SIZE", 1000)),
+		trendingWindow:  envDuration("TRENDING_WINDOW", 48*time.Hour),
+		trending: domain.RankingWeights{
+			Like:             rankingLikeWeight,
+			Dislike:          rankingDislikeWeight,
+			ActivityHalfLife: envDuration("TRENDING_HALF_LIFE", 12*time.Hour).Seconds(),
+			AgeHalfLife:      envDuration("TRENDING_AGE_HALF_LIFE", 72*time.Hour).Seconds(),
+		},
+		popular: domain.RankingWeights{
+			Like:        rankingLikeWeight,
+			Dislike:     rankingDislikeWeight,
+			AgeHalfLife: envDuration("POPULAR_AGE_HALF_LIFE", 720*time.Hour).Seconds(),
+		},
+	}
+}
+
+type rankingUsecase struct {
+	repo   domain.RankingRepository
+	store  domain.RankingStore
+	videos domain.VideoRepository
+	policy rankingPolicy
+}
+
+func NewRankingUsecase(repo domain.RankingRepository, store domain.RankingStore, videos domain.VideoRepository) domain.RankingService {
+	return &rankingUsecase{
+		repo:   repo,
+		store:  store,
+		videos: videos,
+		policy: rankingPolicyFromEnv(),
+	}
+}
+
+func (u *rankingUsecase) List(ctx context.Context, ranking domain.Ranking, opts domain.RankingFetchOptions) (*domain.MultipleVideoResponse, error) {
+	if opts.Category != "" && !opts.Category.Valid() {
+		return nil, errInvalidCategory
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	opts.Sort = domain.Sort{Field: string(ranking), Desc: true}
+	limit, err := openPage(&opts.BaseFetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	ranked, err := u.store.Range(ctx, ranking, opts.Category, opts.After, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	ranked, next := closePage(ranked, limit, opts.Sort, func(v domain.RankedVideo) (string, uuid.UUID) {
+		return strconv.FormatFloat(v.Score, 'g', -1, 64), v.VideoID
+	})
+	ids := make([]uuid.UUID, len(ranked))
+	for i, v := range ranked {
+		ids[i] = v.VideoID
+	}
+	found, err := u.videos.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	resp := &domain.MultipleVideoResponse{Videos: make([]domain.Video, 0, len(found)), Total: -1, Limit: limit, NextPageToken: next}
+	for _, video := range found {
+		if video.Status == domain.VideoStatusReady {
+			resp.Videos = append(resp.Videos, video)
+		}
+	}
+	if !opts.SkipTotal {
+		if resp.Total, err = u.store.Count(ctx, ranking, opts.Category); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (u *rankingUsecase) Refresh(ctx context.Context, ranking domain.Ranking) (int, error) {
+	var videos []domain.RankedVideo
+	var err error
+	switch ranking {
+	case domain.RankingTrending:
+		videos, err = u.repo.TrendingScores(ctx, int(u.policy.trendingWindow.Hours()), u.policy.trending, u.policy.size)
+	case domain.RankingPopular:
+		videos, err = u.repo.PopularScores(ctx, u.policy.popular, u.policy.size)
+	default:
+		return 0, domain.NewFieldError("ranking", "must be trending or popular")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := u.store.Replace(ctx, ranking, videos); err != nil {
+		return 0, err
+	}
+	return len(videos), nil
+}
+
+// RunRefresher refreshes right away, so that a fresh deployment has
+// rankings to serve, then every interval. Each refresh is claimed by one
+// instance.
+func (u *rankingUsecase) RunRefresher(ctx context.Context) {
+	refresh := func() {
+		for _, ranking := range []domain.Ranking{domain.RankingTrending, domain.RankingPopular} {
+			claimed, err := u.store.Lock(ctx, ranking, u.policy.refreshInterval*9/10)
+			if err != nil {
+				slog.ErrorContext(ctx, "claiming ranking refresh failed", "ranking", ranking, "error", err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+			start := time.Now()
+			n, err := u.Refresh(ctx, ranking)
+			if err != nil {
+				slog.ErrorContext(ctx, "refreshing ranking failed", "ranking", ranking, "error", err)
+				continue
+			}
+			slog.InfoContext(ctx, "ranking refreshed", "ranking", ranking, "videos", n, "duration", time.Since(start))
+		}
+	}
+	refresh()
+	ticker := time.NewTicker(u.policy.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type reactionUsecase struct {
+	repo     domain.ReactionRepository
+	videos   domain.VideoRepository
+	comments domain.CommentRepository
+}
+
+var errInvalidReaction = domain.NewFieldError("reaction", "must be LIKE or DISLIKE")
+
+func NewReactionUsecase(repo domain.ReactionRepository, videos domain.VideoRepository, comments domain.CommentRepository) domain.ReactionService {
+	return &reactionUsecase{
+		repo:     repo,
+		videos:   videos,
+		comments: comments,
+	}
+}
+
+// checkVideo reports videos the user may not see as missing.
+func (u *reactionUsecase) checkVideo(ctx context.Context, userID, videoID uuid.UUID) (*domain.Video, error) {
+	video, err := u.videos.FindByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != domain.VideoStatusReady && video.UserID != userID {
+		return nil, domain.NewNotFound("video", videoID.String())
+	}
+	return video, nil
+}
+
+// checkComment reports deleted comments, and comments the user may not see,
+// as missing.
+func (u *reactionUsecase) checkComment(ctx context.Context, userID, commentID uuid.UUID) error {
+	comment, err := u.comments.FindByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	video, err := u.checkVideo(ctx, userID, comment.VideoID)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted() || (comment.Hidden && comment.UserID != userID && video.UserID != userID) {
+		return domain.NewNotFound("comment", commentID.String())
+	}
+	return nil
+}
+
+func (u *reactionUsecase) React(ctx context.Context, userID uuid.UUID, target domain.ReactionTarget, targetID string, kind domain.ReactionKind) (*domain.ReactionCounts, error) {
+	if kind != "" && !kind.Valid() {
+		return nil, errInvalidReaction
+	}
+	id, err := uuid.Parse(targetID)
+	switch target {
+	case domain.ReactionTargetVideo:
+		if err != nil {
+			return nil, errInvalidVideoID
+		}
+		_, err = u.checkVideo(ctx, userID, id)
+	case domain.ReactionTargetComment:
+		if err != nil {
+			return nil, errInvalidCommentID
+		}
+		err = u.checkComment(ctx, userID, id)
+	default:
+		return nil, fmt.Errorf("unknown reaction target %q", target)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kind == "" {
+		return u.repo.Clear(ctx, userID, target, id)
+	}
+	return u.repo.Set(ctx, &domain.Reaction{UserID: userID, TargetType: target, TargetID: id, Kind: kind})
+}
+
+func (u *reactionUsecase) ListLikedVideos(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) (*domain.MultipleVideoResponse, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	opts.Sort = domain.Sort{Field: domain.SortLikedAt, Desc: true}
+	limit, err := openPage(&opts)
+	if err != nil {
+		return nil, err
+	}
+	videos, err := u.repo.LikedVideos(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+	videos, next := closePage(videos, limit, opts.Sort, func(v domain.Video) (string, uuid.UUID) {
+		return v.LikedAt.Format(time.RFC3339Nano), v.ID
+	})
+	return &domain.MultipleVideoResponse{Videos: videos, Total: -1, Limit: limit, NextPageToken: next}, nil
+}
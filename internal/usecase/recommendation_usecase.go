@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const (
+	// recommendationSeeds is how much of the recent history seeds the
+	// recommendations of a user.
+	recommendationSeeds = 10
+	// candidatesPerSlot over-fetches candidates so that filtering out seeds,
+	// own uploads and videos that stopped being ready leaves enough.
+	candidatesPerSlot = 3
+)
+
+type recommendationUsecase struct {
+	videos  domain.VideoRepository
+	history domain.WatchHistoryRepository
+	sources []domain.WeightedSource
+}
+
+// NewRecommendationUsecase blends the candidates of sources. Scores are
+// normalised per source, so weights compare the sources directly.
+func NewRecommendationUsecase(videos domain.VideoRepository, history domain.WatchHistoryRepository, sources ...domain.WeightedSource) domain.RecommendationService {
+	return &recommendationUsecase{videos: videos, history: history, sources: sources}
+}
+
+func recommendationLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	return min(limit, domain.MaxRecommendations)
+}
+
+func (u *recommendationUsecase) Related(ctx context.Context, viewer uuid.UUID, videoID string, limit int) ([]domain.Recommendation, error) {
+	id, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	video, err := u.videos.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != domain.VideoStatusReady && video.UserID != viewer {
+		return nil, domain.NewNotFound("video", videoID)
+	}
+	seed := domain.RecommendationSeed{Videos: []domain.Video{*video}, Viewer: viewer}
+	return u.recommend(ctx, seed, recommendationLimit(limit), uuid.Nil)
+}
+
+func (u *recommendationUsecase) ForUser(ctx context.Context, userID uuid.UUID, limit int) ([]domain.Recommendation, error) {
+	entries, err := u.history.Find(ctx, userID, domain.BaseFetchOptions{
+		Limit: recommendationSeeds,
+		Sort:  domain.Sort{Field: domain.SortWatchedAt, Desc: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	seed := domain.RecommendationSeed{Viewer: userID}
+	for _, e := range entries {
+		if e.Video != nil {
+			seed.Videos = append(seed.Videos, *e.Video)
+		}
+	}
+	return u.recommend(ctx, seed, recommendationLimit(limit), userID)
+}
+
+// recommend blends the candidates of every source, leaving out the seeds and
+// the uploads of skipOwner. A failing source is skipped, so recommendations
+// degrade rather than fail. Ties are broken by id, which keeps results
+// deterministic.
+func (u *recommendationUsecase) recommend(ctx context.Context, seed domain.RecommendationSeed, limit int, skipOwner uuid.UUID) ([]domain.Recommendation, error) {
+	excluded := map[uuid.UUID]bool{}
+	for _, id := range seed.VideoIDs() {
+		excluded[id] = true
+	}
+	scores := map[uuid.UUID]*domain.Recommendation{}
+	for _, ws := range u.sources {
+		candidates, err := ws.Source.Candidates(ctx, seed, limit*candidatesPerSlot)
+		if err != nil {
+			slog.WarnContext(ctx, "candidate source failed", "source", ws.Source.Name(), "error", err)
+			continue
+		}
+		var best float64
+		for _, c := range candidates {
+			best = max(best, c.Score)
+		}
+		for _, c := range candidates {
+			if excluded[c.VideoID] || c.Score <= 0 {
+				continue
+			}
+			r, ok := scores[c.VideoID]
+			if !ok {
+				r = &domain.Recommendation{}
+				scores[c.VideoID] = r
+			}
+			r.Score += ws.Weight * c.Score / best
+			r.Sources = append(r.Sources, ws.Source.Name())
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if a, b := scores[ids[i]].Score, scores[ids[j]].Score; a != b {
+			return a > b
+		}
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	ids = ids[:min(len(ids), limit*candidatesPerSlot)]
+	videos, err := u.videos.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := make([]domain.Recommendation, 0, limit)
+	for _, video := range videos {
+		if len(recommendations) == limit {
+			break
+		}
+		if video.Status != domain.VideoStatusReady || (skipOwner != uuid.Nil && video.UserID == skipOwner) {
+			continue
+		}
+		r := scores[video.ID]
+		r.Video = video
+		recommendations = append(recommendations, *r)
+	}
+	return recommendations, nil
+}
+
+type rankingCandidateSource struct {
+	store   domain.RankingStore
+	ranking domain.Ranking
+}
+
+// NewRankingCandidateSource proposes the best videos of a ranking, in the
+// category of the first seed video when it has one. It needs no seed, so
+// it fills in for users without a history.
+func NewRankingCandidateSource(store domain.RankingStore, ranking domain.Ranking) domain.CandidateSource {
+	return &rankingCandidateSource{store: store, ranking: ranking}
+}
+
+func (s *rankingCandidateSource) Name() string { return string(s.ranking) }
+
+func (s *rankingCandidateSource) Candidates(ctx context.Context, seed domain.RecommendationSeed, limit int) ([]domain.Candidate, error) {
+	var category domain.Category
+	if len(seed.Videos) > 0 && seed.Videos[0].Category.Valid() {
+		category = seed.Videos[0].Category
+	}
+	ranked, err := s.store.Range(ctx, s.ranking, category, nil, limit)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]domain.Candidate, len(ranked))
+	for i, v := range ranked {
+		candidates[i] = domain.Candidate{VideoID: v.VideoID, Score: v.Score}
+	}
+	return candidates, nil
+}
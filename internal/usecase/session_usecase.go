@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type sessionUsecase struct {
+	repo domain.SessionRepository
+	now  func() time.Time
+}
+
+func NewSessionUsecase(repo domain.SessionRepository) domain.SessionService {
+	return &sessionUsecase{repo: repo, now: time.Now}
+}
+
+func (u *sessionUsecase) Start(ctx context.Context, userID uuid.UUID, info domain.SessionInfo, ttl time.Duration) (*domain.Session, error) {
+	now := u.now()
+	label := info.DeviceLabel
+	if label == "" {
+		label = deviceLabelFromUserAgent(info.UserAgent)
+	}
+	session := &domain.Session{
+		UserID:      userID,
+		DeviceLabel: truncate(label, 100),
+		IPAddress:   truncate(info.IPAddress, 45),
+		UserAgent:   truncate(info.UserAgent, 512),
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	if err := u.repo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (u *sessionUsecase) Validate(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := u.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	now := u.now()
+	if session == nil || session.UserID != userID || !session.Active(now) {
+		return domain.ErrInvalidToken
+	}
+	if now.Sub(session.LastSeenAt) >= lastUsedResolution {
+		if err := u.repo.Touch(ctx, session.ID, now); err != nil {
+			slog.WarnContext(ctx, "error recording session activity", "session_id", session.ID.String(), "error", err)
+		}
+	}
+	return nil
+}
+
+func (u *sessionUsecase) List(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	return u.repo.ListActive(ctx, userID, u.now())
+}
+
+func (u *sessionUsecase) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	revoked, err := u.repo.Revoke(ctx, userID, sessionID, u.now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return domain.NewNotFound("session", sessionID.String())
+	}
+	return nil
+}
+
+func (u *sessionUsecase) RevokeAllOthers(ctx context.Context, userID, currentID uuid.UUID) (int64, error) {
+	return u.repo.RevokeAllExcept(ctx, userID, currentID, u.now())
+}
+
+// deviceLabelFromUserAgent makes a rough "Browser on OS" label for sessions
+// whose client did not name itself.
+func deviceLabelFromUserAgent(ua string) string {
+	if ua == "" {
+		return "Unknown device"
+	}
+	browser := ""
+	for _, b := range []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+		{"grpc-go/", "gRPC client"},
+	} {
+		if strings.Contains(ua, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	platform := ""
+	for _, o := range []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(ua, o.token) {
+			platform = o.name
+			break
+		}
+	}
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return truncate(ua, 100)
+	}
+}
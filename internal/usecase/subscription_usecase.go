@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type subscriptionUsecase struct {
+	repo   domain.SubscriptionRepository
+	users  domain.UserRepository
+	videos domain.VideoRepository
+	now    func() time.Time
+}
+
+var errInvalidChannelID = domain.NewFieldError("channel_id", "must be a valid UUID")
+
+func NewSubscriptionUsecase(repo domain.SubscriptionRepository, users domain.UserRepository, videos domain.VideoRepository) domain.SubscriptionService {
+	return &subscriptionUsecase{
+		repo:   repo,
+		users:  users,
+		videos: videos,
+		now:    time.Now,
+	}
+}
+
+func (u *subscriptionUsecase) Subscribe(ctx context.Context, userID uuid.UUID, channelID string) (*domain.Subscription, error) {
+	id, err := uuid.Parse(channelID)
+	if err != nil {
+		return nil, errInvalidChannelID
+	}
+	if id == userID {
+		return nil, domain.NewFieldError("channel_id", "cannot be your own channel")
+	}
+	channel, err := u.users.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if channel == nil || channel.Disabled {
+		return nil, domain.NewNotFound("channel", channelID)
+	}
+	subscription := &domain.Subscription{SubscriberID: userID, ChannelID: id, CreatedAt: u.now()}
+	if err := u.repo.Subscribe(ctx, subscription); err != nil {
+		return nil, err
+	}
+	subscription.Channel = channel
+	return subscription, nil
+}
+
+func (u *subscriptionUsecase) Unsubscribe(ctx context.Context, userID uuid.UUID, channelID string) error {
+	id, err := uuid.Parse(channelID)
+	if err != nil {
+		return errInvalidChannelID
+	}
+	return u.repo.Unsubscribe(ctx, userID, id)
+}
+
+func (u *subscriptionUsecase) list(ctx context.Context, opts domain.SubscriptionFetchOptions) (*domain.MultipleSubscriptionResponse, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	opts.Sort = domain.Sort{Field: domain.SortCreatedAt, Desc: true}
+	limit, err := openPage(&opts.BaseFetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, total, err := u.repo.Find(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, next := closePage(subscriptions, limit, opts.Sort, func(s domain.Subscription) (string, uuid.UUID) {
+		if opts.SubscriberID != uuid.Nil {
+			return s.CreatedAt.Format(time.RFC3339Nano), s.ChannelID
+		}
+		return s.CreatedAt.Format(time.RFC3339Nano), s.SubscriberID
+	})
+	return &domain.MultipleSubscriptionResponse{Subscriptions: subscriptions, Total: total, NextPageToken: next}, nil
+}
+
+func (u *subscriptionUsecase) ListSubscriptions(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) (*domain.MultipleSubscriptionResponse, error) {
+	return u.list(ctx, domain.SubscriptionFetchOptions{BaseFetchOptions: opts, SubscriberID: userID})
+}
+
+func (u *subscriptionUsecase) ListSubscribers(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) (*domain.MultipleSubscriptionResponse, error) {
+	return u.list(ctx, domain.SubscriptionFetchOptions{BaseFetchOptions: opts, ChannelID: userID})
+}
+
+func (u *subscriptionUsecase) Feed(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) (*domain.MultipleVideoResponse, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	opts.Sort = domain.Sort{Field: domain.SortPublishedAt, Desc: true}
+	limit, err := openPage(&opts)
+	if err != nil {
+		return nil, err
+	}
+	videos, err := u.repo.Feed(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+	videos, next := closePage(videos, limit, opts.Sort, func(v domain.Video) (string, uuid.UUID) {
+		return v.PublishedAt.Format(time.RFC3339Nano), v.ID
+	})
+	return &domain.MultipleVideoResponse{Videos: videos, Total: -1, Limit: limit, NextPageToken: next}, nil
+}
+
+func (u *subscriptionUsecase) FanOut(ctx context.Context, videoID string) (int64, error) {
+	id, err := uuid.Parse(videoID)
+	if err != nil {
+		return 0, errInvalidVideoID
+	}
+	video, err := u.videos.FindByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if video.Status != domain.VideoStatusReady {
+		return 0, nil
+	}
+	return u.repo.FanOut(ctx, video, u.now())
+}
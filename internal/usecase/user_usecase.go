@@ -1,36 +1,59 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/pkg/utils"
 )
 
 type userUsecase struct {
 	repo         domain.UserRepository
+	storage      domain.VideoStorage
 	validate     *validator.Validate
 	passwordCost int
+	guard        *loginGuard
+	// dummyHash is compared against when a username does not exist, so a
+	// failed login takes the same time either way.
+	dummyHash []byte
 }
 
-func NewUserUsecase(repo domain.UserRepository) domain.UserService {
-	return &userUsecase{
+// NewUserUsecase builds the user service. storage holds the files of the
+// videos removed along with a user. attempts may be nil, in which case
+// logins are not rate limited.
+func NewUserUsecase(repo domain.UserRepository, storage domain.VideoStorage, attempts domain.LoginAttemptRepository) domain.UserService {
+	u := &userUsecase{
 		repo:         repo,
-		validate:     validator.New(),
+		storage:      storage,
+		validate:     newValidator(),
 		passwordCost: bcrypt.DefaultCost,
 	}
+	if attempts != nil {
+		u.guard = newLoginGuard(attempts)
+	}
+	u.dummyHash, _ = bcrypt.GenerateFromPassword([]byte(uuid.NewString()), u.passwordCost)
+	return u
 }
 
-func (u *userUsecase) CreateUser(user *domain.User) (*domain.User, error) {
+func (u *userUsecase) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	if user == nil {
 		return nil, fmt.Errorf("user is nil")
 	}
 
+	if user.Role == "" {
+		user.Role = domain.RoleCreator
+	}
+	user.Email = strings.ToLower(strings.TrimSpace(user.Email))
+	user.EmailVerified = false
 	if err := u.validate.Struct(user); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, invalidInput(err, "")
 	}
 
 	hashed, err := bcrypt.GenerateFromPassword([]byte(user.Password), u.passwordCost)
@@ -39,33 +62,33 @@ func (u *userUsecase) CreateUser(user *domain.User) (*domain.User, error) {
 	}
 	user.Password = string(hashed)
 
-	return u.repo.Create(user)
+	return u.repo.Create(ctx, user)
 }
 
-func (u *userUsecase) UpdateUser(user *domain.User) error {
+func (u *userUsecase) UpdateUser(ctx context.Context, user *domain.User) error {
 	if user == nil {
 		return fmt.Errorf("user is nil")
 	}
 
 	if user.Username != "" {
 		if err := u.validate.Var(user.Username, "min=3,max=50"); err != nil {
-			return fmt.Errorf("invalid username: %w", err)
+			return invalidInput(err, "username")
 		}
 	}
 	if user.FirstName != "" {
 		if err := u.validate.Var(user.FirstName, "max=100"); err != nil {
-			return fmt.Errorf("invalid first name: %w", err)
+			return invalidInput(err, "first_name")
 		}
 	}
 	if user.LastName != "" {
 		if err := u.validate.Var(user.LastName, "max=100"); err != nil {
-			return fmt.Errorf("invalid last name: %w", err)
+			return invalidInput(err, "last_name")
 		}
 	}
 
 	if user.Password != "" {
 		if err := u.validate.Var(user.Password, "min=6"); err != nil {
-			return fmt.Errorf("invalid password: %w", err)
+			return invalidInput(err, "password")
 		}
 		hashed, err := bcrypt.GenerateFromPassword([]byte(user.Password), u.passwordCost)
 		if err != nil {
@@ -74,90 +97,115 @@ func (u *userUsecase) UpdateUser(user *domain.User) error {
 		user.Password = string(hashed)
 	}
 
-	if err := u.repo.Update(user); err != nil {
+	if err := u.repo.Update(ctx, user); err != nil {
 		return fmt.Errorf("update user: %w", err)
 	}
 	return nil
 }
 
-func (u *userUsecase) DeleteUser(id uuid.UUID) error {
+func (u *userUsecase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	if id == uuid.Nil {
-		return fmt.Errorf("invalid id")
+		return domain.NewFieldError("id", "is required")
 	}
-	if err := u.repo.Delete(id); err != nil {
+	videos, err := u.repo.Delete(ctx, id)
+	if err != nil {
 		return fmt.Errorf("delete user: %w", err)
 	}
+	// The rows are gone by now, so a file that cannot be removed is only
+	// wasted space and does not fail the deletion.
+	for i := range videos {
+		if err := u.storage.RemoveFiles(ctx, &videos[i]); err != nil {
+			slog.WarnContext(ctx, "error removing video files", "video_id", videos[i].ID, "error", err)
+		}
+	}
 	return nil
 }
 
-func (u *userUsecase) GetUserByID(id uuid.UUID) (*domain.User, error) {
+func (u *userUsecase) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	if id == uuid.Nil {
-		return nil, fmt.Errorf("invalid id")
+		return nil, domain.NewFieldError("id", "is required")
 	}
-	return u.repo.GetByID(id)
+	return u.repo.GetByID(ctx, id)
 }
 
-func (u *userUsecase) ListUsers(opts domain.UserFetchOptions) ([]domain.User, int64, error) {
-	if opts.Limit <= 0 && opts.Page <= 0 {
+func (u *userUsecase) ListUsers(ctx context.Context, opts domain.UserFetchOptions) (*domain.MultipleUserResponse, error) {
+	if opts.Limit <= 0 {
 		opts.Limit = 25
 	}
-	return u.repo.GetAll(opts)
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	if opts.Sort.Field == "" {
+		opts.Sort = domain.Sort{Field: domain.SortCreatedAt, Desc: true}
+	}
+	limit, err := openPage(&opts.BaseFetchOptions)
+	if err != nil {
+		return nil, err
+	}
+	users, total, err := u.repo.GetAll(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	users, next := closePage(users, limit, opts.Sort, userSortValue(opts.Sort.Field))
+	return &domain.MultipleUserResponse{Users: users, Total: total, NextPageToken: next}, nil
 }
 
-func (u *userUsecase) Authenticate(username, password string) (*domain.User, error) {
+func (u *userUsecase) Authenticate(ctx context.Context, username, password string) (*domain.User, error) {
 	if username == "" || password == "" {
-		return nil, fmt.Errorf("username and password required")
+		return nil, domain.NewInvalidArgument("username and password are required")
 	}
-	user, err := u.repo.GetByUsername(username)
+	user, err := u.repo.GetByUsername(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("lookup user: %w", err)
 	}
 	if user == nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, domain.ErrInvalidCredentials
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, domain.ErrInvalidCredentials
+	}
+	if user.Disabled {
+		return nil, domain.NewPermissionDenied("account is disabled").WithReason("ACCOUNT_DISABLED")
 	}
 	return user, nil
 }
 
-func (u *userUsecase) ChangePassword(userID string, currentPassword, newPassword string) error {
+func (u *userUsecase) ChangePassword(ctx context.Context, userID string, currentPassword, newPassword string) error {
 	id, err := uuid.Parse(userID)
 	if err != nil {
-		return fmt.Errorf("error parsing user id : %v", err)
+		return domain.NewFieldError("user_id", "must be a valid UUID")
 	}
 	if id == uuid.Nil {
-		return fmt.Errorf("invalid id")
+		return domain.NewFieldError("user_id", "is required")
 	}
 	if newPassword == "" {
-		return fmt.Errorf("new password required")
+		return domain.NewFieldError("new_password", "is required")
 	}
-	user, err := u.repo.GetByID(id)
+	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("lookup user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user not found")
+		return domain.NewNotFound("user", id.String())
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
-		return fmt.Errorf("current password incorrect")
+		return domain.NewFieldError("current_password", "is incorrect")
 	}
 	if err := u.validate.Var(newPassword, "min=6"); err != nil {
-		return fmt.Errorf("invalid new password: %w", err)
+		return invalidInput(err, "new_password")
 	}
 	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), u.passwordCost)
 	if err != nil {
 		return fmt.Errorf("hashing password: %w", err)
 	}
-	user.Password = string(hashed)
-	if err := u.repo.Update(user); err != nil {
+	if err := u.repo.UpdateFields(ctx, user.ID, map[string]any{"password": string(hashed)}); err != nil {
 		return fmt.Errorf("update password: %w", err)
 	}
 	return nil
 }
 
-func (u *userUsecase) ResetPassword(username, newPassword string) error {
-	user, err := u.GetByUsername(username)
+func (u *userUsecase) ResetPassword(ctx context.Context, username, newPassword string) error {
+	user, err := u.GetByUsername(ctx, username)
 	if err != nil {
 		return fmt.Errorf("error getting the user by username: %v", err)
 	}
@@ -170,20 +218,118 @@ func (u *userUsecase) ResetPassword(username, newPassword string) error {
 	return nil
 }
 
-func (u *userUsecase) GetByUsername(username string) (*domain.User, error) {
-	return u.repo.GetByUsername(username)
+func (u *userUsecase) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return u.repo.GetByUsername(ctx, username)
 }
-func (u *userUsecase) Login(username, password string) (*domain.User, error) {
-	user, err := u.GetByUsername(username)
-	if err != nil {
+
+// Login checks a username and password. Unknown usernames, wrong passwords
+// and disabled accounts all return domain.ErrInvalidCredentials after a bcrypt
+// comparison, so neither the error nor the timing reveals which one it was.
+func (u *userUsecase) Login(ctx context.Context, username, password string) (*domain.User, error) {
+	ip := utils.GetClientIP(ctx)
+	if err := u.guard.check(ctx, username, ip); err != nil {
 		return nil, err
 	}
+	user, err := u.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	hash := u.dummyHash
+	if user != nil {
+		hash = []byte(user.Password)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || user == nil {
+		u.guard.failed(ctx, username, ip)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if user.Disabled {
+		return nil, domain.ErrInvalidCredentials
+	}
+	// With 2FA the failures are only cleared once the code is verified too,
+	// so wrong codes keep adding up towards a lockout.
+	if !user.TOTPEnabled {
+		u.guard.succeeded(ctx, username)
+	}
+	return user, nil
+}
+
+func (u *userUsecase) SetRole(ctx context.Context, id uuid.UUID, role domain.Role) (*domain.User, error) {
+	if !role.Valid() {
+		return nil, domain.NewFieldError("role", "must be one of: viewer, creator, moderator, admin")
+	}
+	user, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
 	if user == nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.NewNotFound("user", id.String())
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err := u.repo.UpdateFields(ctx, user.ID, map[string]any{"role": role}); err != nil {
+		return nil, fmt.Errorf("update role: %w", err)
+	}
+	user.Role = role
+	return user, nil
+}
+
+func (u *userUsecase) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) (*domain.User, error) {
+	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.NewNotFound("user", id.String())
+	}
+	if err := u.repo.UpdateFields(ctx, user.ID, map[string]any{"disabled": disabled}); err != nil {
+		return nil, fmt.Errorf("update disabled flag: %w", err)
+	}
+	user.Disabled = disabled
+	return user, nil
+}
+
+func (u *userUsecase) UpdateProfile(ctx context.Context, id uuid.UUID, update domain.ProfileUpdate) (*domain.User, error) {
+	user, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+	if user == nil {
+		return nil, domain.NewNotFound("user", id.String())
+	}
+	// Only the changed columns are written, so the update cannot undo a
+	// role change or a disabled flag set in the meantime.
+	fields := map[string]any{}
+	if update.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*update.FirstName)
+		fields["first_name"] = user.FirstName
+	}
+	if update.LastName != nil {
+		user.LastName = strings.TrimSpace(*update.LastName)
+		fields["last_name"] = user.LastName
+	}
+	if update.AvatarURL != nil {
+		user.AvatarURL = strings.TrimSpace(*update.AvatarURL)
+		fields["avatar_url"] = user.AvatarURL
+	}
+	if update.Bio != nil {
+		user.Bio = strings.TrimSpace(*update.Bio)
+		fields["bio"] = user.Bio
+	}
+	if update.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*update.Email))
+		if email != user.Email {
+			user.Email = email
+			user.EmailVerified = false
+			fields["email"] = email
+			fields["email_verified"] = false
+		}
+	}
+	if err := u.validate.Struct(user); err != nil {
+		return nil, invalidInput(err, "")
+	}
+	if len(fields) == 0 {
+		return user, nil
+	}
+	if err := u.repo.UpdateFields(ctx, user.ID, fields); err != nil {
+		return nil, fmt.Errorf("update profile: %w", err)
 	}
 	return user, nil
 }
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// newValidator returns a validator that reports fields by their JSON name, so
+// field violations match what API clients send.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+	return v
+}
+
+// invalidInput converts a validator error into an InvalidArgument domain
+// error with one violation per failing field. field names the value for
+// errors returned by Validate.Var, which carry no field name of their own.
+func invalidInput(err error, field string) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	violations := make([]domain.FieldViolation, 0, len(verrs))
+	for _, fe := range verrs {
+		name := fe.Field()
+		if name == "" {
+			name = field
+		}
+		violations = append(violations, domain.FieldViolation{Field: name, Description: describeViolation(fe)})
+	}
+	message := "validation failed"
+	if len(violations) == 1 {
+		message = violations[0].Field + ": " + violations[0].Description
+	}
+	return domain.NewInvalidArgument(message, violations...)
+}
+
+func describeViolation(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
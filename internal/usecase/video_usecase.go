@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -20,16 +23,55 @@ type videoUsecase struct {
 	rmq         *queue.RabbitMQ
 }
 
+var (
+	errInvalidVideoID  = domain.NewFieldError("video_id", "must be a valid UUID")
+	errInvalidStatus   = domain.NewFieldError("status", "must be one of: PENDING, PROCESSING, READY, FAILED")
+	errInvalidCategory = domain.NewFieldError("category", "must be one of the supported categories")
+)
+
+// normalizeTags turns tag names into tags keyed by slug, dropping duplicates
+// and keeping the first spelling of each.
+func normalizeTags(names []string) ([]domain.Tag, error) {
+	tags := make([]domain.Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := domain.TagSlug(name)
+		switch {
+		case slug == "":
+			return nil, domain.NewFieldError("tags", fmt.Sprintf("%q must contain a letter or digit", name))
+		case utf8.RuneCountInString(name) > domain.MaxTagLength || len(slug) > domain.MaxTagLength:
+			return nil, domain.NewFieldError("tags", fmt.Sprintf("must be at most %d characters long", domain.MaxTagLength))
+		case seen[slug]:
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, domain.Tag{Slug: slug, Name: name})
+	}
+	if len(tags) > domain.MaxTagsPerVideo {
+		return nil, domain.NewFieldError("tags", fmt.Sprintf("at most %d tags are allowed", domain.MaxTagsPerVideo))
+	}
+	return tags, nil
+}
+
+func tagNames(tags []domain.Tag) []string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names
+}
+
 func NewVideoUsecase(repo domain.VideoRepository, minioClient *database.MinioClient, rmq *queue.RabbitMQ) domain.VideoService {
 	return &videoUsecase{
 		repo:        repo,
-		validate:    validator.New(),
+		validate:    newValidator(),
 		minioClient: minioClient,
 		rmq:         rmq,
 	}
 }
 
-func (u *videoUsecase) CreateVideo(video *domain.Video) (*domain.Video, error) {
+func (u *videoUsecase) CreateVideo(ctx context.Context, video *domain.Video) (*domain.Video, error) {
 	// if err := u.validate.Struct(video); err != nil {
 	// 	return nil, fmt.Errorf("validation failed: %w", err)
 	// }
@@ -42,33 +84,41 @@ func (u *videoUsecase) CreateVideo(video *domain.Video) (*domain.Video, error) {
 		video.Status != domain.VideoStatusProcessing &&
 		video.Status != domain.VideoStatusReady &&
 		video.Status != domain.VideoStatusFailed {
-		return nil, fmt.Errorf("invalid video status: %s", video.Status)
+		return nil, errInvalidStatus
 	}
 	if video.UserID == uuid.Nil {
-		return nil, fmt.Errorf("user_id is required")
+		return nil, domain.NewFieldError("user_id", "is required")
+	}
+	if video.Category != "" && !video.Category.Valid() {
+		return nil, errInvalidCategory
 	}
-	createdVideo, err := u.repo.Create(video)
+	tags, err := normalizeTags(tagNames(video.Tags))
+	if err != nil {
+		return nil, err
+	}
+	video.Tags = tags
+	createdVideo, err := u.repo.Create(ctx, video)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create video: %w", err)
 	}
 	return createdVideo, nil
 }
 
-func (u *videoUsecase) FindByID(id string) (*domain.Video, error) {
+func (u *videoUsecase) FindByID(ctx context.Context, id string) (*domain.Video, error) {
 	videoID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid video id: %w", err)
+		return nil, errInvalidVideoID
 	}
 
-	video, err := u.repo.FindByID(videoID)
+	video, err := u.repo.FindByID(ctx, videoID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find video: %w", err)
+		return nil, err
 	}
 
 	return video, nil
 }
 
-func (u *videoUsecase) Find(opts domain.VideoFetchOptions) (*domain.MultipleVideoResponse, error) {
+func (u *videoUsecase) Find(ctx context.Context, opts domain.VideoFetchOptions) (*domain.MultipleVideoResponse, error) {
 	if opts.Limit == 0 {
 		opts.Limit = 20
 	}
@@ -76,33 +126,66 @@ func (u *videoUsecase) Find(opts domain.VideoFetchOptions) (*domain.MultipleVide
 	if opts.Limit > 100 {
 		opts.Limit = 100
 	}
-
-	videos, total, err := u.repo.Find(opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find videos: %w", err)
+	switch {
+	case opts.Sort.Field == domain.SortRelevance && opts.Query == "":
+		return nil, domain.NewFieldError("order_by", "relevance requires a query")
+	case opts.Sort.Field == "" && opts.Query != "":
+		opts.Sort = domain.Sort{Field: domain.SortRelevance, Desc: true}
+	case opts.Sort.Field == "":
+		opts.Sort = domain.Sort{Field: domain.SortCreatedAt, Desc: true}
+	}
+	if opts.Category != "" && !opts.Category.Valid() {
+		return nil, errInvalidCategory
+	}
+	if len(opts.Tags) > 0 {
+		slugs := make([]string, 0, len(opts.Tags))
+		for _, t := range opts.Tags {
+			if slug := domain.TagSlug(t); slug != "" && !slices.Contains(slugs, slug) {
+				slugs = append(slugs, slug)
+			}
+		}
+		opts.Tags = slugs
 	}
 	page := opts.Page
 	if page == 0 {
 		page = 1
 	}
-	return &domain.MultipleVideoResponse{
-		Videos: videos,
-		Total:  total,
-		Page:   page,
-		Limit:  opts.Limit,
-	}, nil
+	limit, err := openPage(&opts.BaseFetchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	videos, total, err := u.repo.Find(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find videos: %w", err)
+	}
+	videos, next := closePage(videos, limit, opts.Sort, videoSortValue(opts.Sort.Field))
+	resp := &domain.MultipleVideoResponse{
+		Videos:        videos,
+		Total:         total,
+		Page:          page,
+		Limit:         limit,
+		NextPageToken: next,
+	}
+	if opts.IncludeFacets {
+		resp.Facets, err = u.repo.Facets(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count facets: %w", err)
+		}
+	}
+	return resp, nil
 }
 
-func (u *videoUsecase) Update(id string, video *domain.Video) (*domain.Video, error) {
+func (u *videoUsecase) Update(ctx context.Context, id string, video *domain.Video) (*domain.Video, error) {
 	videoID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid video id: %w", err)
+		return nil, errInvalidVideoID
 	}
 
 	// Fetch existing video
-	existing, err := u.repo.FindByID(videoID)
+	existing, err := u.repo.FindByID(ctx, videoID)
 	if err != nil {
-		return nil, fmt.Errorf("video not found: %w", err)
+		return nil, err
 	}
 
 	// Update only provided fields
@@ -124,71 +207,93 @@ func (u *videoUsecase) Update(id string, video *domain.Video) (*domain.Video, er
 			video.Status != domain.VideoStatusProcessing &&
 			video.Status != domain.VideoStatusReady &&
 			video.Status != domain.VideoStatusFailed {
-			return nil, fmt.Errorf("invalid video status: %s", video.Status)
+			return nil, errInvalidStatus
 		}
 		existing.Status = video.Status
 	}
+	if video.Category != "" {
+		if !video.Category.Valid() {
+			return nil, errInvalidCategory
+		}
+		existing.Category = video.Category
+	}
+	// A nil Tags leaves the tags alone; an empty one clears them.
+	var tags []domain.Tag
+	if video.Tags != nil {
+		if tags, err = normalizeTags(tagNames(video.Tags)); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate before update
 	if err := u.validate.Struct(existing); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, invalidInput(err, "")
 	}
 
-	if err := u.repo.Update(existing); err != nil {
+	if err := u.repo.Update(ctx, existing); err != nil {
 		return nil, fmt.Errorf("failed to update video: %w", err)
 	}
+	if video.Tags != nil {
+		if err := u.repo.SetTags(ctx, existing, tags); err != nil {
+			return nil, fmt.Errorf("failed to update video tags: %w", err)
+		}
+	}
 
 	return existing, nil
 }
 
-func (u *videoUsecase) Delete(id string) error {
+func (u *videoUsecase) Delete(ctx context.Context, id string) error {
 	videoID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid video id: %w", err)
+		return errInvalidVideoID
 	}
 
-	if err := u.repo.Delete(videoID); err != nil {
+	if err := u.repo.Delete(ctx, videoID); err != nil {
 		return fmt.Errorf("failed to delete video: %w", err)
 	}
 
 	return nil
 }
 
-func (u *videoUsecase) IncrementViews(id string) error {
-	videoID, err := uuid.Parse(id)
-	if err != nil {
-		return fmt.Errorf("invalid video id: %w", err)
+func (u *videoUsecase) ListTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error) {
+	if limit <= 0 {
+		limit = 10
 	}
-
-	if err := u.repo.IncrementViews(videoID); err != nil {
-		return fmt.Errorf("failed to increment views: %w", err)
+	if limit > 50 {
+		limit = 50
 	}
-
-	return nil
+	tags, err := u.repo.ListTags(ctx, domain.TagSlug(prefix), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return tags, nil
 }
-func (u *videoUsecase) CompleteUpload(userID, videoID string) error {
-	video, err := u.FindByID(videoID)
+
+func (u *videoUsecase) CompleteUpload(ctx context.Context, userID, videoID string) error {
+	video, err := u.FindByID(ctx, videoID)
 	if err != nil {
 		return err
 	}
 	if video.UserID.String() != userID {
-		return fmt.Errorf("video does not belong to the current user")
+		return domain.NewPermissionDenied("video does not belong to the current user")
 	}
-	ctx := context.Background()
 	_, err = u.minioClient.Client.StatObject(ctx, u.minioClient.Bucket, video.FileName, minio.GetObjectOptions{})
 	if err != nil {
-		return fmt.Errorf("video file not found in storage (did you upload it?): %w", err)
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return domain.NewFailedPrecondition("video file has not been uploaded yet").WithReason("UPLOAD_MISSING")
+		}
+		return fmt.Errorf("checking uploaded video file: %w", err)
 	}
-	if err := u.UpdateStatus(videoID, domain.VideoStatusProcessing); err != nil {
+	if err := u.UpdateStatus(ctx, videoID, domain.VideoStatusProcessing); err != nil {
 		return fmt.Errorf("error updating the video status: %w", err)
 	}
-	if err := u.rmq.PublishVideoUploaded(videoID, video.FileName); err != nil {
+	if err := u.rmq.PublishVideoUploaded(ctx, videoID, video.FileName); err != nil {
 		return fmt.Errorf("failed to add to video encoding queue: %w", err)
 	}
 	return nil
 }
 
-func (u *videoUsecase) UpdateStatus(videoID string, status domain.VideoStatus) error {
-	_, err := u.Update(videoID, &domain.Video{Status: status})
+func (u *videoUsecase) UpdateStatus(ctx context.Context, videoID string, status domain.VideoStatus) error {
+	_, err := u.Update(ctx, videoID, &domain.Video{Status: status})
 	return err
 }
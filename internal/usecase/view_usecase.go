@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+	"github.com/hunderaweke/gostream/internal/metrics"
+)
+
+// maxPlaybackRate is the fastest speed a player may report watching at, so
+// 30 seconds of watch time need at least 15 seconds of real time.
+const maxPlaybackRate = 2
+
+// botPattern matches the user agents of crawlers and scripted clients.
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|curl|wget|python-|go-http-client|java/|headless|phantomjs|scrapy|httpclient`)
+
+// viewPolicy holds the rules for counting views.
+type viewPolicy struct {
+	threshold     time.Duration
+	window        time.Duration
+	perIP         int64
+	flushInterval time.Duration
+}
+
+// viewPolicyFromEnv reads the rules from the environment:
+//
+//   - VIEW_THRESHOLD: watch time before a view counts (default 30s)
+//   - VIEW_WINDOW: a viewer counts once per video per window (default 1h)
+//   - VIEW_MAX_PER_IP: views one IP address adds to a video per window (default 10)
+//   - VIEW_FLUSH_INTERVAL: how often counted views are saved (default 10s)
+func viewPolicyFromEnv() viewPolicy {
+	return viewPolicy{
+		threshold:     envDuration("VIEW_THRESHOLD", 30*time.Second),
+		window:        envDuration("VIEW_WINDOW", time.Hour),
+		perIP:         envInt("VIEW_MAX_PER_IP", 10),
+		flushInterval: envDuration("VIEW_FLUSH_INTERVAL", 10*time.Second),
+	}
+}
+
+type viewUsecase struct {
+	repo   domain.ViewRepository
+	videos domain.VideoRepository
+	policy viewPolicy
+	now    func() time.Time
+}
+
+func NewViewUsecase(repo domain.ViewRepository, videos domain.VideoRepository) domain.ViewService {
+	return &viewUsecase{
+		repo:   repo,
+		videos: videos,
+		policy: viewPolicyFromEnv(),
+		now:    time.Now,
+	}
+}
+
+func isBot(userAgent string) bool {
+	return userAgent == "" || botPattern.MatchString(userAgent)
+}
+
+// deviceKey tells devices apart by IP address and user agent, without
+// keeping either in Redis.
+func deviceKey(viewer domain.Viewer) string {
+	sum := sha256.Sum256([]byte(viewer.IP + "|" + viewer.UserAgent))
+	return hex.EncodeToString(sum[:12])
+}
+
+// viewerKey is who a view is deduplicated for: the account when signed in,
+// the device otherwise.
+func viewerKey(viewer domain.Viewer) string {
+	if viewer.UserID != uuid.Nil {
+		return "u:" + viewer.UserID.String()
+	}
+	return "d:" + deviceKey(viewer)
+}
+
+// StartPlayback is keyed by device rather than account, since players often
+// fetch playlists without credentials even for signed-in viewers.
+func (u *viewUsecase) StartPlayback(ctx context.Context, videoID string, viewer domain.Viewer) {
+	id, err := uuid.Parse(videoID)
+	if err != nil || isBot(viewer.UserAgent) {
+		return
+	}
+	if err := u.repo.StartPlayback(ctx, id, deviceKey(viewer), u.now(), u.policy.window); err != nil {
+		slog.WarnContext(ctx, "recording playback failed", "video_id", videoID, "error", err)
+	}
+}
+
+func (u *viewUsecase) RecordView(ctx context.Context, videoID string, viewer domain.Viewer, watched time.Duration) (domain.ViewResult, error) {
+	id, err := uuid.Parse(videoID)
+	if err != nil {
+		return "", errInvalidVideoID
+	}
+	if watched < 0 {
+		return "", domain.NewFieldError("watched_seconds", "must not be negative")
+	}
+	video, err := u.videos.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if video.Status != domain.VideoStatusReady {
+		return "", domain.NewNotFound("video", videoID)
+	}
+	result, err := u.countView(ctx, id, viewer, watched)
+	if err != nil {
+		return "", err
+	}
+	metrics.ViewsReportedTotal.WithLabelValues(string(result)).Inc()
+	return result, nil
+}
+
+// countView counts a view when the watch time is long enough, matches the
+// time since the device fetched the playlist, and was not already counted.
+func (u *viewUsecase) countView(ctx context.Context, id uuid.UUID, viewer domain.Viewer, watched time.Duration) (domain.ViewResult, error) {
+	if isBot(viewer.UserAgent) {
+		return domain.ViewBot, nil
+	}
+	if watched == 0 || watched < u.policy.threshold {
+		return domain.ViewTooShort, nil
+	}
+	start, err := u.repo.PlaybackStart(ctx, id, deviceKey(viewer))
+	if err != nil {
+		return "", err
+	}
+	if start.IsZero() || u.now().Sub(start) < watched/maxPlaybackRate {
+		return domain.ViewNoPlayback, nil
+	}
+	ip := viewer.IP
+	if ip == "" {
+		ip = "unknown"
+	}
+	return u.repo.Count(ctx, id, viewerKey(viewer), ip, u.policy.window, u.policy.perIP)
+}
+
+func (u *viewUsecase) Flush(ctx context.Context) (int64, error) {
+	views, err := u.repo.TakePending(ctx)
+	if err != nil || len(views) == 0 {
+		return 0, err
+	}
+	if err := u.videos.AddViews(ctx, views, u.now()); err != nil {
+		if restoreErr := u.repo.RestorePending(ctx, views); restoreErr != nil {
+			slog.ErrorContext(ctx, "pending views lost", "videos", len(views), "error", restoreErr)
+		}
+		return 0, err
+	}
+	var total int64
+	for _, n := range views {
+		total += n
+	}
+	metrics.ViewsFlushedTotal.Add(float64(total))
+	return total, nil
+}
+
+func (u *viewUsecase) RunFlusher(ctx context.Context) {
+	runFlusher(ctx, "views", u.policy.flushInterval, func(ctx context.Context) error {
+		_, err := u.Flush(ctx)
+		return err
+	})
+}
+
+// runFlusher calls flush every interval until ctx is done, then once more
+// so that buffered writes survive a shutdown.
+func runFlusher(ctx context.Context, name string, interval time.Duration, flush func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := flush(flushCtx); err != nil {
+				slog.Error("final flush failed", "buffer", name, "error", err)
+			}
+			return
+		case <-ticker.C:
+			if err := flush(ctx); err != nil {
+				slog.ErrorContext(ctx, "flush failed", "buffer", name, "error", err)
+			}
+		}
+	}
+}
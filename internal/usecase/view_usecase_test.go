@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+const testUserAgent = "Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0"
+
+type memViews struct {
+	domain.ViewRepository
+	starts  map[string]time.Time
+	counted map[string]bool
+}
+
+func (m *memViews) StartPlayback(ctx context.Context, videoID uuid.UUID, device string, at time.Time, window time.Duration) error {
+	key := videoID.String() + ":" + device
+	if _, ok := m.starts[key]; !ok {
+		m.starts[key] = at
+	}
+	return nil
+}
+
+func (m *memViews) PlaybackStart(ctx context.Context, videoID uuid.UUID, device string) (time.Time, error) {
+	return m.starts[videoID.String()+":"+device], nil
+}
+
+func (m *memViews) Count(ctx context.Context, videoID uuid.UUID, viewer, ip string, window time.Duration, perIP int64) (domain.ViewResult, error) {
+	key := videoID.String() + ":" + viewer
+	if m.counted[key] {
+		return domain.ViewDuplicate, nil
+	}
+	m.counted[key] = true
+	return domain.ViewCounted, nil
+}
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      bool
+	}{
+		{userAgent: "", want: true},
+		{userAgent: "Googlebot/2.1 (+http://www.google.com/bot.html)", want: true},
+		{userAgent: "curl/8.5.0", want: true},
+		{userAgent: "python-requests/2.31", want: true},
+		{userAgent: "Mozilla/5.0 (compatible; HeadlessChrome/120.0)", want: true},
+		{userAgent: testUserAgent, want: false},
+		{userAgent: "VLC/3.0.20 LibVLC/3.0.20", want: false},
+	}
+	for _, tt := range tests {
+		if got := isBot(tt.userAgent); got != tt.want {
+			t.Errorf("isBot(%q) = %v, want %v", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+func TestRecordView(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ready := &domain.Video{Status: domain.VideoStatusReady}
+	ready.ID = uuid.New()
+	processing := &domain.Video{Status: domain.VideoStatusProcessing}
+	processing.ID = uuid.New()
+	viewer := domain.Viewer{IP: "10.0.0.1", UserAgent: testUserAgent}
+
+	tests := []struct {
+		name    string
+		video   *domain.Video
+		viewer  domain.Viewer
+		watched time.Duration
+		// played is how long before the report the playlist was fetched;
+		// zero means it never was.
+		played  time.Duration
+		repeat  bool
+		want    domain.ViewResult
+		wantErr bool
+	}{
+		{name: "counted", video: ready, viewer: viewer, watched: 40 * time.Second, played: time.Minute, want: domain.ViewCounted},
+		{name: "at the threshold", video: ready, viewer: viewer, watched: 30 * time.Second, played: time.Minute, want: domain.ViewCounted},
+		{name: "below the threshold", video: ready, viewer: viewer, watched: 29 * time.Second, played: time.Minute, want: domain.ViewTooShort},
+		{name: "nothing watched", video: ready, viewer: viewer, played: time.Minute, want: domain.ViewTooShort},
+		{name: "negative watch time", video: ready, viewer: viewer, watched: -time.Second, wantErr: true},
+		{name: "bot", video: ready, viewer: domain.Viewer{IP: "10.0.0.1", UserAgent: "Googlebot/2.1"}, watched: time.Minute, played: time.Hour, want: domain.ViewBot},
+		{name: "no user agent", video: ready, viewer: domain.Viewer{IP: "10.0.0.1"}, watched: time.Minute, played: time.Hour, want: domain.ViewBot},
+		{name: "playlist never fetched", video: ready, viewer: viewer, watched: time.Minute, want: domain.ViewNoPlayback},
+		{name: "faster than the playback rate", video: ready, viewer: viewer, watched: time.Minute, played: 29 * time.Second, want: domain.ViewNoPlayback},
+		{name: "at the playback rate", video: ready, viewer: viewer, watched: time.Minute, played: 30 * time.Second, want: domain.ViewCounted},
+		{name: "counted once", video: ready, viewer: viewer, watched: time.Minute, played: time.Hour, repeat: true, want: domain.ViewDuplicate},
+		{name: "video not ready", video: processing, viewer: viewer, watched: time.Minute, played: time.Hour, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			views := &memViews{starts: map[string]time.Time{}, counted: map[string]bool{}}
+			u := &viewUsecase{
+				repo:   views,
+				videos: &memVideos{videos: map[uuid.UUID]*domain.Video{ready.ID: ready, processing.ID: processing}},
+				policy: viewPolicy{threshold: 30 * time.Second, window: time.Hour, perIP: 10},
+				now:    func() time.Time { return now },
+			}
+			ctx := context.Background()
+			id := tt.video.ID.String()
+			if tt.played > 0 {
+				now = start.Add(-tt.played)
+				u.StartPlayback(ctx, id, tt.viewer)
+				now = start
+			}
+			if tt.repeat {
+				if _, err := u.RecordView(ctx, id, tt.viewer, tt.watched); err != nil {
+					t.Fatalf("first report: %v", err)
+				}
+			}
+			got, err := u.RecordView(ctx, id, tt.viewer, tt.watched)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
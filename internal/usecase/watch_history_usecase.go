@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type watchHistoryUsecase struct {
+	repo          domain.WatchHistoryRepository
+	buffer        domain.WatchProgressBuffer
+	videos        domain.VideoRepository
+	flushInterval time.Duration
+	now           func() time.Time
+}
+
+// NewWatchHistoryUsecase saves buffered progress every WATCH_FLUSH_INTERVAL
+// (default 30s).
+func NewWatchHistoryUsecase(repo domain.WatchHistoryRepository, buffer domain.WatchProgressBuffer, videos domain.VideoRepository) domain.WatchHistoryService {
+	return &watchHistoryUsecase{
+		repo:          repo,
+		buffer:        buffer,
+		videos:        videos,
+		flushInterval: envDuration("WATCH_FLUSH_INTERVAL", 30*time.Second),
+		now:           time.Now,
+	}
+}
+
+func (u *watchHistoryUsecase) ReportProgress(ctx context.Context, userID uuid.UUID, videoID string, position, duration float64) (*domain.WatchEntry, error) {
+	id, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	switch {
+	case position < 0:
+		return nil, domain.NewFieldError("position_seconds", "must not be negative")
+	case duration < 0:
+		return nil, domain.NewFieldError("duration_seconds", "must not be negative")
+	case duration > 0 && position > duration:
+		return nil, domain.NewFieldError("position_seconds", "must not be past the duration")
+	}
+	previous, err := u.buffer.Get(ctx, userID, id)
+	if err != nil {
+		slog.WarnContext(ctx, "reading buffered watch progress failed", "error", err)
+	}
+	// Heartbeats after the first one skip looking the video up again.
+	if previous == nil {
+		video, err := u.videos.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if video.Status != domain.VideoStatusReady && video.UserID != userID {
+			return nil, domain.NewNotFound("video", videoID)
+		}
+	}
+	entry := &domain.WatchEntry{
+		UserID:    userID,
+		VideoID:   id,
+		Position:  position,
+		Duration:  duration,
+		Completed: duration > 0 && position >= duration-domain.WatchCompleteMargin.Seconds(),
+		WatchedAt: u.now(),
+	}
+	if err := u.buffer.Put(ctx, entry); err != nil {
+		// Without Redis the progress is written straight through.
+		slog.WarnContext(ctx, "buffering watch progress failed", "error", err)
+		if err := u.repo.Save(ctx, []domain.WatchEntry{*entry}); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+func (u *watchHistoryUsecase) Progress(ctx context.Context, userID uuid.UUID, videoID string) (*domain.WatchEntry, error) {
+	id, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, errInvalidVideoID
+	}
+	entry, err := u.buffer.Get(ctx, userID, id)
+	if err != nil {
+		slog.WarnContext(ctx, "reading buffered watch progress failed", "error", err)
+	}
+	if entry != nil {
+		return entry, nil
+	}
+	return u.repo.Get(ctx, userID, id)
+}
+
+// History lists saved progress, so the latest few seconds of playback show
+// up after the next flush.
+func (u *watchHistoryUsecase) History(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) (*domain.MultipleWatchEntryResponse, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	if opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	opts.Sort = domain.Sort{Field: domain.SortWatchedAt, Desc: true}
+	limit, err := openPage(&opts)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := u.repo.Find(ctx, userID, opts)
+	if err != nil {
+		return nil, err
+	}
+	entries, next := closePage(entries, limit, opts.Sort, func(e domain.WatchEntry) (string, uuid.UUID) {
+		return e.WatchedAt.Format(time.RFC3339Nano), e.VideoID
+	})
+	return &domain.MultipleWatchEntryResponse{Entries: entries, NextPageToken: next}, nil
+}
+
+func (u *watchHistoryUsecase) Clear(ctx context.Context, userID uuid.UUID, videoID string) error {
+	id := uuid.Nil
+	if videoID != "" {
+		var err error
+		if id, err = uuid.Parse(videoID); err != nil {
+			return errInvalidVideoID
+		}
+	}
+	// The buffer goes first, so a flush that saves the entries after the
+	// rows are deleted sees that they were cleared and deletes them again.
+	if err := u.buffer.Clear(ctx, userID, id, u.now()); err != nil {
+		return err
+	}
+	return u.repo.Clear(ctx, userID, id)
+}
+
+func (u *watchHistoryUsecase) Flush(ctx context.Context) (int, error) {
+	entries, err := u.buffer.TakePending(ctx)
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+	if err := u.repo.Save(ctx, entries); err != nil {
+		if restoreErr := u.buffer.RestorePending(ctx, entries); restoreErr != nil {
+			slog.ErrorContext(ctx, "pending watch progress lost", "entries", len(entries), "error", restoreErr)
+		}
+		return 0, err
+	}
+	// A Clear may have run while the entries were being saved; the rows it
+	// missed are deleted here.
+	cleared, err := u.buffer.Cleared(ctx, entries)
+	if err != nil {
+		return len(entries), err
+	}
+	for i, entry := range entries {
+		if !cleared[i] {
+			continue
+		}
+		if err := u.repo.Clear(ctx, entry.UserID, entry.VideoID); err != nil {
+			return len(entries), err
+		}
+	}
+	return len(entries), nil
+}
+
+func (u *watchHistoryUsecase) RunFlusher(ctx context.Context) {
+	runFlusher(ctx, "watch_history", u.flushInterval, func(ctx context.Context) error {
+		_, err := u.Flush(ctx)
+		return err
+	})
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+type memWatchHistory struct {
+	mu     sync.Mutex
+	rows   map[[2]uuid.UUID]domain.WatchEntry
+	onSave func()
+}
+
+func (m *memWatchHistory) Save(ctx context.Context, entries []domain.WatchEntry) error {
+	if m.onSave != nil {
+		m.onSave()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range entries {
+		m.rows[[2]uuid.UUID{e.UserID, e.VideoID}] = e
+	}
+	return nil
+}
+
+func (m *memWatchHistory) Get(ctx context.Context, userID, videoID uuid.UUID) (*domain.WatchEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.rows[[2]uuid.UUID{userID, videoID}]; ok {
+		return &e, nil
+	}
+	return nil, nil
+}
+
+func (m *memWatchHistory) Find(ctx context.Context, userID uuid.UUID, opts domain.BaseFetchOptions) ([]domain.WatchEntry, error) {
+	return nil, nil
+}
+
+func (m *memWatchHistory) Clear(ctx context.Context, userID, videoID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.rows {
+		if key[0] == userID && (videoID == uuid.Nil || key[1] == videoID) {
+			delete(m.rows, key)
+		}
+	}
+	return nil
+}
+
+type memWatchBuffer struct {
+	mu      sync.Mutex
+	pending []domain.WatchEntry
+	cleared map[[2]uuid.UUID]time.Time
+}
+
+func (m *memWatchBuffer) Put(ctx context.Context, entry *domain.WatchEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = append(m.pending, *entry)
+	return nil
+}
+
+func (m *memWatchBuffer) Get(ctx context.Context, userID, videoID uuid.UUID) (*domain.WatchEntry, error) {
+	return nil, nil
+}
+
+func (m *memWatchBuffer) TakePending(ctx context.Context) ([]domain.WatchEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := m.pending
+	m.pending = nil
+	return entries, nil
+}
+
+func (m *memWatchBuffer) RestorePending(ctx context.Context, entries []domain.WatchEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = append(m.pending, entries...)
+	return nil
+}
+
+func (m *memWatchBuffer) Clear(ctx context.Context, userID, videoID uuid.UUID, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cleared[[2]uuid.UUID{userID, videoID}] = at
+	kept := m.pending[:0]
+	for _, e := range m.pending {
+		if e.UserID != userID || (videoID != uuid.Nil && e.VideoID != videoID) {
+			kept = append(kept, e)
+		}
+	}
+	m.pending = kept
+	return nil
+}
+
+func (m *memWatchBuffer) Cleared(ctx context.Context, entries []domain.WatchEntry) ([]bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cleared := make([]bool, len(entries))
+	for i, e := range entries {
+		for _, videoID := range []uuid.UUID{e.VideoID, uuid.Nil} {
+			if at, ok := m.cleared[[2]uuid.UUID{e.UserID, videoID}]; ok && !at.Before(e.WatchedAt) {
+				cleared[i] = true
+			}
+		}
+	}
+	return cleared, nil
+}
+
+func TestWatchHistoryClearDuringFlush(t *testing.T) {
+	userID, videoID := uuid.New(), uuid.New()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		clearID  string
+		reported time.Duration
+		want     bool
+	}{
+		{name: "video cleared", clearID: videoID.String(), want: false},
+		{name: "history cleared", clearID: "", want: false},
+		{name: "other video cleared", clearID: uuid.NewString(), want: true},
+		{name: "watched after the clear", clearID: videoID.String(), reported: time.Minute, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memWatchHistory{rows: map[[2]uuid.UUID]domain.WatchEntry{}}
+			buffer := &memWatchBuffer{cleared: map[[2]uuid.UUID]time.Time{}}
+			u := &watchHistoryUsecase{repo: repo, buffer: buffer, now: func() time.Time { return start }}
+			ctx := context.Background()
+
+			buffer.Put(ctx, &domain.WatchEntry{UserID: userID, VideoID: videoID, Position: 42, WatchedAt: start.Add(tt.reported - time.Second)})
+			// The entry has been taken from the buffer when the user clears
+			// their history, and is saved after the rows were deleted.
+			repo.onSave = func() {
+				repo.onSave = nil
+				if err := u.Clear(ctx, userID, tt.clearID); err != nil {
+					t.Fatalf("Clear: %v", err)
+				}
+			}
+			if _, err := u.Flush(ctx); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			entry, _ := repo.Get(ctx, userID, videoID)
+			if got := entry != nil; got != tt.want {
+				t.Errorf("entry kept = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
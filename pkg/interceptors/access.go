@@ -0,0 +1,54 @@
+package interceptors
+
+// Access says whether an RPC needs an authenticated caller.
+type Access int
+
+const (
+	// Authenticated methods reject calls without valid credentials. It is the
+	// default for methods missing from MethodAccess.
+	Authenticated Access = iota
+	// Public methods never look at credentials.
+	Public
+	// OptionalAuth methods run anonymously when no credentials are sent, and
+	// authenticate the caller when they are, so the handler can personalize
+	// its response. Invalid credentials are still rejected.
+	OptionalAuth
+)
+
+// MethodAccess lists every RPC that does not require authentication. Methods
+// are matched by their full name, so adding an RPC never makes it public by
+// accident.
+var MethodAccess = map[string]Access{
+	"/gostream.auth.v1.AuthService/Login":             Public,
+	"/gostream.auth.v1.AuthService/Register":          Public,
+	"/gostream.auth.v1.AuthService/VerifyMFA":         Public,
+	"/gostream.auth.v1.AuthService/ListOIDCProviders": Public,
+	"/gostream.auth.v1.AuthService/StartOIDCLogin":    Public,
+	"/gostream.auth.v1.AuthService/CompleteOIDCLogin": Public,
+
+	"/gostream.user.v1.UserService/VerifyEmail": Public,
+
+	"/gostream.video.v1.VideoService/GetVideos":   OptionalAuth,
+	"/gostream.video.v1.VideoService/GetVideo":    OptionalAuth,
+	"/gostream.video.v1.VideoService/ListTags":    OptionalAuth,
+	"/gostream.video.v1.VideoService/RecordView":  OptionalAuth,
+	"/gostream.video.v1.VideoService/GetTrending": OptionalAuth,
+	"/gostream.video.v1.VideoService/GetPopular":  OptionalAuth,
+
+	"/gostream.playlist.v1.PlaylistService/GetPlaylist":   OptionalAuth,
+	"/gostream.playlist.v1.PlaylistService/ListPlaylists": OptionalAuth,
+	"/gostream.playlist.v1.PlaylistService/PlayPlaylist":  OptionalAuth,
+
+	"/gostream.comment.v1.CommentService/ListComments": OptionalAuth,
+
+	"/gostream.analytics.v1.AnalyticsService/IngestPlaybackEvents": OptionalAuth,
+
+	"/gostream.recommendation.v1.RecommendationService/GetRelatedVideos": OptionalAuth,
+}
+
+func methodAccess(fullMethod string) Access {
+	if access, ok := MethodAccess[fullMethod]; ok {
+		return access
+	}
+	return Authenticated
+}
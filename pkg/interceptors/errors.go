@@ -0,0 +1,37 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/hunderaweke/gostream/internal/apierror"
+	"google.golang.org/grpc"
+)
+
+// ErrorInterceptor turns the errors returned by handlers into gRPC statuses
+// with errdetails, hiding anything that is not a domain error. See
+// apierror.Status.
+type ErrorInterceptor struct {
+}
+
+func NewErrorInterceptor() *ErrorInterceptor {
+	return &ErrorInterceptor{}
+}
+
+func (i *ErrorInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, apierror.Status(ctx, info.FullMethod, err).Err()
+		}
+		return resp, nil
+	}
+}
+
+func (i *ErrorInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return apierror.Status(ss.Context(), info.FullMethod, err).Err()
+		}
+		return nil
+	}
+}
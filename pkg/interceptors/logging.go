@@ -0,0 +1,126 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hunderaweke/gostream/internal/logging"
+	"github.com/hunderaweke/gostream/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDMetadataKey = "x-request-id"
+
+type LoggingInterceptor struct {
+}
+
+func NewLoggingInterceptor() *LoggingInterceptor {
+	return &LoggingInterceptor{}
+}
+
+// clientIP returns the caller's address. Calls relayed by the in-process
+// gateway arrive over loopback, so for those the last x-forwarded-for entry,
+// which the gateway appends from the HTTP connection, is used instead.
+func clientIP(ctx context.Context) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if parsed := net.ParseIP(ip); parsed == nil || !parsed.IsLoopback() {
+		return ip
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			if forwarded := strings.TrimSpace(hops[len(hops)-1]); net.ParseIP(forwarded) != nil {
+				return forwarded
+			}
+		}
+	}
+	return ip
+}
+
+// userAgent prefers the browser's user agent forwarded by the gateway over
+// the gRPC client's own.
+func userAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// requestContext attaches a request ID (taken from the x-request-id metadata
+// forwarded by the gateway, or freshly generated), the client IP and the user
+// agent to ctx.
+func requestContext(ctx context.Context) (context.Context, string) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 && logging.ValidRequestID(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	ctx = utils.SetRequestID(ctx, requestID)
+	ctx = utils.SetClientIP(ctx, clientIP(ctx))
+	ctx = utils.SetUserAgent(ctx, userAgent(ctx))
+	return ctx, requestID
+}
+
+func logCall(ctx context.Context, fullMethod string, start time.Time, err error) {
+	attrs := []any{
+		"method", fullMethod,
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "grpc request failed", append(attrs, "error", err)...)
+	} else {
+		slog.InfoContext(ctx, "grpc request", attrs...)
+	}
+}
+
+// Unary sets up the request context, echoes the request ID in the response
+// header and logs the outcome of the call.
+func (i *LoggingInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		ctx, requestID := requestContext(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+		resp, err = handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// Stream does the same as Unary for streaming RPCs, logging once the stream
+// ends.
+func (i *LoggingInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, requestID := requestContext(ss.Context())
+		ss.SetHeader(metadata.Pairs(requestIDMetadataKey, requestID))
+
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
@@ -0,0 +1,52 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hunderaweke/gostream/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type MetricsInterceptor struct {
+}
+
+func NewMetricsInterceptor() *MetricsInterceptor {
+	return &MetricsInterceptor{}
+}
+
+func (i *MetricsInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		resp, err = handler(ctx, req)
+		observeCall(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// Stream records streaming RPCs once the stream ends.
+func (i *MetricsInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeCall(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeCall(fullMethod string, start time.Time, err error) {
+	service, method := splitMethodName(fullMethod)
+	metrics.GRPCHandlingSeconds.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+	metrics.GRPCHandledTotal.WithLabelValues(service, method, status.Code(err).String()).Inc()
+}
+
+// splitMethodName turns "/pkg.Service/Method" into ("pkg.Service", "Method").
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
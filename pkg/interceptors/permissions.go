@@ -0,0 +1,53 @@
+package interceptors
+
+import "github.com/hunderaweke/gostream/internal/domain"
+
+// MethodPermissions lists the permission the caller's role must grant for each
+// authenticated RPC. Authenticated methods missing from the map only require a
+// valid access token.
+var MethodPermissions = map[string]domain.Permission{
+	"/gostream.video.v1.VideoService/GetVideos":      domain.PermVideosRead,
+	"/gostream.video.v1.VideoService/GetVideo":       domain.PermVideosRead,
+	"/gostream.video.v1.VideoService/CreateVideo":    domain.PermVideosWrite,
+	"/gostream.video.v1.VideoService/CompleteUpload": domain.PermVideosWrite,
+	"/gostream.video.v1.VideoService/UpdateVideo":    domain.PermVideosWrite,
+	"/gostream.video.v1.VideoService/ListTags":       domain.PermVideosRead,
+	"/gostream.video.v1.VideoService/RecordView":     domain.PermStream,
+	"/gostream.video.v1.VideoService/GetTrending":    domain.PermVideosRead,
+	"/gostream.video.v1.VideoService/GetPopular":     domain.PermVideosRead,
+
+	// Managing playlists only needs an account; playing one needs streaming.
+	"/gostream.playlist.v1.PlaylistService/GetPlaylist":   domain.PermVideosRead,
+	"/gostream.playlist.v1.PlaylistService/ListPlaylists": domain.PermVideosRead,
+	"/gostream.playlist.v1.PlaylistService/PlayPlaylist":  domain.PermStream,
+
+	"/gostream.comment.v1.CommentService/ListComments": domain.PermVideosRead,
+
+	// Reacting only needs an account; listing liked videos needs read access.
+	"/gostream.reaction.v1.ReactionService/ListMyLikedVideos": domain.PermVideosRead,
+
+	// Following channels only needs an account; reading the feed needs read
+	// access.
+	"/gostream.subscription.v1.SubscriptionService/GetSubscriptionFeed": domain.PermVideosRead,
+
+	// Reporting and reading playback progress comes from players; clearing
+	// the history only needs an account.
+	"/gostream.history.v1.HistoryService/ReportPlaybackProgress": domain.PermStream,
+	"/gostream.history.v1.HistoryService/GetResumePosition":      domain.PermStream,
+	"/gostream.history.v1.HistoryService/GetWatchHistory":        domain.PermVideosRead,
+
+	// Player events come with streaming; analytics are for creators.
+	"/gostream.analytics.v1.AnalyticsService/IngestPlaybackEvents": domain.PermStream,
+	"/gostream.analytics.v1.AnalyticsService/GetVideoAnalytics":    domain.PermVideosWrite,
+
+	"/gostream.recommendation.v1.RecommendationService/GetRelatedVideos":    domain.PermVideosRead,
+	"/gostream.recommendation.v1.RecommendationService/GetRecommendedForMe": domain.PermVideosRead,
+
+	"/gostream.admin.v1.AdminService/ListUsers":      domain.PermUsersManage,
+	"/gostream.admin.v1.AdminService/SetUserRole":    domain.PermUsersManage,
+	"/gostream.admin.v1.AdminService/DisableUser":    domain.PermUsersManage,
+	"/gostream.admin.v1.AdminService/EnableUser":     domain.PermUsersManage,
+	"/gostream.admin.v1.AdminService/DeleteUser":     domain.PermUsersManage,
+	"/gostream.admin.v1.AdminService/ListVideos":     domain.PermVideosModerate,
+	"/gostream.admin.v1.AdminService/SetVideoStatus": domain.PermVideosModerate,
+}
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -15,6 +14,10 @@ const (
 	RefreshToken         = TokenType("refresh")
 	AccessTokenDuration  = time.Hour * 4
 	RefreshTokenDuration = time.Hour * 10
+	// MFAChallengeToken is issued after a correct password for a user with
+	// 2FA enabled and can only be exchanged for tokens through VerifyMFA.
+	MFAChallengeToken         = TokenType("mfa")
+	MFAChallengeTokenDuration = time.Minute * 5
 )
 
 type TokenType string
@@ -22,33 +25,79 @@ type UserClaims struct {
 	jwt.RegisteredClaims
 	ID   uuid.UUID
 	Type TokenType
+	Role domain.Role
+	// SessionID binds access and refresh tokens to a revocable session.
+	SessionID uuid.UUID `json:"sid"`
 }
 
-func GenerateToken(user domain.User, tokenType TokenType) (string, error) {
-	if tokenType != AccessToken && tokenType != RefreshToken {
+// GenerateToken signs a token for user. sessionID is uuid.Nil for tokens that
+// do not belong to a session, such as MFA challenges.
+func GenerateToken(user domain.User, tokenType TokenType, sessionID uuid.UUID) (string, error) {
+	if tokenType != AccessToken && tokenType != RefreshToken && tokenType != MFAChallengeToken {
 		return "", fmt.Errorf("invalid token type: %q", tokenType)
 	}
-	expiresAt := time.Now().Add(AccessTokenDuration)
-	if tokenType == RefreshToken {
-		expiresAt = time.Now().Add(RefreshTokenDuration)
+	ks, err := currentKeySet()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenDuration)
+	switch tokenType {
+	case RefreshToken:
+		expiresAt = now.Add(RefreshTokenDuration)
+	case MFAChallengeToken:
+		expiresAt = now.Add(MFAChallengeTokenDuration)
+	}
+	claims := UserClaims{
+		Type:      tokenType,
+		ID:        user.ID,
+		Role:      user.Role,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			Issuer:    ks.Issuer,
+			Audience:  jwt.ClaimStrings{ks.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, UserClaims{Type: tokenType, ID: user.ID, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)}})
-	tokenStr, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	signingKey := ks.signingKey
+	if ks.secret != nil {
+		signingKey = ks.secret
+	} else {
+		token.Header["kid"] = ks.signingKID
+	}
+	tokenStr, err := token.SignedString(signingKey)
 	if err != nil {
 		return "", fmt.Errorf("error signing the token %v", err)
 	}
 	return tokenStr, nil
 }
 func ValidateToken(tokenStr string, tokenType string) (*UserClaims, error) {
+	ks, err := currentKeySet()
+	if err != nil {
+		return nil, err
+	}
 	var claims UserClaims
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("JWT_SECRET")), nil
-	})
+	parser := jwt.NewParser(jwt.WithValidMethods(ks.validMethods()))
+	token, err := parser.ParseWithClaims(tokenStr, &claims, ks.keyFunc)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing the token string: %v", err)
 	}
 	if !token.Valid || claims.Type != TokenType(tokenType) {
 		return nil, fmt.Errorf("invalid token")
 	}
+	if !claims.VerifyIssuer(ks.Issuer, true) || !claims.VerifyAudience(ks.Audience, true) {
+		return nil, fmt.Errorf("invalid token issuer or audience")
+	}
+	if claims.IssuedAt == nil || claims.RegisteredClaims.ID == "" {
+		return nil, fmt.Errorf("token is missing iat or jti")
+	}
+	if claims.Subject != claims.ID.String() {
+		return nil, fmt.Errorf("token subject does not match user id")
+	}
 	return &claims, nil
 }
@@ -0,0 +1,284 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	"github.com/hunderaweke/gostream/internal/domain"
+)
+
+// writeKey writes key as a PKCS#8 PEM file and returns its path.
+func writeKey(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newEd25519Key(t *testing.T) string {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeKey(t, private)
+}
+
+// setKeyEnv clears every key variable and sets the given ones.
+func setKeyEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, name := range []string{"JWT_SECRET", "JWT_SIGNING_KEY_FILE", "JWT_SIGNING_KEY_ID", "JWT_VERIFICATION_KEY_FILES", "JWT_ISSUER", "JWT_AUDIENCE"} {
+		t.Setenv(name, env[name])
+	}
+}
+
+// useKeySet installs the key set built from env for the rest of the test.
+func useKeySet(t *testing.T, env map[string]string) {
+	t.Helper()
+	setKeyEnv(t, env)
+	ks, err := LoadKeySetFromEnv()
+	if err != nil {
+		t.Fatalf("LoadKeySetFromEnv: %v", err)
+	}
+	SetKeySet(ks)
+	t.Cleanup(func() { SetKeySet(nil) })
+}
+
+func TestLoadKeySetFromEnv(t *testing.T) {
+	signing := newEd25519Key(t)
+	retired := newEd25519Key(t)
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallPath := writeKey(t, small)
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+		wantKID []string
+	}{
+		{name: "no key", env: map[string]string{}, wantErr: true},
+		{name: "secret", env: map[string]string{"JWT_SECRET": "s3cret"}},
+		{name: "signing key", env: map[string]string{"JWT_SIGNING_KEY_FILE": signing, "JWT_SIGNING_KEY_ID": "k1"}, wantKID: []string{"k1"}},
+		{name: "missing key id", env: map[string]string{"JWT_SIGNING_KEY_FILE": signing}, wantErr: true},
+		{name: "missing key file", env: map[string]string{"JWT_SIGNING_KEY_FILE": filepath.Join(t.TempDir(), "none.pem"), "JWT_SIGNING_KEY_ID": "k1"}, wantErr: true},
+		{name: "small rsa key", env: map[string]string{"JWT_SIGNING_KEY_FILE": smallPath, "JWT_SIGNING_KEY_ID": "k1"}, wantErr: true},
+		{
+			name:    "retired key",
+			env:     map[string]string{"JWT_SIGNING_KEY_FILE": signing, "JWT_SIGNING_KEY_ID": "k2", "JWT_VERIFICATION_KEY_FILES": "k1:" + retired},
+			wantKID: []string{"k1", "k2"},
+		},
+		{
+			name:    "verification entry without kid",
+			env:     map[string]string{"JWT_SIGNING_KEY_FILE": signing, "JWT_SIGNING_KEY_ID": "k2", "JWT_VERIFICATION_KEY_FILES": retired},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate kid",
+			env:     map[string]string{"JWT_SIGNING_KEY_FILE": signing, "JWT_SIGNING_KEY_ID": "k1", "JWT_VERIFICATION_KEY_FILES": "k1:" + retired},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setKeyEnv(t, tt.env)
+			ks, err := LoadKeySetFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(ks.verification) != len(tt.wantKID) {
+				t.Fatalf("got %d verification keys, want %d", len(ks.verification), len(tt.wantKID))
+			}
+			for _, kid := range tt.wantKID {
+				if _, ok := ks.verification[kid]; !ok {
+					t.Errorf("verification key %q missing", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	user := domain.User{Role: domain.RoleCreator}
+	user.ID = uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{name: "hs256", env: map[string]string{"JWT_SECRET": "s3cret"}},
+		{name: "eddsa", env: map[string]string{"JWT_SIGNING_KEY_FILE": newEd25519Key(t), "JWT_SIGNING_KEY_ID": "k1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useKeySet(t, tt.env)
+			token, err := GenerateToken(user, AccessToken, sessionID)
+			if err != nil {
+				t.Fatalf("GenerateToken: %v", err)
+			}
+			claims, err := ValidateToken(token, string(AccessToken))
+			if err != nil {
+				t.Fatalf("ValidateToken: %v", err)
+			}
+			if claims.ID != user.ID || claims.SessionID != sessionID || claims.Role != user.Role {
+				t.Errorf("claims = %+v, want user %s session %s", claims, user.ID, sessionID)
+			}
+		})
+	}
+}
+
+func TestValidateTokenRejects(t *testing.T) {
+	retired := newEd25519Key(t)
+	user := domain.User{Role: domain.RoleViewer}
+	user.ID = uuid.New()
+
+	// sign builds a token for user with the given claims changes, signed
+	// with method and key and carrying kid when it is not empty.
+	sign := func(t *testing.T, method jwt.SigningMethod, key any, kid string, change func(*UserClaims)) string {
+		t.Helper()
+		now := time.Now()
+		claims := UserClaims{
+			ID:   user.ID,
+			Type: AccessToken,
+			Role: user.Role,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   user.ID.String(),
+				Issuer:    "gostream",
+				Audience:  jwt.ClaimStrings{"gostream"},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+				ID:        uuid.NewString(),
+			},
+		}
+		if change != nil {
+			change(&claims)
+		}
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	retiredKey, err := readPrivateKey(retired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknownKey, err := readPrivateKey(newEd25519Key(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("s3cret")
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		tokType TokenType
+		wantErr bool
+	}{
+		{
+			name:  "retired key",
+			token: func(t *testing.T) string { return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", nil) },
+		},
+		{
+			name:    "unknown kid",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodEdDSA, unknownKey, "other", nil) },
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key under a known kid",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodEdDSA, unknownKey, "old", nil) },
+			wantErr: true,
+		},
+		{
+			name:    "hs256 in asymmetric mode",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodHS256, secret, "old", nil) },
+			wantErr: true,
+		},
+		{
+			name:    "wrong type",
+			token:   func(t *testing.T) string { return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", nil) },
+			tokType: RefreshToken,
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", func(c *UserClaims) { c.Issuer = "someone" })
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", func(c *UserClaims) { c.Audience = jwt.ClaimStrings{"other"} })
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", func(c *UserClaims) {
+					c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing jti",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", func(c *UserClaims) { c.RegisteredClaims.ID = "" })
+			},
+			wantErr: true,
+		},
+		{
+			name: "subject mismatch",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodEdDSA, retiredKey, "old", func(c *UserClaims) { c.Subject = uuid.NewString() })
+			},
+			wantErr: true,
+		},
+	}
+	useKeySet(t, map[string]string{
+		"JWT_SIGNING_KEY_FILE":       newEd25519Key(t),
+		"JWT_SIGNING_KEY_ID":         "new",
+		"JWT_VERIFICATION_KEY_FILES": "old:" + retired,
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokType := tt.tokType
+			if tokType == "" {
+				tokType = AccessToken
+			}
+			_, err := ValidateToken(tt.token(t), string(tokType))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
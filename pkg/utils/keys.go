@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// KeySet holds the key used to sign new tokens and every key accepted when
+// verifying them. Keeping retired keys in the verification set lets tokens
+// signed before a rotation stay valid until they expire.
+type KeySet struct {
+	signingKID    string
+	signingMethod jwt.SigningMethod
+	signingKey    any
+	verification  map[string]verificationKey
+	// secret is set instead of the asymmetric keys in legacy HS256 mode.
+	secret   []byte
+	Issuer   string
+	Audience string
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+}
+
+var (
+	keySetMu sync.RWMutex
+	keySet   *KeySet
+)
+
+// SetKeySet installs the key set used by GenerateToken and ValidateToken.
+func SetKeySet(ks *KeySet) {
+	keySetMu.Lock()
+	defer keySetMu.Unlock()
+	keySet = ks
+}
+
+func currentKeySet() (*KeySet, error) {
+	keySetMu.RLock()
+	ks := keySet
+	keySetMu.RUnlock()
+	if ks != nil {
+		return ks, nil
+	}
+	ks, err := LoadKeySetFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	SetKeySet(ks)
+	return ks, nil
+}
+
+// LoadKeySetFromEnv builds a key set from the environment:
+//
+//   - JWT_SIGNING_KEY_FILE: PEM private key (RSA or Ed25519) used for signing;
+//     when empty, tokens are signed with HS256 and JWT_SECRET
+//   - JWT_SIGNING_KEY_ID: kid of the signing key
+//   - JWT_VERIFICATION_KEY_FILES: comma separated kid:path pairs of PEM public
+//     (or private) keys that are still accepted, e.g. retired signing keys
+//   - JWT_ISSUER, JWT_AUDIENCE: iss and aud claims (default "gostream")
+func LoadKeySetFromEnv() (*KeySet, error) {
+	ks := &KeySet{
+		verification: map[string]verificationKey{},
+		Issuer:       envOrDefault("JWT_ISSUER", "gostream"),
+		Audience:     envOrDefault("JWT_AUDIENCE", "gostream"),
+	}
+	signingFile := os.Getenv("JWT_SIGNING_KEY_FILE")
+	if signingFile == "" {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return nil, fmt.Errorf("either JWT_SIGNING_KEY_FILE or JWT_SECRET must be set")
+		}
+		ks.secret = []byte(secret)
+		ks.signingMethod = jwt.SigningMethodHS256
+		return ks, nil
+	}
+
+	kid := os.Getenv("JWT_SIGNING_KEY_ID")
+	if kid == "" {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY_ID is required with JWT_SIGNING_KEY_FILE")
+	}
+	private, err := readPrivateKey(signingFile)
+	if err != nil {
+		return nil, err
+	}
+	method, public, err := methodForKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	ks.signingKID = kid
+	ks.signingMethod = method
+	ks.signingKey = private
+	ks.verification[kid] = verificationKey{method: method, public: public}
+
+	if pairs := os.Getenv("JWT_VERIFICATION_KEY_FILES"); pairs != "" {
+		for _, pair := range strings.Split(pairs, ",") {
+			kid, path, ok := strings.Cut(strings.TrimSpace(pair), ":")
+			if !ok || kid == "" || path == "" {
+				return nil, fmt.Errorf("invalid JWT_VERIFICATION_KEY_FILES entry %q, want kid:path", pair)
+			}
+			if _, exists := ks.verification[kid]; exists {
+				return nil, fmt.Errorf("duplicate key id %q", kid)
+			}
+			public, err := readPublicKey(path)
+			if err != nil {
+				return nil, err
+			}
+			method, public, err := methodForKey(public)
+			if err != nil {
+				return nil, err
+			}
+			ks.verification[kid] = verificationKey{method: method, public: public}
+		}
+	}
+	return ks, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key file: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key %s: %v", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(block.Type, "PRIVATE KEY") {
+		private, err := readPrivateKey(path)
+		if err != nil {
+			return nil, err
+		}
+		return private.Public(), nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key %s: %v", path, err)
+	}
+	return key, nil
+}
+
+func methodForKey(public crypto.PublicKey) (jwt.SigningMethod, crypto.PublicKey, error) {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return nil, nil, fmt.Errorf("rsa keys must be at least 2048 bits")
+		}
+		return jwt.SigningMethodRS256, key, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, key, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+}
+
+// keyFunc selects the verification key named by the token's kid header and
+// rejects tokens whose alg does not match that key.
+func (ks *KeySet) keyFunc(t *jwt.Token) (any, error) {
+	if ks.secret != nil {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return ks.secret, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ks.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	return key.public, nil
+}
+
+func (ks *KeySet) validMethods() []string {
+	if ks.secret != nil {
+		return []string{jwt.SigningMethodHS256.Alg()}
+	}
+	var methods []string
+	for _, key := range ks.verification {
+		methods = append(methods, key.method.Alg())
+	}
+	return methods
+}
+
+// JWK is a public key in JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWKS returns every verification key of the current key set. It is
+// empty in HS256 mode, where the secret cannot be published.
+func PublicJWKS() (JWKS, error) {
+	ks, err := currentKeySet()
+	if err != nil {
+		return JWKS{}, err
+	}
+	set := JWKS{Keys: []JWK{}}
+	for kid, key := range ks.verification {
+		jwk := JWK{Use: "sig", Alg: key.method.Alg(), Kid: kid}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set, nil
+}
@@ -0,0 +1,52 @@
+package utils
+
+import "context"
+
+const requestIDKey contextKey = "request_id"
+
+func SetRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// GetRequestID returns the request ID stored in ctx, or "" if there is none.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+const (
+	clientIPKey  contextKey = "client_ip"
+	userAgentKey contextKey = "user_agent"
+	sessionIDKey contextKey = "session_id"
+)
+
+func SetClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// GetClientIP returns the caller's IP address stored in ctx, or "" if unknown.
+func GetClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+func SetUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentKey, userAgent)
+}
+
+// GetUserAgent returns the caller's user agent stored in ctx, or "" if unknown.
+func GetUserAgent(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey).(string)
+	return userAgent
+}
+
+func SetSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+// GetSessionID returns the session of the authenticated caller, or "" for
+// API key callers and unauthenticated requests.
+func GetSessionID(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionIDKey).(string)
+	return sessionID
+}
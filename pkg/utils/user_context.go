@@ -3,11 +3,16 @@ package utils
 import (
 	"context"
 	"fmt"
+
+	"github.com/hunderaweke/gostream/internal/domain"
 )
 
 type contextKey string
 
-const userIDKey contextKey = "user_id"
+const (
+	userIDKey   contextKey = "user_id"
+	userRoleKey contextKey = "user_role"
+)
 
 func SetUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, userIDKey, userID)
@@ -22,3 +27,13 @@ func GetUserID(ctx context.Context) (string, error) {
 	}
 	return userID, nil
 }
+
+func SetUserRole(ctx context.Context, role domain.Role) context.Context {
+	return context.WithValue(ctx, userRoleKey, role)
+}
+
+// GetUserRole returns the role of the authenticated caller, or "" if unknown.
+func GetUserRole(ctx context.Context) domain.Role {
+	role, _ := ctx.Value(userRoleKey).(domain.Role)
+	return role
+}